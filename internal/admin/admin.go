@@ -0,0 +1,91 @@
+// Package admin implements the runtime cache and circuit-breaker operations
+// an operator needs during an upstream incident: inspecting the caches,
+// purging entries that are known to be wrong, and resetting or forcing open
+// a registry's circuit breaker. They are served as the /admin HTTP endpoints
+// and, when enabled, as the admin_* MCP tools; both are off by default and
+// need their own token.
+package admin
+
+import (
+	"strings"
+
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
+)
+
+// Target is one registry client as the admin operations see it.
+type Target struct {
+	Registry   string                // Name used in arguments and results, e.g. "norway"
+	Cache      *infra.Cache          // Response cache
+	Breaker    *infra.CircuitBreaker // Circuit breaker in front of the registry
+	Invalidate func(identifier string)
+}
+
+// Console runs admin operations over a fixed set of targets.
+type Console struct {
+	targets []Target
+}
+
+// NewConsole creates a console over targets, reported in the order given.
+// Targets without a cache or breaker are left out, so an unconfigured client
+// can be passed as is.
+func NewConsole(targets ...Target) *Console {
+	c := &Console{}
+	for _, t := range targets {
+		if t.Cache != nil && t.Breaker != nil {
+			c.targets = append(c.targets, t)
+		}
+	}
+	return c
+}
+
+// Registries returns the names of the console's targets.
+func (c *Console) Registries() []string {
+	names := make([]string, len(c.targets))
+	for i, t := range c.targets {
+		names[i] = t.Registry
+	}
+	return names
+}
+
+// selectTargets returns the target named registry, or all targets when
+// registry is empty.
+func (c *Console) selectTargets(registry string) ([]Target, error) {
+	registry = strings.ToLower(strings.TrimSpace(registry))
+	if registry == "" {
+		return c.targets, nil
+	}
+	for _, t := range c.targets {
+		if t.Registry == registry {
+			return []Target{t}, nil
+		}
+	}
+	return nil, apierrors.NewValidationError("registry", registry, "must be one of "+strings.Join(c.Registries(), ", "))
+}
+
+// cacheStats reports on the cache of t.
+func cacheStats(t Target) CacheStats {
+	negative := t.Cache.NegativeStats()
+	return CacheStats{
+		Registry:        t.Registry,
+		Entries:         t.Cache.Size(),
+		NegativeEntries: negative.Entries,
+		NegativeHits:    negative.Hits,
+		NegativeStores:  negative.Stores,
+	}
+}
+
+// circuitStats reports on the circuit breaker of t.
+func circuitStats(t Target) CircuitStats {
+	stats := t.Breaker.Stats()
+	out := CircuitStats{
+		Registry:            t.Registry,
+		State:               stats.State,
+		Forced:              stats.Forced,
+		ConsecutiveFailures: stats.ConsecutiveFails,
+	}
+	if !stats.LastFailure.IsZero() {
+		out.LastFailure = stats.LastFailure.UTC().Format("2006-01-02T15:04:05Z")
+	}
+	return out
+}
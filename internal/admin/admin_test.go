@@ -0,0 +1,120 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
+)
+
+func newTestConsole(t *testing.T) (*Console, *infra.Cache, *infra.CircuitBreaker) {
+	t.Helper()
+	cache := infra.NewCache(100)
+	t.Cleanup(cache.Close)
+	breaker := infra.NewCircuitBreakerWithConfig(3, time.Minute, 1)
+	invalidate := func(id string) { cache.Delete("company:" + id) }
+	c := NewConsole(
+		Target{Registry: "norway", Cache: cache, Breaker: breaker, Invalidate: invalidate},
+		Target{Registry: "sweden"}, // Not configured
+	)
+	return c, cache, breaker
+}
+
+func TestNewConsole_SkipsUnconfiguredTargets(t *testing.T) {
+	c, _, _ := newTestConsole(t)
+	if got := c.Registries(); len(got) != 1 || got[0] != "norway" {
+		t.Errorf("Registries() = %v, want [norway]", got)
+	}
+	if _, err := c.CacheStatsMCP(context.Background(), CacheStatsArgs{Registry: "sweden"}); !apierrors.IsValidation(err) {
+		t.Errorf("stats of unconfigured registry: err = %v, want a validation error", err)
+	}
+}
+
+func TestPurgeCacheMCP(t *testing.T) {
+	ctx := context.Background()
+	c, cache, _ := newTestConsole(t)
+	fill := func() {
+		cache.Set("company:923609016", "a", time.Minute)
+		cache.Set("company:974760673", "b", time.Minute)
+		cache.Set("search:equinor:1", "c", time.Minute)
+	}
+
+	tests := []struct {
+		name string
+		args PurgeCacheArgs
+		want int64
+	}{
+		{"identifier", PurgeCacheArgs{Registry: "norway", Identifier: "923609016"}, 2},
+		{"prefix", PurgeCacheArgs{Registry: "Norway", Prefix: "company:"}, 1},
+		{"all", PurgeCacheArgs{Registry: "norway", All: true}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fill()
+			got, err := c.PurgeCacheMCP(ctx, tt.args)
+			if err != nil {
+				t.Fatalf("PurgeCacheMCP: %v", err)
+			}
+			if got.Entries != tt.want {
+				t.Errorf("entries = %d, want %d", got.Entries, tt.want)
+			}
+		})
+	}
+
+	for _, args := range []PurgeCacheArgs{
+		{Identifier: "923609016"},
+		{Registry: "iceland", All: true},
+		{Registry: "norway"},
+		{Registry: "norway", Prefix: "search:", All: true},
+	} {
+		if _, err := c.PurgeCacheMCP(ctx, args); !apierrors.IsValidation(err) {
+			t.Errorf("PurgeCacheMCP(%+v): err = %v, want a validation error", args, err)
+		}
+	}
+}
+
+func TestSetCircuitMCP(t *testing.T) {
+	ctx := context.Background()
+	c, _, breaker := newTestConsole(t)
+
+	got, err := c.SetCircuitMCP(ctx, SetCircuitArgs{Registry: "norway", Action: "open"})
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if got.Circuit.State != "open" || !got.Circuit.Forced || breaker.Allow() {
+		t.Errorf("after open: %+v, allow = %v", got.Circuit, breaker.Allow())
+	}
+
+	got, err = c.SetCircuitMCP(ctx, SetCircuitArgs{Registry: "norway", Action: "reset"})
+	if err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	if got.Circuit.State != "closed" || got.Circuit.Forced || !breaker.Allow() {
+		t.Errorf("after reset: %+v", got.Circuit)
+	}
+
+	_, err = c.SetCircuitMCP(ctx, SetCircuitArgs{Registry: "norway", Action: "close"})
+	var verr *apierrors.ValidationError
+	if !errors.As(err, &verr) || verr.Field != "action" {
+		t.Errorf("unknown action: err = %v, want a validation error on action", err)
+	}
+}
+
+func TestCircuitStatusMCP(t *testing.T) {
+	c, _, breaker := newTestConsole(t)
+	breaker.RecordFailure()
+
+	got, err := c.CircuitStatusMCP(context.Background(), CircuitStatusArgs{})
+	if err != nil {
+		t.Fatalf("CircuitStatusMCP: %v", err)
+	}
+	if len(got.Circuits) != 1 {
+		t.Fatalf("circuits = %+v, want one", got.Circuits)
+	}
+	if s := got.Circuits[0]; s.State != "closed" || s.ConsecutiveFailures != 1 || s.LastFailure == "" {
+		t.Errorf("circuit = %+v", s)
+	}
+}
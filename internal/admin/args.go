@@ -0,0 +1,95 @@
+package admin
+
+// Tag convention: see internal/norway/args.go. The jsonschema tag value is the
+// property description; a field is required unless its json tag has omitempty.
+
+// Circuit actions accepted by SetCircuitArgs.Action.
+const (
+	ActionReset = "reset" // Close the circuit and forget its failures
+	ActionOpen  = "open"  // Reject calls to the registry until reset
+)
+
+// CacheStats reports on one registry's response cache.
+type CacheStats struct {
+	Registry        string `json:"registry" jsonschema:"Registry the cache belongs to"`
+	Entries         int64  `json:"entries" jsonschema:"Responses held in memory, fresh or kept to fall back on"`
+	NegativeEntries int64  `json:"negative_entries" jsonschema:"Not-found and validation outcomes remembered"`
+	NegativeHits    int64  `json:"negative_hits" jsonschema:"Calls answered by a remembered outcome since startup"`
+	NegativeStores  int64  `json:"negative_stores" jsonschema:"Outcomes remembered since startup"`
+}
+
+// CircuitStats reports on one registry's circuit breaker.
+type CircuitStats struct {
+	Registry            string `json:"registry" jsonschema:"Registry the circuit breaker guards"`
+	State               string `json:"state" jsonschema:"closed, open or half-open"`
+	Forced              bool   `json:"forced,omitempty" jsonschema:"True when the circuit was opened by an operator and stays open until reset"`
+	ConsecutiveFailures int    `json:"consecutive_failures" jsonschema:"Failed calls in a row"`
+	LastFailure         string `json:"last_failure,omitempty" jsonschema:"When the last call failed, RFC 3339"`
+}
+
+// CacheStatsArgs contains parameters for listing cache statistics
+type CacheStatsArgs struct {
+	Registry string `json:"registry,omitempty" jsonschema:"Only this registry: norway, denmark, finland, sweden or vies. Omit for all"`
+}
+
+// CacheStatsResult is the result of listing cache statistics
+type CacheStatsResult struct {
+	Caches []CacheStats `json:"caches" jsonschema:"One entry per registry"`
+}
+
+// PurgeCacheArgs contains parameters for purging cache entries. Exactly one
+// of Identifier, Prefix and All is set.
+type PurgeCacheArgs struct {
+	Registry   string `json:"registry" jsonschema:"Registry whose cache to purge: norway, denmark, finland, sweden or vies"`
+	Identifier string `json:"identifier,omitempty" jsonschema:"Drop everything cached for this company: an organization number, CVR, P-number, business ID or, for vies, a VAT number with its country prefix"`
+	Prefix     string `json:"prefix,omitempty" jsonschema:"Drop every entry whose cache key starts with this, e.g. search: for all cached searches"`
+	All        bool   `json:"all,omitempty" jsonschema:"Drop the registry's whole cache, including the disk cache"`
+}
+
+// PurgeCacheResult is the result of purging cache entries
+type PurgeCacheResult struct {
+	Registry string `json:"registry" jsonschema:"Registry whose cache was purged"`
+	Purged   string `json:"purged" jsonschema:"What was dropped, e.g. identifier 923609016"`
+	Entries  int64  `json:"entries" jsonschema:"Responses held in memory after the purge"`
+}
+
+// CircuitStatusArgs contains parameters for listing circuit breakers
+type CircuitStatusArgs struct {
+	Registry string `json:"registry,omitempty" jsonschema:"Only this registry: norway, denmark, finland, sweden or vies. Omit for all"`
+}
+
+// CircuitStatusResult is the result of listing circuit breakers
+type CircuitStatusResult struct {
+	Circuits []CircuitStats `json:"circuits" jsonschema:"One entry per registry"`
+}
+
+// SetCircuitArgs contains parameters for resetting or opening a circuit
+type SetCircuitArgs struct {
+	Registry string `json:"registry" jsonschema:"Registry whose circuit breaker to change: norway, denmark, finland, sweden or vies"`
+	Action   string `json:"action" jsonschema:"reset to close the circuit and forget its failures, or open to reject calls to the registry until reset; while open, tools that can answer from stale cache entries do so"`
+}
+
+// SetCircuitResult is the result of resetting or opening a circuit
+type SetCircuitResult struct {
+	Circuit CircuitStats `json:"circuit" jsonschema:"The circuit breaker after the change"`
+}
+
+// LogAttrs returns structured-log attributes for the cache statistics request.
+func (a CacheStatsArgs) LogAttrs() []any { return []any{"registry", a.Registry} }
+
+// LogAttrs returns structured-log attributes for the cache purge request.
+func (a PurgeCacheArgs) LogAttrs() []any {
+	return []any{"registry", a.Registry, "identifier", a.Identifier, "prefix", a.Prefix, "all", a.All}
+}
+
+// LogAttrs returns structured-log attributes for the cache purge result.
+func (r PurgeCacheResult) LogAttrs() []any { return []any{"purged", r.Purged} }
+
+// LogAttrs returns structured-log attributes for the circuit status request.
+func (a CircuitStatusArgs) LogAttrs() []any { return []any{"registry", a.Registry} }
+
+// LogAttrs returns structured-log attributes for the circuit change request.
+func (a SetCircuitArgs) LogAttrs() []any { return []any{"registry", a.Registry, "action", a.Action} }
+
+// LogAttrs returns structured-log attributes for the circuit change result.
+func (r SetCircuitResult) LogAttrs() []any { return []any{"state", r.Circuit.State} }
@@ -0,0 +1,96 @@
+package admin
+
+import (
+	"context"
+	"strings"
+
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+)
+
+// CacheStatsMCP lists the cache statistics of one registry or all of them.
+func (c *Console) CacheStatsMCP(_ context.Context, args CacheStatsArgs) (CacheStatsResult, error) {
+	targets, err := c.selectTargets(args.Registry)
+	if err != nil {
+		return CacheStatsResult{}, err
+	}
+	out := CacheStatsResult{Caches: make([]CacheStats, len(targets))}
+	for i, t := range targets {
+		out.Caches[i] = cacheStats(t)
+	}
+	return out, nil
+}
+
+// PurgeCacheMCP drops the entries of one registry's cache cached for an
+// identifier, under a key prefix, or all of them. Remembered not-found
+// outcomes go with them, in memory and on disk.
+func (c *Console) PurgeCacheMCP(_ context.Context, args PurgeCacheArgs) (PurgeCacheResult, error) {
+	if strings.TrimSpace(args.Registry) == "" {
+		return PurgeCacheResult{}, apierrors.NewValidationError("registry", "", "is required")
+	}
+	targets, err := c.selectTargets(args.Registry)
+	if err != nil {
+		return PurgeCacheResult{}, err
+	}
+	t := targets[0]
+
+	identifier := strings.TrimSpace(args.Identifier)
+	set := 0
+	for _, given := range []bool{identifier != "", args.Prefix != "", args.All} {
+		if given {
+			set++
+		}
+	}
+	if set != 1 {
+		return PurgeCacheResult{}, apierrors.NewValidationError("identifier", identifier, "give exactly one of identifier, prefix and all")
+	}
+
+	out := PurgeCacheResult{Registry: t.Registry}
+	switch {
+	case identifier != "":
+		t.Invalidate(identifier)
+		out.Purged = "identifier " + identifier
+	case args.Prefix != "":
+		t.Cache.DeletePrefix(args.Prefix)
+		out.Purged = "prefix " + args.Prefix
+	default:
+		t.Cache.DeletePrefix("")
+		out.Purged = "all"
+	}
+	out.Entries = t.Cache.Size()
+	return out, nil
+}
+
+// CircuitStatusMCP lists the circuit breakers of one registry or all of them.
+func (c *Console) CircuitStatusMCP(_ context.Context, args CircuitStatusArgs) (CircuitStatusResult, error) {
+	targets, err := c.selectTargets(args.Registry)
+	if err != nil {
+		return CircuitStatusResult{}, err
+	}
+	out := CircuitStatusResult{Circuits: make([]CircuitStats, len(targets))}
+	for i, t := range targets {
+		out.Circuits[i] = circuitStats(t)
+	}
+	return out, nil
+}
+
+// SetCircuitMCP resets or forces open one registry's circuit breaker.
+func (c *Console) SetCircuitMCP(_ context.Context, args SetCircuitArgs) (SetCircuitResult, error) {
+	if strings.TrimSpace(args.Registry) == "" {
+		return SetCircuitResult{}, apierrors.NewValidationError("registry", "", "is required")
+	}
+	targets, err := c.selectTargets(args.Registry)
+	if err != nil {
+		return SetCircuitResult{}, err
+	}
+	t := targets[0]
+
+	switch strings.ToLower(strings.TrimSpace(args.Action)) {
+	case ActionReset:
+		t.Breaker.Reset()
+	case ActionOpen:
+		t.Breaker.ForceOpen()
+	default:
+		return SetCircuitResult{}, apierrors.NewValidationError("action", args.Action, "must be reset or open")
+	}
+	return SetCircuitResult{Circuit: circuitStats(t)}, nil
+}
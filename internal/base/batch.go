@@ -0,0 +1,122 @@
+package base
+
+import (
+	"context"
+
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
+)
+
+// Reasons reported in BatchItemError.Reason.
+const (
+	BatchReasonNotFound = "not_found"
+	BatchReasonInvalid  = "invalid"
+	BatchReasonUpstream = "upstream_error"
+)
+
+// BatchItemError explains why one identifier in a batch lookup produced no
+// company. ID keeps the caller's spelling so it can be matched to the input.
+type BatchItemError struct {
+	ID     string `json:"id" jsonschema:"Identifier as given by the caller"`
+	Reason string `json:"reason" jsonschema:"Why the lookup failed: not_found, invalid or upstream_error"`
+	Error  string `json:"error,omitempty" jsonschema:"Error detail for invalid and upstream_error"`
+}
+
+// BatchSpec describes how to look up one identifier for BatchLookup.
+type BatchSpec[T any] struct {
+	// Workers bounds concurrent lookups. Pass the client's Concurrency() so
+	// the pool never outgrows the semaphore every request already acquires.
+	Workers int
+
+	// Normalize maps an identifier to its canonical form; inputs that
+	// normalize to the same value are looked up once.
+	Normalize func(id string) string
+
+	// Validate rejects malformed identifiers before any request is made.
+	Validate func(id string) error
+
+	// Fetch looks up one normalized identifier.
+	Fetch func(ctx context.Context, id string) (T, error)
+}
+
+// BatchResult holds the outcome of BatchLookup.
+type BatchResult[T any] struct {
+	Found   []T              // In input order, one per distinct identifier
+	Errors  []BatchItemError // One per input that produced no company
+	Missing []string         // Caller-form IDs with no company, as in Errors
+}
+
+// BatchLookup fans ids out over spec.Fetch with a bounded worker pool and
+// collects per-item outcomes. It never fails as a whole: invalid input,
+// not-found and upstream errors are reported per identifier, so one bad
+// entry in a customer list does not hide the rest. Each finished lookup is
+// reported as progress through ctx (see infra.WithProgress).
+func BatchLookup[T any](ctx context.Context, ids []string, spec BatchSpec[T]) BatchResult[T] {
+	type outcome struct {
+		value T
+		err   error
+	}
+
+	// Validate and deduplicate up front so each distinct identifier costs
+	// one request regardless of how often the caller repeats it.
+	normalized := make([]string, len(ids))
+	invalid := make([]error, len(ids))
+	index := make(map[string]int)
+	var unique []string
+	for i, id := range ids {
+		if err := spec.Validate(id); err != nil {
+			invalid[i] = err
+			continue
+		}
+		normalized[i] = spec.Normalize(id)
+		if _, ok := index[normalized[i]]; !ok {
+			index[normalized[i]] = len(unique)
+			unique = append(unique, normalized[i])
+		}
+	}
+
+	outcomes := make([]outcome, len(unique))
+	progress := infra.NewProgressCounter(ctx, len(unique), "lookups")
+	infra.RunBounded(ctx, len(unique), spec.Workers, func(ctx context.Context, i int) {
+		v, err := spec.Fetch(ctx, unique[i])
+		outcomes[i] = outcome{value: v, err: err}
+		progress.Add(1)
+	})
+
+	res := BatchResult[T]{Found: make([]T, 0, len(unique))}
+	reported := make(map[string]bool, len(unique))
+	for i, id := range ids {
+		if invalid[i] != nil {
+			res.addError(id, BatchReasonInvalid, invalid[i])
+			continue
+		}
+		key := normalized[i]
+		o := outcomes[index[key]]
+		if o.err != nil {
+			res.addError(id, batchReason(o.err), o.err)
+			continue
+		}
+		if !reported[key] {
+			reported[key] = true
+			res.Found = append(res.Found, o.value)
+		}
+	}
+	return res
+}
+
+func (r *BatchResult[T]) addError(id, reason string, err error) {
+	r.Errors = append(r.Errors, BatchItemError{ID: id, Reason: reason, Error: err.Error()})
+	r.Missing = append(r.Missing, id)
+}
+
+// batchReason classifies a lookup error for BatchItemError.Reason.
+func batchReason(err error) string {
+	switch {
+	case apierrors.IsNotFound(err):
+		return BatchReasonNotFound
+	case apierrors.IsValidation(err):
+		return BatchReasonInvalid
+	default:
+		return BatchReasonUpstream
+	}
+}
@@ -0,0 +1,93 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+)
+
+func testBatchSpec(calls *int32) BatchSpec[string] {
+	return BatchSpec[string]{
+		Workers:   4,
+		Normalize: func(id string) string { return strings.ReplaceAll(id, "-", "") },
+		Validate: func(id string) error {
+			if strings.ContainsAny(id, "xyz") {
+				return fmt.Errorf("bad id %q", id)
+			}
+			return nil
+		},
+		Fetch: func(_ context.Context, id string) (string, error) {
+			atomic.AddInt32(calls, 1)
+			switch id {
+			case "404":
+				return "", apierrors.NewNotFoundError("test", id)
+			case "500":
+				return "", errors.New("upstream exploded")
+			}
+			return "company-" + id, nil
+		},
+	}
+}
+
+func TestBatchLookup_PerItemOutcomes(t *testing.T) {
+	var calls int32
+	res := BatchLookup(context.Background(), []string{"1", "x", "404", "2", "500"}, testBatchSpec(&calls))
+
+	if want := []string{"company-1", "company-2"}; !reflect.DeepEqual(res.Found, want) {
+		t.Errorf("Found = %v, want %v", res.Found, want)
+	}
+	if want := []string{"x", "404", "500"}; !reflect.DeepEqual(res.Missing, want) {
+		t.Errorf("Missing = %v, want %v", res.Missing, want)
+	}
+	wantReasons := []string{BatchReasonInvalid, BatchReasonNotFound, BatchReasonUpstream}
+	if len(res.Errors) != len(wantReasons) {
+		t.Fatalf("Errors = %+v, want %d entries", res.Errors, len(wantReasons))
+	}
+	for i, reason := range wantReasons {
+		if res.Errors[i].Reason != reason || res.Errors[i].Error == "" {
+			t.Errorf("Errors[%d] = %+v, want reason %q with message", i, res.Errors[i], reason)
+		}
+	}
+	if calls != 4 {
+		t.Errorf("Fetch called %d times, want 4 (invalid input must not be fetched)", calls)
+	}
+}
+
+func TestBatchLookup_DeduplicatesNormalizedIDs(t *testing.T) {
+	var calls int32
+	res := BatchLookup(context.Background(), []string{"1-2", "12", "404", "4-04"}, testBatchSpec(&calls))
+
+	if calls != 2 {
+		t.Errorf("Fetch called %d times, want 2", calls)
+	}
+	if want := []string{"company-12"}; !reflect.DeepEqual(res.Found, want) {
+		t.Errorf("Found = %v, want %v", res.Found, want)
+	}
+	// Both spellings of a missing ID are reported so each input line can be matched.
+	if want := []string{"404", "4-04"}; !reflect.DeepEqual(res.Missing, want) {
+		t.Errorf("Missing = %v, want %v", res.Missing, want)
+	}
+}
+
+func TestBatchLookup_Empty(t *testing.T) {
+	var calls int32
+	res := BatchLookup(context.Background(), nil, testBatchSpec(&calls))
+	if len(res.Found) != 0 || len(res.Errors) != 0 || len(res.Missing) != 0 || calls != 0 {
+		t.Errorf("unexpected result for empty input: %+v (calls %d)", res, calls)
+	}
+}
+
+func TestClient_Concurrency(t *testing.T) {
+	client := NewClient()
+	defer client.Close()
+
+	if got := client.Concurrency(); got != MaxConcurrentRequests {
+		t.Errorf("Concurrency() = %d, want %d", got, MaxConcurrentRequests)
+	}
+}
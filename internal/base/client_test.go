@@ -3,6 +3,9 @@ package base
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
@@ -150,7 +153,11 @@ func TestClient_CheckCircuitBreaker_Open(t *testing.T) {
 	// Circuit should now be open
 	err := client.CheckCircuitBreaker()
 	if err == nil {
-		t.Error("expected error when circuit is open")
+		t.Fatal("expected error when circuit is open")
+	}
+	var open *infra.ErrCircuitOpen
+	if !errors.As(err, &open) || open.RetryAt != client.CircuitBreakerStats().RetryAt || open.RetryAt.IsZero() {
+		t.Errorf("RetryAt = %v, want the breaker's own retry time", open.RetryAt)
 	}
 }
 
@@ -227,7 +234,7 @@ func TestReadAndClose(t *testing.T) {
 			Body: body,
 		}
 
-		data, err := readAndClose(resp)
+		data, err := readAndClose(context.Background(), resp, RequestConfig{})
 		if err != nil {
 			t.Fatalf("readAndClose failed: %v", err)
 		}
@@ -243,7 +250,7 @@ func TestReadAndClose(t *testing.T) {
 			Body: body,
 		}
 
-		data, err := readAndClose(resp)
+		data, err := readAndClose(context.Background(), resp, RequestConfig{})
 		if err != nil {
 			t.Fatalf("readAndClose failed: %v", err)
 		}
@@ -267,7 +274,7 @@ func TestDoRequest_Success(t *testing.T) {
 	client := NewClient()
 	defer client.Close()
 
-	body, statusCode, err := client.DoRequest(context.Background(), RequestConfig{
+	resp, err := client.DoRequest(context.Background(), RequestConfig{
 		URL:      server.URL,
 		MaxRetry: 1,
 	})
@@ -275,11 +282,11 @@ func TestDoRequest_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("DoRequest failed: %v", err)
 	}
-	if statusCode != http.StatusOK {
-		t.Errorf("status code = %d, want 200", statusCode)
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status code = %d, want 200", resp.StatusCode)
 	}
-	if string(body) != `{"status":"ok"}` {
-		t.Errorf("body = %q, want '{\"status\":\"ok\"}'", string(body))
+	if string(resp.Body) != `{"status":"ok"}` {
+		t.Errorf("body = %q, want '{\"status\":\"ok\"}'", string(resp.Body))
 	}
 }
 
@@ -294,7 +301,7 @@ func TestDoRequest_CustomUserAgent(t *testing.T) {
 	client := NewClient()
 	defer client.Close()
 
-	_, _, _ = client.DoRequest(context.Background(), RequestConfig{
+	_, _ = client.DoRequest(context.Background(), RequestConfig{
 		URL:       server.URL,
 		UserAgent: "custom-agent/1.0",
 		MaxRetry:  1,
@@ -305,6 +312,187 @@ func TestDoRequest_CustomUserAgent(t *testing.T) {
 	}
 }
 
+func TestDoRequest_Headers(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient()
+	defer client.Close()
+
+	resp, err := client.DoRequest(context.Background(), RequestConfig{
+		URL:     server.URL,
+		Headers: http.Header{"Accept": {"application/xml"}, "X-Request-Id": {"abc"}},
+	})
+	if err != nil {
+		t.Fatalf("DoRequest failed: %v", err)
+	}
+	if got := received.Get("Accept"); got != "application/xml" {
+		t.Errorf("Accept = %q, want the header from the config", got)
+	}
+	if got := received.Get("X-Request-Id"); got != "abc" {
+		t.Errorf("X-Request-Id = %q, want abc", got)
+	}
+	if got := resp.Header.Get("ETag"); got != `"v1"` {
+		t.Errorf("response ETag = %q, want \"v1\"", got)
+	}
+}
+
+func TestDoRequest_PostBodyAndAuth(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer token-1" {
+			t.Errorf("request = %s with %q, want an authorized POST", r.Method, r.Header.Get("Authorization"))
+		}
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := NewClient()
+	defer client.Close()
+
+	authCalls := 0
+	resp, err := client.DoRequest(context.Background(), RequestConfig{
+		Method: http.MethodPost,
+		URL:    server.URL,
+		Body:   []byte(`{"id":"5560125790"}`),
+		Auth: func(_ context.Context, req *http.Request) error {
+			authCalls++
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer token-%d", authCalls))
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("DoRequest failed: %v", err)
+	}
+	if string(resp.Body) != "ok" {
+		t.Errorf("body = %q, want ok", resp.Body)
+	}
+	if len(bodies) != 2 || bodies[0] != bodies[1] || bodies[1] != `{"id":"5560125790"}` {
+		t.Errorf("request bodies = %q, want the body sent on both attempts", bodies)
+	}
+	if authCalls != 1 {
+		t.Errorf("Auth calls = %d, want 1", authCalls)
+	}
+}
+
+func TestDoRequest_AuthError(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	client := NewClient()
+	defer client.Close()
+
+	authErr := errors.New("token request returned 401")
+	_, err := client.DoRequest(context.Background(), RequestConfig{
+		URL: server.URL,
+		Auth: func(context.Context, *http.Request) error {
+			return authErr
+		},
+	})
+	if !errors.Is(err, authErr) {
+		t.Fatalf("err = %v, want the Auth error", err)
+	}
+	if called {
+		t.Error("the registry was called without credentials")
+	}
+	if stats := client.CircuitBreakerStats(); stats.ConsecutiveFails != 1 {
+		t.Errorf("ConsecutiveFails = %d, want an Auth error to count as a failure", stats.ConsecutiveFails)
+	}
+}
+
+func TestDoRequest_ConditionalRefresh(t *testing.T) {
+	var requests, notModified int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		_, _ = w.Write([]byte(`{"name":"EQUINOR ASA"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient()
+	defer client.Close()
+
+	type company struct {
+		Name string `json:"name"`
+	}
+	parses := 0
+	fetch := func(ctx context.Context) (*company, error) {
+		resp, err := client.DoRequest(ctx, RequestConfig{URL: server.URL})
+		if err != nil {
+			return nil, err
+		}
+		parses++
+		var c company
+		return &c, json.Unmarshal(resp.Body, &c)
+	}
+
+	// A TTL short enough that the entry is refreshed within the call, not
+	// in the background, once it is twice as old.
+	const ttl = 20 * time.Millisecond
+	for i := range 2 {
+		if i > 0 {
+			time.Sleep(3 * ttl)
+		}
+		got, err := infra.Fetch(context.Background(), client.Cache, "company:1", ttl, fetch)
+		if err != nil || got.Name != "EQUINOR ASA" {
+			t.Fatalf("Fetch %d = %+v, %v; want the company", i, got, err)
+		}
+	}
+	if requests != 2 || notModified != 1 {
+		t.Errorf("requests = %d, 304s = %d; want 2 requests, the refresh answered 304", requests, notModified)
+	}
+	if parses != 1 {
+		t.Errorf("parsed %d bodies, want 1", parses)
+	}
+	if stats := client.CircuitBreakerStats(); stats.ConsecutiveFails != 0 {
+		t.Errorf("ConsecutiveFails = %d after a 304, want 0", stats.ConsecutiveFails)
+	}
+}
+
+func TestDoRequest_UnconditionalNotModified(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer server.Close()
+
+	client := NewClient()
+	defer client.Close()
+
+	// A 304 to a request without validators has no body to parse.
+	_, err := client.DoRequest(context.Background(), RequestConfig{URL: server.URL, MaxRetry: 1})
+	if err == nil || !strings.Contains(err.Error(), "unexpected redirect 304") {
+		t.Errorf("err = %v, want an unexpected 304", err)
+	}
+
+	// A caller sending its own validators gets the 304 back.
+	resp, err := client.DoRequest(context.Background(), RequestConfig{
+		URL:     server.URL,
+		Headers: http.Header{"If-None-Match": {`"v1"`}},
+	})
+	if err != nil || resp.StatusCode != http.StatusNotModified {
+		t.Errorf("DoRequest = %+v, %v; want the 304", resp, err)
+	}
+}
+
 func TestDoRequest_DefaultUserAgent(t *testing.T) {
 	var receivedUA string
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -316,7 +504,7 @@ func TestDoRequest_DefaultUserAgent(t *testing.T) {
 	client := NewClient()
 	defer client.Close()
 
-	_, _, _ = client.DoRequest(context.Background(), RequestConfig{
+	_, _ = client.DoRequest(context.Background(), RequestConfig{
 		URL:      server.URL,
 		MaxRetry: 1,
 	})
@@ -335,7 +523,7 @@ func TestDoRequest_CircuitOpen(t *testing.T) {
 		client.RecordFailure()
 	}
 
-	_, _, err := client.DoRequest(context.Background(), RequestConfig{
+	_, err := client.DoRequest(context.Background(), RequestConfig{
 		URL: "http://example.com",
 	})
 
@@ -361,7 +549,7 @@ func TestDoRequest_ServerError_Retries(t *testing.T) {
 	client := NewClient()
 	defer client.Close()
 
-	body, statusCode, err := client.DoRequest(context.Background(), RequestConfig{
+	resp, err := client.DoRequest(context.Background(), RequestConfig{
 		URL:      server.URL,
 		MaxRetry: 5,
 	})
@@ -369,11 +557,11 @@ func TestDoRequest_ServerError_Retries(t *testing.T) {
 	if err != nil {
 		t.Fatalf("DoRequest failed: %v", err)
 	}
-	if statusCode != http.StatusOK {
-		t.Errorf("status code = %d, want 200", statusCode)
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status code = %d, want 200", resp.StatusCode)
 	}
-	if string(body) != "success" {
-		t.Errorf("body = %q, want 'success'", string(body))
+	if string(resp.Body) != "success" {
+		t.Errorf("body = %q, want 'success'", string(resp.Body))
 	}
 	if attempts != 3 {
 		t.Errorf("attempts = %d, want 3", attempts)
@@ -400,7 +588,7 @@ func TestDoRequest_RateLimited(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	body, statusCode, err := client.DoRequest(ctx, RequestConfig{
+	resp, err := client.DoRequest(ctx, RequestConfig{
 		URL:      server.URL,
 		MaxRetry: 3,
 	})
@@ -408,11 +596,11 @@ func TestDoRequest_RateLimited(t *testing.T) {
 	if err != nil {
 		t.Fatalf("DoRequest failed: %v", err)
 	}
-	if statusCode != http.StatusOK {
-		t.Errorf("status code = %d, want 200", statusCode)
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status code = %d, want 200", resp.StatusCode)
 	}
-	if string(body) != "success" {
-		t.Errorf("body = %q, want 'success'", string(body))
+	if string(resp.Body) != "success" {
+		t.Errorf("body = %q, want 'success'", string(resp.Body))
 	}
 }
 
@@ -429,7 +617,7 @@ func TestDoRequest_ContextCanceled(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel() // Cancel immediately
 
-	_, _, err := client.DoRequest(ctx, RequestConfig{
+	_, err := client.DoRequest(ctx, RequestConfig{
 		URL:      server.URL,
 		MaxRetry: 1,
 	})
@@ -437,6 +625,9 @@ func TestDoRequest_ContextCanceled(t *testing.T) {
 	if err == nil {
 		t.Error("expected error when context is canceled")
 	}
+	if stats := client.CircuitBreakerStats(); stats.ConsecutiveFails != 0 {
+		t.Errorf("cancelled call counted as %d failures, want none", stats.ConsecutiveFails)
+	}
 }
 
 func TestDoRequest_AllRetriesFail(t *testing.T) {
@@ -449,7 +640,7 @@ func TestDoRequest_AllRetriesFail(t *testing.T) {
 	client := NewClient()
 	defer client.Close()
 
-	_, _, err := client.DoRequest(context.Background(), RequestConfig{
+	_, err := client.DoRequest(context.Background(), RequestConfig{
 		URL:      server.URL,
 		MaxRetry: 2,
 	})
@@ -469,7 +660,7 @@ func TestDoRequest_NotFound(t *testing.T) {
 	client := NewClient()
 	defer client.Close()
 
-	body, statusCode, err := client.DoRequest(context.Background(), RequestConfig{
+	resp, err := client.DoRequest(context.Background(), RequestConfig{
 		URL:      server.URL,
 		MaxRetry: 1,
 	})
@@ -478,11 +669,11 @@ func TestDoRequest_NotFound(t *testing.T) {
 	if err != nil {
 		t.Fatalf("DoRequest failed: %v", err)
 	}
-	if statusCode != http.StatusNotFound {
-		t.Errorf("status code = %d, want 404", statusCode)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status code = %d, want 404", resp.StatusCode)
 	}
-	if string(body) != "not found" {
-		t.Errorf("body = %q, want 'not found'", string(body))
+	if string(resp.Body) != "not found" {
+		t.Errorf("body = %q, want 'not found'", string(resp.Body))
 	}
 }
 
@@ -498,7 +689,7 @@ func TestDoRequest_DefaultMaxRetry(t *testing.T) {
 	defer client.Close()
 
 	// MaxRetry = 0 should default to 3
-	_, _, _ = client.DoRequest(context.Background(), RequestConfig{
+	_, _ = client.DoRequest(context.Background(), RequestConfig{
 		URL:      server.URL,
 		MaxRetry: 0, // defaults to 3
 	})
@@ -524,7 +715,7 @@ func TestDoRequest_RateLimited_InvalidRetryAfter(t *testing.T) {
 	client := NewClient()
 	defer client.Close()
 
-	_, _, err := client.DoRequest(context.Background(), RequestConfig{
+	_, err := client.DoRequest(context.Background(), RequestConfig{
 		URL:      server.URL,
 		MaxRetry: 3,
 	})
@@ -548,7 +739,7 @@ func TestDoRequest_RateLimited_ContextCanceled(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
 	defer cancel()
 
-	_, _, err := client.DoRequest(ctx, RequestConfig{
+	_, err := client.DoRequest(ctx, RequestConfig{
 		URL:      server.URL,
 		MaxRetry: 2,
 	})
@@ -572,7 +763,7 @@ func TestDoRequest_BackoffContextCanceled(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
 	defer cancel()
 
-	_, _, err := client.DoRequest(ctx, RequestConfig{
+	_, err := client.DoRequest(ctx, RequestConfig{
 		URL:      server.URL,
 		MaxRetry: 10,
 	})
@@ -591,9 +782,15 @@ func TestReadAndClose_ResponseTooLarge(t *testing.T) {
 		Body: body,
 	}
 
-	_, err := readAndClose(resp)
-	if err == nil {
-		t.Error("expected error for oversized response")
+	_, err := readAndClose(context.Background(), resp, RequestConfig{})
+	if !errors.Is(err, errResponseTooLarge) {
+		t.Errorf("err = %v, want errResponseTooLarge", err)
+	}
+
+	// A request can lower the limit.
+	resp = &http.Response{Body: io.NopCloser(strings.NewReader("0123456789"))}
+	if _, err := readAndClose(context.Background(), resp, RequestConfig{MaxBodySize: 9}); !errors.Is(err, errResponseTooLarge) {
+		t.Errorf("err = %v with MaxBodySize 9, want errResponseTooLarge", err)
 	}
 }
 
@@ -603,7 +800,7 @@ func TestReadAndClose_ReadError(t *testing.T) {
 		Body: body,
 	}
 
-	_, err := readAndClose(resp)
+	_, err := readAndClose(context.Background(), resp, RequestConfig{})
 	if err == nil {
 		t.Error("expected error when read fails")
 	}
@@ -615,3 +812,85 @@ type errorReader struct{}
 func (e *errorReader) Read(p []byte) (int, error) {
 	return 0, io.ErrUnexpectedEOF
 }
+
+func TestDoRequest_RateLimiter(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithRateLimiter(infra.NewRateLimiter(infra.RateLimitConfig{Rate: 20, Burst: 1, DailyQuota: 2})))
+	defer client.Close()
+
+	start := time.Now()
+	for range 2 {
+		if _, err := client.DoRequest(context.Background(), RequestConfig{URL: server.URL}); err != nil {
+			t.Fatalf("DoRequest: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("two requests at 20/s with a burst of 1 took %v, want about 50ms", elapsed)
+	}
+
+	_, err := client.DoRequest(context.Background(), RequestConfig{URL: server.URL})
+	var exhausted *infra.ErrQuotaExhausted
+	if !errors.As(err, &exhausted) {
+		t.Fatalf("err = %v, want the daily quota exhausted", err)
+	}
+	if requests != 2 {
+		t.Errorf("server saw %d requests, want 2", requests)
+	}
+	if stats := client.CircuitBreakerStats(); stats.ConsecutiveFails != 0 {
+		t.Errorf("quota refusal counted as %d failures, want none", stats.ConsecutiveFails)
+	}
+	stats := client.RateLimitStats()
+	if len(stats.Hosts) != 1 || stats.Hosts[0].QuotaRemaining != 0 || stats.Waits != 1 {
+		t.Errorf("stats = %+v, want one host with no quota left after one wait", stats)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"120", 2 * time.Minute, true},
+		{"Sun, 18 Oct 2026 12:00:30 GMT", 30 * time.Second, true},
+		{"Sunday, 18-Oct-26 12:01:00 GMT", time.Minute, true},
+		{"Sun, 18 Oct 2026 11:00:00 GMT", 0, true}, // In the past
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := retryAfter(tt.value, now)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("retryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestDoRequest_RetryAfterPastDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient()
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	_, err := client.DoRequest(ctx, RequestConfig{URL: server.URL, MaxRetry: 2})
+	if err == nil || !strings.Contains(err.Error(), "past the deadline") {
+		t.Errorf("err = %v, want a retry past the deadline", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("waited for a retry it could not make")
+	}
+}
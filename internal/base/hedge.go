@@ -0,0 +1,216 @@
+package base
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/olgasafonova/nordic-registry-mcp-server/metrics"
+)
+
+// HedgeConfig configures hedged requests: when a GET has not answered
+// within the latency quantile of its endpoint's recent successful calls, an identical
+// second GET is sent and whichever answers first is used. A registry with a
+// slow tail, such as PRH's occasional 20-second answers, then costs an
+// interactive session the p95 plus one ordinary answer instead of the tail.
+type HedgeConfig struct {
+	Quantile   float64 // Latency quantile after which to hedge, e.g. 0.95
+	MinSamples uint64  // Successful calls recorded for an endpoint before it is hedged
+	MaxRate    float64 // Most hedges per request over time, e.g. 0.1
+}
+
+// DefaultHedgeConfig hedges past the p95 once an endpoint has 20 recorded
+// successful calls, at most one request in ten.
+var DefaultHedgeConfig = HedgeConfig{Quantile: 0.95, MinSamples: 20, MaxRate: 0.1}
+
+// WithHedging turns on hedged requests with cfg; nil turns them off. Only
+// a client with a registry name is hedged.
+func WithHedging(cfg *HedgeConfig) ClientOption {
+	return func(client *Client) {
+		client.hedger = nil
+		if cfg != nil {
+			client.hedger = &hedger{cfg: *cfg}
+		}
+	}
+}
+
+// WithRegistry names the registry the client calls, labelling its latency
+// metrics.
+func WithRegistry(name string) ClientOption {
+	return func(client *Client) {
+		client.Registry = name
+	}
+}
+
+// latencyWindow is how many of an endpoint's latest successful calls the
+// hedge delay is estimated from, so it follows a registry that speeds up or
+// slows down rather than its whole history.
+const latencyWindow = 200
+
+// hedger holds the hedging configuration, the hedge budget and the recent
+// latencies of a client. Every request adds MaxRate to the budget, up to
+// one hedge, and every hedge spends one, so hedges never exceed MaxRate of
+// requests for long and a registry that turns slow across the board is not
+// sent double the traffic.
+type hedger struct {
+	cfg       HedgeConfig
+	mu        sync.Mutex
+	budget    float64
+	latencies map[string]*latencyRing // endpoint -> latest successful calls
+}
+
+// latencyRing keeps the latest latencyWindow latencies of an endpoint,
+// overwriting the oldest.
+type latencyRing struct {
+	samples []time.Duration
+	next    int
+}
+
+// observe records the latency of a successful call to endpoint. Failed
+// calls are left out: a timeout or a fast error says nothing about how long
+// an answer takes.
+func (h *hedger) observe(endpoint string, d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.latencies == nil {
+		h.latencies = make(map[string]*latencyRing)
+	}
+	r, ok := h.latencies[endpoint]
+	if !ok {
+		r = &latencyRing{samples: make([]time.Duration, 0, latencyWindow)}
+		h.latencies[endpoint] = r
+	}
+	if len(r.samples) < latencyWindow {
+		r.samples = append(r.samples, d)
+		return
+	}
+	r.samples[r.next] = d
+	r.next = (r.next + 1) % latencyWindow
+}
+
+// delay returns how long to wait for the first answer before hedging a
+// request to endpoint: the configured quantile of its recent successful
+// calls, or false when there are too few of them. It adds the request's
+// share to the hedge budget.
+func (h *hedger) delay(endpoint string) (time.Duration, bool) {
+	h.mu.Lock()
+	h.budget = min(h.budget+h.cfg.MaxRate, 1)
+	var samples []time.Duration
+	if r, ok := h.latencies[endpoint]; ok {
+		samples = slices.Clone(r.samples)
+	}
+	h.mu.Unlock()
+
+	if uint64(len(samples)) < max(h.cfg.MinSamples, 1) {
+		return 0, false
+	}
+	slices.Sort(samples)
+	i := min(max(int(math.Ceil(h.cfg.Quantile*float64(len(samples))))-1, 0), len(samples)-1)
+	if samples[i] <= 0 {
+		return 0, false
+	}
+	return samples[i], true
+}
+
+// spend takes one hedge from the budget, or reports that none is left.
+func (h *hedger) spend() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.budget < 1 {
+		return false
+	}
+	h.budget--
+	return true
+}
+
+// attemptResult is the outcome of one executeAttempt.
+type attemptResult struct {
+	resp     *Response
+	retryErr error
+	fatal    error
+	hedge    bool // Whether the hedge, not the first request, produced it
+}
+
+// executeHedged runs one attempt of req, hedging it when the client hedges,
+// req is a GET and the endpoint's latency is known. The first successful
+// or fatal answer wins and the other request is cancelled; when the first
+// answer is a retryable error, the other is waited for.
+func (c *Client) executeHedged(ctx context.Context, req *http.Request, cfg RequestConfig, attempt int) attemptResult {
+	if c.hedger == nil || c.Registry == "" || req.Method != http.MethodGet {
+		return c.runAttempt(ctx, req, cfg, attempt, false)
+	}
+	endpoint := endpointLabel(cfg, req.URL)
+	delay, ok := c.hedger.delay(endpoint)
+	if !ok {
+		return c.runAttempt(ctx, req, cfg, attempt, false)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make(chan attemptResult, 2) // Buffered so the loser never blocks
+	launch := func(hedge bool) {
+		go func() { results <- c.runAttempt(ctx, req.Clone(ctx), cfg, attempt, hedge) }()
+	}
+	launch(false)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case res := <-results:
+		return res
+	case <-timer.C:
+	}
+
+	if !c.hedger.spend() || !c.RateLimiter.Allow(req.URL.Host) {
+		return <-results
+	}
+	c.Logger.Debug("Hedging slow registry request", "registry", c.Registry, "endpoint", endpoint, "after", delay)
+	launch(true)
+
+	first := <-results
+	if first.retryErr != nil {
+		if second := <-results; second.retryErr == nil {
+			first = second
+		}
+	}
+	winner := "primary"
+	if first.hedge {
+		winner = "hedge"
+	}
+	metrics.RegistryAPIHedges.WithLabelValues(c.Registry, endpoint, winner).Inc()
+	return first
+}
+
+// runAttempt wraps executeAttempt in an attemptResult.
+func (c *Client) runAttempt(ctx context.Context, req *http.Request, cfg RequestConfig, attempt int, hedge bool) attemptResult {
+	resp, retryErr, fatal := c.executeAttempt(ctx, req, cfg, attempt)
+	return attemptResult{resp: resp, retryErr: retryErr, fatal: fatal, hedge: hedge}
+}
+
+// endpointLabel names the endpoint of a request in latency metrics:
+// cfg.Endpoint when set, else the URL path with identifiers masked, so
+// /enheter/923609016 and /enheter/974760673 share one latency histogram.
+// Path segments with four or more digits count as identifiers.
+func endpointLabel(cfg RequestConfig, u *url.URL) string {
+	if cfg.Endpoint != "" {
+		return cfg.Endpoint
+	}
+	segments := strings.Split(u.Path, "/")
+	for i, segment := range segments {
+		digits := 0
+		for _, r := range segment {
+			if r >= '0' && r <= '9' {
+				digits++
+			}
+		}
+		if digits >= 4 {
+			segments[i] = ":id"
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,154 @@
+package base
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/olgasafonova/nordic-registry-mcp-server/metrics"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestEndpointLabel(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		url      string
+		want     string
+	}{
+		{"", "https://data.brreg.no/enhetsregisteret/api/enheter/923609016", "/enhetsregisteret/api/enheter/:id"},
+		{"", "https://avoindata.prh.fi/opendata-ytj-api/v3/companies?businessId=0112038-9", "/opendata-ytj-api/v3/companies"},
+		{"", "https://ec.europa.eu/taxation_customs/vies/rest-api/ms/DK/vat/10150817", "/taxation_customs/vies/rest-api/ms/DK/vat/:id"},
+		{"company", "https://cvrapi.dk/api?vat=10150817", "company"},
+	}
+	for _, tt := range tests {
+		u, _ := url.Parse(tt.url)
+		if got := endpointLabel(RequestConfig{Endpoint: tt.endpoint}, u); got != tt.want {
+			t.Errorf("endpointLabel(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestHedger_Budget(t *testing.T) {
+	h := &hedger{cfg: HedgeConfig{Quantile: 0.95, MinSamples: 1, MaxRate: 0.5}}
+	if _, ok := h.delay("unseen"); ok {
+		t.Error("hedging an endpoint without recorded calls")
+	}
+	if h.spend() {
+		t.Error("hedge allowed after one request at a rate of 0.5")
+	}
+	h.delay("unseen")
+	if !h.spend() {
+		t.Error("no hedge after two requests at a rate of 0.5")
+	}
+	if h.spend() {
+		t.Error("budget spent twice")
+	}
+}
+
+func TestHedger_Delay(t *testing.T) {
+	h := &hedger{cfg: HedgeConfig{Quantile: 0.95, MinSamples: 20}}
+	seedLatency(h, "lookup", 19, 10*time.Millisecond)
+	if _, ok := h.delay("lookup"); ok {
+		t.Error("hedging after 19 of 20 calls")
+	}
+	seedLatency(h, "lookup", 1, 2*time.Second)
+	if d, ok := h.delay("lookup"); !ok || d != 10*time.Millisecond {
+		t.Errorf("delay = %v, %v; want the p95 of 10ms", d, ok)
+	}
+
+	// A registry that turns slow moves the delay once its recent calls are
+	// slow, however long its fast history.
+	seedLatency(h, "lookup", latencyWindow, 10*time.Millisecond)
+	seedLatency(h, "lookup", latencyWindow/2, time.Second)
+	if d, _ := h.delay("lookup"); d != time.Second {
+		t.Errorf("delay after a slow half window = %v, want 1s", d)
+	}
+}
+
+// seedLatency records n successful calls of d for an endpoint.
+func seedLatency(h *hedger, endpoint string, n int, d time.Duration) {
+	for range n {
+		h.observe(endpoint, d)
+	}
+}
+
+func TestDoRequest_Hedged(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			// The first request hits the slow tail.
+			select {
+			case <-time.After(5 * time.Second):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithRegistry("test-hedge"), WithHedging(&HedgeConfig{Quantile: 0.95, MinSamples: 20, MaxRate: 1}))
+	defer client.Close()
+	seedLatency(client.hedger, "lookup", 20, 10*time.Millisecond)
+
+	start := time.Now()
+	resp, err := client.DoRequest(context.Background(), RequestConfig{URL: server.URL, Endpoint: "lookup"})
+	if err != nil || resp.StatusCode != http.StatusOK || string(resp.Body) != "ok" {
+		t.Fatalf("DoRequest = %+v, %v", resp, err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("hedged request took %v, want the hedge's answer", elapsed)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("server saw %d requests, want 2", got)
+	}
+
+	var m dto.Metric
+	if err := metrics.RegistryAPIHedges.WithLabelValues("test-hedge", "lookup", "hedge").Write(&m); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got := m.GetCounter().GetValue(); got != 1 {
+		t.Errorf("hedges won = %v, want 1", got)
+	}
+}
+
+func TestDoRequest_NotHedged(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name string
+		opts []ClientOption
+		cfg  RequestConfig
+	}{
+		{"hedging off", []ClientOption{WithRegistry("test-no-hedge")}, RequestConfig{Endpoint: "lookup"}},
+		{"too few calls", []ClientOption{WithRegistry("test-no-hedge"), WithHedging(&DefaultHedgeConfig)}, RequestConfig{Endpoint: "rare"}},
+		{"no budget", []ClientOption{WithRegistry("test-no-hedge"), WithHedging(&HedgeConfig{Quantile: 0.95, MinSamples: 20, MaxRate: 0.1})}, RequestConfig{Endpoint: "lookup"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls.Store(0)
+			client := NewClient(tt.opts...)
+			defer client.Close()
+			if client.hedger != nil {
+				seedLatency(client.hedger, "lookup", 20, 10*time.Millisecond)
+			}
+			tt.cfg.URL = server.URL
+			if _, err := client.DoRequest(context.Background(), tt.cfg); err != nil {
+				t.Fatalf("DoRequest: %v", err)
+			}
+			if got := calls.Load(); got != 1 {
+				t.Errorf("server saw %d requests, want 1", got)
+			}
+		})
+	}
+}
@@ -1,12 +1,23 @@
 package denmark
 
+import (
+	"strconv"
+
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/base"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/legalform"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/lei"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/nace"
+)
+
 // Tag convention (verified against google/jsonschema-go, which the go-sdk uses
-// to derive input schemas): the `jsonschema:"..."` tag VALUE becomes the
-// property description, and a field is REQUIRED unless its `json` tag carries
-// `,omitempty`. There is no `required` keyword — `jsonschema:"required"` merely
-// sets the description to the literal word "required". Enum, minimum, maximum
-// and pattern constraints are not derivable from tags; they are enforced in the
-// handlers (see validation.go) and described in the tag text.
+// to derive input and output schemas): the `jsonschema:"..."` tag VALUE becomes
+// the property description, and a field is REQUIRED unless its `json` tag
+// carries `,omitempty` (for result types: always present). There is no
+// `required` keyword — `jsonschema:"required"` merely sets the description to
+// the literal word "required". Enum, minimum, maximum and pattern constraints
+// are not derivable from tags; they are enforced in the handlers
+// (see validation.go) and described in the tag text.
 
 // SearchCompaniesArgs contains parameters for company search
 type SearchCompaniesArgs struct {
@@ -15,54 +26,77 @@ type SearchCompaniesArgs struct {
 
 // SearchCompaniesResult is the result of a company search
 type SearchCompaniesResult struct {
-	Company *CompanySummary `json:"company,omitempty"`
-	Found   bool            `json:"found"`
-	Message string          `json:"message,omitempty"`
+	Company *CompanySummary `json:"company,omitempty" jsonschema:"Best-matching company, when found"`
+	Found   bool            `json:"found" jsonschema:"Whether a company matched"`
+	Message string          `json:"message,omitempty" jsonschema:"Explanation when nothing matched"`
 }
 
 // CompanySummary is a simplified company representation for search results
 type CompanySummary struct {
-	CVR          string `json:"cvr"`
-	Name         string `json:"name"`
-	Address      string `json:"address,omitempty"`
-	City         string `json:"city,omitempty"`
-	Zipcode      string `json:"zipcode,omitempty"`
-	CompanyType  string `json:"company_type,omitempty"`
-	IndustryDesc string `json:"industry,omitempty"`
-	Employees    string `json:"employees,omitempty"`
-	StartDate    string `json:"start_date,omitempty"`
-	Status       string `json:"status,omitempty"`
-	Phone        string `json:"phone,omitempty"`
-	Email        string `json:"email,omitempty"`
+	CVR            string          `json:"cvr" jsonschema:"8-digit CVR number"`
+	Name           string          `json:"name" jsonschema:"Registered company name"`
+	Address        string          `json:"address,omitempty" jsonschema:"Street address"`
+	City           string          `json:"city,omitempty" jsonschema:"City"`
+	Zipcode        string          `json:"zipcode,omitempty" jsonschema:"Postal code"`
+	CompanyType    string          `json:"company_type,omitempty" jsonschema:"Company type description, e.g. ApS or A/S"`
+	IndustryDesc   string          `json:"industry,omitempty" jsonschema:"Industry description from the CVR register"`
+	Employees      string          `json:"employees,omitempty" jsonschema:"Number of employees as reported to CVR"`
+	StartDate      string          `json:"start_date,omitempty" jsonschema:"Start date, YYYY-MM-DD"`
+	Status         string          `json:"status,omitempty" jsonschema:"Derived status: ACTIVE, BANKRUPT or DISSOLVED"`
+	Phone          string          `json:"phone,omitempty" jsonschema:"Registered phone number"`
+	Email          string          `json:"email,omitempty" jsonschema:"Registered email address"`
+	NACE           *nace.Code      `json:"nace,omitempty" jsonschema:"Industry code mapped to NACE"`
+	LegalFormClass *legalform.Form `json:"legal_form_class,omitempty" jsonschema:"Legal form mapped to category, owner liability and ELF code"`
 }
 
 // GetCompanyArgs contains parameters for getting a company by CVR
 type GetCompanyArgs struct {
 	CVR  string `json:"cvr" jsonschema:"8-digit Danish CVR number; spaces, dashes and a leading DK prefix are stripped automatically, e.g. 10150817 or DK-10150817"`
 	Full bool   `json:"full,omitempty" jsonschema:"Return the full company record (production units, owners, full history) instead of the compact summary (default false)"`
+
+	infra.CacheControl
 }
 
 // GetCompanyResult is the result of getting a company
 type GetCompanyResult struct {
-	Company *Company              `json:"company,omitempty"` // Full company (when full=true)
-	Summary *CompanyDetailSummary `json:"summary,omitempty"` // Summary (default)
+	Company *Company              `json:"company,omitempty" jsonschema:"Full company record from the CVR API, when full=true"`
+	Summary *CompanyDetailSummary `json:"summary,omitempty" jsonschema:"Compact company record, the default"`
+	LEI     *lei.Entity           `json:"lei,omitempty" jsonschema:"Legal Entity Identifier from the GLEIF golden copy, when configured and the company has one"`
+
+	infra.Freshness
 }
 
+// LEIKey returns the jurisdiction and CVR number for LEI enrichment.
+func (r *GetCompanyResult) LEIKey() (string, string) {
+	switch {
+	case r.Summary != nil:
+		return "DK", r.Summary.CVR
+	case r.Company != nil && r.Company.CVR != 0:
+		return "DK", strconv.Itoa(r.Company.CVR)
+	}
+	return "", ""
+}
+
+// SetLEI attaches the company's LEI entity.
+func (r *GetCompanyResult) SetLEI(e *lei.Entity) { r.LEI = e }
+
 // CompanyDetailSummary is a compact company representation for get_company responses
 type CompanyDetailSummary struct {
-	CVR             string `json:"cvr"`
-	Name            string `json:"name"`
-	Address         string `json:"address,omitempty"`
-	City            string `json:"city,omitempty"`
-	Zipcode         string `json:"zipcode,omitempty"`
-	CompanyType     string `json:"company_type,omitempty"`
-	Industry        string `json:"industry,omitempty"`
-	Employees       int    `json:"employees,omitempty"`
-	StartDate       string `json:"start_date,omitempty"`
-	Status          string `json:"status,omitempty"`
-	Phone           string `json:"phone,omitempty"`
-	Email           string `json:"email,omitempty"`
-	ProductionUnits int    `json:"production_units_count,omitempty"`
+	CVR             string          `json:"cvr" jsonschema:"8-digit CVR number"`
+	Name            string          `json:"name" jsonschema:"Registered company name"`
+	Address         string          `json:"address,omitempty" jsonschema:"Street address"`
+	City            string          `json:"city,omitempty" jsonschema:"City"`
+	Zipcode         string          `json:"zipcode,omitempty" jsonschema:"Postal code"`
+	CompanyType     string          `json:"company_type,omitempty" jsonschema:"Company type description, e.g. ApS or A/S"`
+	Industry        string          `json:"industry,omitempty" jsonschema:"Industry description from the CVR register"`
+	Employees       int             `json:"employees,omitempty" jsonschema:"Number of employees"`
+	StartDate       string          `json:"start_date,omitempty" jsonschema:"Start date, YYYY-MM-DD"`
+	Status          string          `json:"status,omitempty" jsonschema:"Derived status: ACTIVE, BANKRUPT or DISSOLVED"`
+	Phone           string          `json:"phone,omitempty" jsonschema:"Registered phone number"`
+	Email           string          `json:"email,omitempty" jsonschema:"Registered email address"`
+	ProductionUnits int             `json:"production_units_count,omitempty" jsonschema:"Number of production units (P-numbers)"`
+	NACE            *nace.Code      `json:"nace,omitempty" jsonschema:"Industry code mapped to NACE"`
+	LegalFormClass  *legalform.Form `json:"legal_form_class,omitempty" jsonschema:"Legal form mapped to category, owner liability and ELF code"`
 }
 
 // GetProductionUnitsArgs contains parameters for getting production units
@@ -74,24 +108,24 @@ type GetProductionUnitsArgs struct {
 
 // GetProductionUnitsResult is the result of getting production units
 type GetProductionUnitsResult struct {
-	ProductionUnits []ProductionUnitSummary `json:"production_units"`
-	TotalResults    int                     `json:"total_results"`
-	Page            int                     `json:"page"`
-	Size            int                     `json:"size"`
-	TotalPages      int                     `json:"total_pages"`
-	HasMore         bool                    `json:"has_more"`
+	ProductionUnits []ProductionUnitSummary `json:"production_units" jsonschema:"Production units on this page"`
+	TotalResults    int                     `json:"total_results" jsonschema:"Total number of production units"`
+	Page            int                     `json:"page" jsonschema:"Page number, 0-indexed"`
+	Size            int                     `json:"size" jsonschema:"Page size used"`
+	TotalPages      int                     `json:"total_pages" jsonschema:"Total number of pages"`
+	HasMore         bool                    `json:"has_more" jsonschema:"Whether more pages follow"`
 }
 
 // ProductionUnitSummary is a simplified production unit for MCP responses
 type ProductionUnitSummary struct {
-	PNumber      string `json:"p_number"`
-	Name         string `json:"name"`
-	Address      string `json:"address,omitempty"`
-	City         string `json:"city,omitempty"`
-	Zipcode      string `json:"zipcode,omitempty"`
-	IsMain       bool   `json:"is_main"`
-	Employees    string `json:"employees,omitempty"`
-	IndustryDesc string `json:"industry,omitempty"`
+	PNumber      string `json:"p_number" jsonschema:"10-digit production unit number (P-number)"`
+	Name         string `json:"name" jsonschema:"Production unit name"`
+	Address      string `json:"address,omitempty" jsonschema:"Street address"`
+	City         string `json:"city,omitempty" jsonschema:"City"`
+	Zipcode      string `json:"zipcode,omitempty" jsonschema:"Postal code"`
+	IsMain       bool   `json:"is_main" jsonschema:"Whether this is the company's main production unit"`
+	Employees    string `json:"employees,omitempty" jsonschema:"Number of employees as reported to CVR"`
+	IndustryDesc string `json:"industry,omitempty" jsonschema:"Industry description from the CVR register"`
 }
 
 // SearchByPhoneArgs contains parameters for searching by phone number
@@ -101,9 +135,9 @@ type SearchByPhoneArgs struct {
 
 // SearchByPhoneResult is the result of searching by phone number
 type SearchByPhoneResult struct {
-	Company *CompanySummary `json:"company,omitempty"`
-	Found   bool            `json:"found"`
-	Message string          `json:"message,omitempty"`
+	Company *CompanySummary `json:"company,omitempty" jsonschema:"Company registered with the phone number, when found"`
+	Found   bool            `json:"found" jsonschema:"Whether a company matched"`
+	Message string          `json:"message,omitempty" jsonschema:"Explanation when nothing matched"`
 }
 
 // GetByPNumberArgs contains parameters for getting a company by P-number
@@ -113,9 +147,22 @@ type GetByPNumberArgs struct {
 
 // GetByPNumberResult is the result of getting a company by P-number
 type GetByPNumberResult struct {
-	Company *CompanySummary `json:"company,omitempty"`
-	Found   bool            `json:"found"`
-	Message string          `json:"message,omitempty"`
+	Company *CompanySummary `json:"company,omitempty" jsonschema:"Company owning the production unit, when found"`
+	Found   bool            `json:"found" jsonschema:"Whether a company matched"`
+	Message string          `json:"message,omitempty" jsonschema:"Explanation when nothing matched"`
+}
+
+// BatchGetCompaniesArgs contains parameters for batch company lookup
+type BatchGetCompaniesArgs struct {
+	CVRs []string `json:"cvrs" jsonschema:"8-digit Danish CVR numbers to look up, max 100. DK prefix, spaces and dashes are stripped automatically. Malformed or unknown entries are reported under errors and missing rather than failing the call"`
+}
+
+// BatchGetCompaniesResult is the result of batch company lookup
+type BatchGetCompaniesResult struct {
+	Companies    []CompanySummary      `json:"companies" jsonschema:"Companies found, in input order"`
+	TotalResults int                   `json:"total_results" jsonschema:"Number of companies found"`
+	Missing      []string              `json:"missing,omitempty" jsonschema:"Input CVR numbers with no company, as given by the caller"`
+	Errors       []base.BatchItemError `json:"errors,omitempty" jsonschema:"Why each missing CVR number failed"`
 }
 
 // LogAttrs implementations expose each tool's structured-log attributes so
@@ -149,3 +196,11 @@ func (r SearchByPhoneResult) LogAttrs() []any { return []any{"found", r.Found} }
 
 // LogAttrs returns structured-log attributes for the P-number result.
 func (r GetByPNumberResult) LogAttrs() []any { return []any{"found", r.Found} }
+
+// LogAttrs returns structured-log attributes for the batch lookup.
+func (a BatchGetCompaniesArgs) LogAttrs() []any { return []any{"cvrs_count", len(a.CVRs)} }
+
+// LogAttrs returns structured-log attributes for the batch result.
+func (r BatchGetCompaniesResult) LogAttrs() []any {
+	return []any{"companies", len(r.Companies), "missing", len(r.Missing)}
+}
@@ -65,6 +65,16 @@ func TestLogAttrs(t *testing.T) {
 			GetByPNumberResult{Found: false}.LogAttrs(),
 			[]any{"found", false},
 		},
+		{
+			"BatchGetCompaniesArgs",
+			BatchGetCompaniesArgs{CVRs: []string{"10150817", "24256790"}}.LogAttrs(),
+			[]any{"cvrs_count", 2},
+		},
+		{
+			"BatchGetCompaniesResult",
+			BatchGetCompaniesResult{Companies: []CompanySummary{{CVR: "10150817"}}, Missing: []string{"99999999"}}.LogAttrs(),
+			[]any{"companies", 1, "missing", 1},
+		},
 	}
 
 	for _, tt := range tests {
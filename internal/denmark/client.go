@@ -19,12 +19,6 @@ const (
 	// BaseURL is the CVR API endpoint
 	BaseURL = "https://cvrapi.dk/api"
 
-	// SearchCacheTTL for search results (shorter to ensure fresher results)
-	SearchCacheTTL = 2 * time.Minute
-
-	// DefaultCacheTTL for company details and other cached responses
-	DefaultCacheTTL = 5 * time.Minute
-
 	// DefaultUserAgent is the default user agent for CVR API requests
 	DefaultUserAgent = "nordic-registry-mcp-server/1.0 (github.com/olgasafonova/nordic-registry-mcp-server)"
 )
@@ -53,13 +47,45 @@ func WithLogger(l *slog.Logger) ClientOption {
 	}
 }
 
-// WithCache sets a custom cache
+// WithCache sets a custom cache, closing the one it replaces
 func WithCache(c *infra.Cache) ClientOption {
 	return func(client *Client) {
+		if client.Cache != nil && client.Cache != c {
+			client.Cache.Close()
+		}
 		client.Cache = c
 	}
 }
 
+// WithCachePolicy sets the cache TTLs of the client's operations
+func WithCachePolicy(p *infra.CachePolicy) ClientOption {
+	return func(client *Client) {
+		client.CachePolicy = p
+	}
+}
+
+// WithBreakerPolicy sets the client's circuit breaker from the policy
+func WithBreakerPolicy(p *infra.BreakerPolicy) ClientOption {
+	return func(client *Client) {
+		client.CircuitBreaker = p.NewCircuitBreaker("denmark")
+	}
+}
+
+// WithHedging turns on hedged requests with cfg; nil turns them off
+func WithHedging(cfg *base.HedgeConfig) ClientOption {
+	return func(client *Client) {
+		base.WithHedging(cfg)(client.Client)
+	}
+}
+
+// WithRateLimitPolicy sets the request budget of the client's host from the
+// policy
+func WithRateLimitPolicy(p *infra.RateLimitPolicy) ClientOption {
+	return func(client *Client) {
+		client.RateLimiter = p.NewRateLimiter("denmark")
+	}
+}
+
 // WithBaseURL sets a custom base URL (for testing)
 func WithBaseURL(url string) ClientOption {
 	return func(client *Client) {
@@ -70,7 +96,11 @@ func WithBaseURL(url string) ClientOption {
 // NewClient creates a new CVR API client
 func NewClient(opts ...ClientOption) *Client {
 	c := &Client{
-		Client:    base.NewClient(),
+		Client: base.NewClient(
+			base.WithRegistry("denmark"),
+			base.WithCircuitBreaker(infra.DefaultBreakerPolicy().NewCircuitBreaker("denmark")),
+			base.WithRateLimiter(infra.DefaultRateLimitPolicy().NewRateLimiter("denmark")),
+		),
 		baseURL:   BaseURL,
 		userAgent: DefaultUserAgent,
 	}
@@ -95,22 +125,18 @@ type lookupRequest struct {
 // req.ttl. All single-result Denmark lookups (search, P-number, phone) share
 // this flow; only the request descriptor differs.
 func (c *Client) cachedLookup(ctx context.Context, req lookupRequest) (*Company, error) {
-	if cached, ok := c.Cache.Get(req.cacheKey); ok {
-		return cached.(*Company), nil
-	}
-
-	var result Company
-	if err := c.doRequest(ctx, req.params, &result); err != nil {
-		return nil, err
-	}
-
-	// Check if we got a valid result
-	if result.CVR == 0 {
-		return nil, apierrors.NewNotFoundError("denmark", req.notFoundID)
-	}
+	return infra.Fetch(ctx, c.Cache, req.cacheKey, req.ttl, func(ctx context.Context) (*Company, error) {
+		var result Company
+		if err := c.doRequest(ctx, req.params, &result); err != nil {
+			return nil, err
+		}
 
-	c.Cache.Set(req.cacheKey, &result, req.ttl)
-	return &result, nil
+		// Check if we got a valid result
+		if result.CVR == 0 {
+			return nil, apierrors.NewNotFoundError("denmark", req.notFoundID)
+		}
+		return &result, nil
+	})
 }
 
 // SearchCompany searches for a company by name
@@ -123,7 +149,7 @@ func (c *Client) SearchCompany(ctx context.Context, query string) (*Company, err
 		params:     params,
 		cacheKey:   "search:" + params.Encode(),
 		notFoundID: query,
-		ttl:        SearchCacheTTL,
+		ttl:        c.CachePolicy.TTL("denmark", "search"),
 	})
 }
 
@@ -141,7 +167,7 @@ func (c *Client) lookupByParam(ctx context.Context, paramKey, value, cacheKind s
 		params:     params,
 		cacheKey:   cacheKey,
 		notFoundID: cacheKey,
-		ttl:        DefaultCacheTTL,
+		ttl:        c.CachePolicy.TTL("denmark", cacheKind),
 	})
 }
 
@@ -182,49 +208,52 @@ func (c *Client) GetCompany(ctx context.Context, cvr string) (*Company, error) {
 	}
 
 	cacheKey := "company:" + cvr
-	if cached, ok := c.Cache.Get(cacheKey); ok {
-		return cached.(*Company), nil
-	}
-
-	// Use deduplication to avoid duplicate requests for the same CVR
-	result, _, err := c.Dedup.Do(ctx, cacheKey, func() (interface{}, error) {
-		params := url.Values{}
-		params.Set("vat", cvr)
-		params.Set("country", "dk")
-
-		var company Company
-		if err := c.doRequest(ctx, params, &company); err != nil {
+	return infra.Fetch(ctx, c.Cache, cacheKey, c.CachePolicy.TTL("denmark", "company"), func(ctx context.Context) (*Company, error) {
+		// Use deduplication to avoid duplicate requests for the same CVR
+		result, _, err := c.Dedup.Do(ctx, cacheKey, func() (interface{}, error) {
+			params := url.Values{}
+			params.Set("vat", cvr)
+			params.Set("country", "dk")
+
+			var company Company
+			if err := c.doRequest(ctx, params, &company); err != nil {
+				return nil, err
+			}
+
+			// Check if we got a valid result
+			if company.CVR == 0 {
+				return nil, apierrors.NewNotFoundError("denmark", cvr)
+			}
+
+			return &company, nil
+		})
+		if err != nil {
 			return nil, err
 		}
-
-		// Check if we got a valid result
-		if company.CVR == 0 {
-			return nil, apierrors.NewNotFoundError("denmark", cvr)
-		}
-
-		return &company, nil
+		return result.(*Company), nil
 	})
+}
 
-	if err != nil {
-		return nil, err
-	}
-
-	company := result.(*Company)
-	c.Cache.Set(cacheKey, company, DefaultCacheTTL)
-	return company, nil
+// InvalidateCompany drops what is cached under a CVR number, or under a
+// P-number or phone number, so the next read goes to the registry.
+func (c *Client) InvalidateCompany(identifier string) {
+	c.Cache.Delete("company:" + NormalizeCVR(identifier))
+	c.Cache.Delete("pnumber:" + identifier)
+	c.Cache.Delete("phone:" + identifier)
 }
 
 // doRequest performs an HTTP request using the base client infrastructure
 func (c *Client) doRequest(ctx context.Context, params url.Values, result interface{}) error {
 	reqURL := c.baseURL + "?" + params.Encode()
 
-	body, statusCode, err := c.Client.DoRequest(ctx, base.RequestConfig{
+	resp, err := c.Client.DoRequest(ctx, base.RequestConfig{
 		URL:       reqURL,
 		UserAgent: c.userAgent,
 	})
 	if err != nil {
 		return err
 	}
+	body, statusCode := resp.Body, resp.StatusCode
 
 	if errResp := c.classifyError(statusCode, body, params); errResp != nil {
 		return errResp
@@ -6,27 +6,52 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/base"
 	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/legalform"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/nace"
 )
 
+// CVR reports industrycode values without saying whether they are DB07 or
+// DB25 codes; nace.FromRegistry tells them apart by the code.
+const (
+	industryScheme     = nace.SchemeDB07
+	industryScheme2025 = nace.SchemeDB25
+)
+
+// legalformCountry keys the companydesc values in the legal-form table.
+const legalformCountry = "denmark"
+
 // MCP Tool wrapper methods
 // These methods wrap the client methods with Args/Result types for MCP integration.
 
+// industryNACE maps the company's DB07 industry code to NACE. CVR sends the
+// code as a number, so leading zeros (agriculture, 01xxxx) are restored first.
+func industryNACE(company *Company) *nace.Code {
+	if company.IndustryCode <= 0 {
+		return nil
+	}
+	return nace.FromRegistry(industryScheme, industryScheme2025, fmt.Sprintf("%06d", company.IndustryCode), company.IndustryDesc)
+}
+
 // toCompanySummary maps a CVR company record to the shared summary shape.
 func toCompanySummary(company *Company) *CompanySummary {
 	return &CompanySummary{
-		CVR:          strconv.Itoa(company.CVR),
-		Name:         company.Name,
-		Address:      company.Address,
-		City:         company.City,
-		Zipcode:      company.Zipcode,
-		CompanyType:  company.CompanyType,
-		IndustryDesc: company.IndustryDesc,
-		Employees:    strconv.Itoa(company.Employees),
-		StartDate:    company.StartDate,
-		Status:       getStatus(company),
-		Phone:        company.Phone,
-		Email:        company.Email,
+		CVR:            strconv.Itoa(company.CVR),
+		Name:           company.Name,
+		Address:        company.Address,
+		City:           company.City,
+		Zipcode:        company.Zipcode,
+		CompanyType:    company.CompanyType,
+		IndustryDesc:   company.IndustryDesc,
+		Employees:      strconv.Itoa(company.Employees),
+		StartDate:      company.StartDate,
+		Status:         getStatus(company),
+		Phone:          company.Phone,
+		Email:          company.Email,
+		NACE:           industryNACE(company),
+		LegalFormClass: legalform.Lookup(legalformCountry, company.CompanyType),
 	}
 }
 
@@ -65,7 +90,32 @@ func (c *Client) SearchCompaniesMCP(ctx context.Context, args SearchCompaniesArg
 	if err != nil {
 		return SearchCompaniesResult{}, err
 	}
+	if outcome.Found && !strings.EqualFold(outcome.Company.Name, query) {
+		return confirmBestMatch(ctx, query, outcome)
+	}
+
+	return SearchCompaniesResult(outcome), nil
+}
 
+// confirmBestMatch asks the user, when the client supports it, whether the
+// single company CVR returned for query is the one they mean. Unless the user
+// rejects it, the match is returned as before.
+func confirmBestMatch(ctx context.Context, query string, outcome companyLookup) (SearchCompaniesResult, error) {
+	co := outcome.Company
+	label := infra.Label(co.Name, "CVR "+co.CVR, co.CompanyType, co.City, co.Status)
+	answer, err := infra.Ask(ctx, infra.Question{
+		Key:     "company",
+		Message: fmt.Sprintf("CVR returns only its best match for %q: %s. Is this the company you mean?", query, label),
+	})
+	if err != nil {
+		return SearchCompaniesResult{}, err
+	}
+	if answer.Answered && !answer.Confirmed {
+		return SearchCompaniesResult{
+			Found:   false,
+			Message: fmt.Sprintf("The user rejected %s as the match for %q. Search again with the exact legal name, or use the CVR number.", label, query),
+		}, nil
+	}
 	return SearchCompaniesResult(outcome), nil
 }
 
@@ -100,11 +150,47 @@ func (c *Client) GetCompanyMCP(ctx context.Context, args GetCompanyArgs) (GetCom
 		Phone:           company.Phone,
 		Email:           company.Email,
 		ProductionUnits: len(company.ProductionUnits),
+		NACE:            industryNACE(company),
+		LegalFormClass:  legalform.Lookup(legalformCountry, company.CompanyType),
 	}
 
 	return GetCompanyResult{Summary: summary}, nil
 }
 
+// BatchGetCompaniesMCP looks up several CVR numbers by fanning out over
+// GetCompany. At most Concurrency() lookups run at once, so a batch queues
+// behind the same semaphore as every other Danish request. Malformed, unknown
+// and failed CVRs are reported per item instead of failing the call.
+func (c *Client) BatchGetCompaniesMCP(ctx context.Context, args BatchGetCompaniesArgs) (BatchGetCompaniesResult, error) {
+	if len(args.CVRs) == 0 {
+		return BatchGetCompaniesResult{}, nil
+	}
+	if len(args.CVRs) > MaxBatchSize {
+		return BatchGetCompaniesResult{}, apierrors.NewValidationError("cvrs", fmt.Sprintf("%d items", len(args.CVRs)),
+			fmt.Sprintf("exceeds maximum of %d CVR numbers per request", MaxBatchSize))
+	}
+
+	res := base.BatchLookup(ctx, args.CVRs, base.BatchSpec[CompanySummary]{
+		Workers:   c.Concurrency(),
+		Normalize: NormalizeCVR,
+		Validate:  ValidateCVR,
+		Fetch: func(ctx context.Context, cvr string) (CompanySummary, error) {
+			company, err := c.GetCompany(ctx, cvr)
+			if err != nil {
+				return CompanySummary{}, err
+			}
+			return *toCompanySummary(company), nil
+		},
+	})
+
+	return BatchGetCompaniesResult{
+		Companies:    res.Found,
+		TotalResults: len(res.Found),
+		Missing:      res.Missing,
+		Errors:       res.Errors,
+	}, nil
+}
+
 // Default and max page sizes for Denmark production units
 const (
 	DefaultProductionUnitsPageSize = 20
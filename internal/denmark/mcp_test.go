@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
 	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
 )
 
@@ -212,6 +213,7 @@ func TestGetCompanyMCP_Summary(t *testing.T) {
 			Zipcode:      "2880",
 			City:         "Bagsværd",
 			CompanyType:  "Aktieselskab",
+			IndustryCode: 212000,
 			IndustryDesc: "Fremstilling af farmaceutiske præparater",
 			Employees:    45000,
 			StartDate:    "01/01 - 1925",
@@ -249,6 +251,22 @@ func TestGetCompanyMCP_Summary(t *testing.T) {
 	if result.Summary.Employees != 45000 {
 		t.Errorf("Employees = %d, want %d", result.Summary.Employees, 45000)
 	}
+	if n := result.Summary.NACE; n == nil || n.Code != "21.20" || n.NationalCode != "212000" {
+		t.Errorf("NACE = %+v, want 21.20 from DB07 212000", n)
+	}
+	if f := result.Summary.LegalFormClass; f == nil || f.Code != "A/S" || f.ELF == "" {
+		t.Errorf("LegalFormClass = %+v, want A/S with an ELF code", f)
+	}
+}
+
+func TestIndustryNACE_LeadingZero(t *testing.T) {
+	n := industryNACE(&Company{IndustryCode: 11100, IndustryDesc: "Dyrkning af korn"})
+	if n == nil || n.Code != "01.11" || n.Section != "A" {
+		t.Errorf("industryNACE(011100) = %+v, want 01.11 in section A", n)
+	}
+	if n := industryNACE(&Company{}); n != nil {
+		t.Errorf("industryNACE(0) = %+v, want nil", n)
+	}
 }
 
 func TestGetCompanyMCP_Full(t *testing.T) {
@@ -954,3 +972,66 @@ func TestGetProductionUnitsMCP_NegativeSize(t *testing.T) {
 		t.Errorf("Size = %d, want %d (default)", result.Size, 20)
 	}
 }
+
+// =============================================================================
+// BatchGetCompaniesMCP Tests
+// =============================================================================
+
+func TestBatchGetCompaniesMCP_PerItemOutcomes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("vat") {
+		case "10150817":
+			_ = json.NewEncoder(w).Encode(Company{CVR: 10150817, Name: "NOVO NORDISK A/S"})
+		case "24256790":
+			_ = json.NewEncoder(w).Encode(Company{CVR: 24256790, Name: "CARLSBERG A/S", EndDate: "01/01 - 2020"})
+		case "11111111":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+
+	result, err := client.BatchGetCompaniesMCP(ctx(), BatchGetCompaniesArgs{
+		CVRs: []string{"10150817", "DK-24256790", "99999999", "123", "11111111", "DK10150817"},
+	})
+	if err != nil {
+		t.Fatalf("BatchGetCompaniesMCP failed: %v", err)
+	}
+
+	if result.TotalResults != 2 || len(result.Companies) != 2 {
+		t.Fatalf("expected 2 companies, got %d (%d)", len(result.Companies), result.TotalResults)
+	}
+	if result.Companies[0].Name != "NOVO NORDISK A/S" || result.Companies[1].Status != "DISSOLVED" {
+		t.Errorf("unexpected companies: %+v", result.Companies)
+	}
+
+	wantMissing := []string{"99999999", "123", "11111111"}
+	if len(result.Missing) != len(wantMissing) {
+		t.Fatalf("Missing = %v, want %v", result.Missing, wantMissing)
+	}
+	wantReasons := []string{"not_found", "invalid", "upstream_error"}
+	for i, e := range result.Errors {
+		if e.ID != wantMissing[i] || e.Reason != wantReasons[i] {
+			t.Errorf("Errors[%d] = %+v, want id %q reason %q", i, e, wantMissing[i], wantReasons[i])
+		}
+	}
+}
+
+func TestBatchGetCompaniesMCP_Limits(t *testing.T) {
+	client := NewClient()
+	defer client.Close()
+
+	result, err := client.BatchGetCompaniesMCP(ctx(), BatchGetCompaniesArgs{})
+	if err != nil || len(result.Companies) != 0 {
+		t.Errorf("empty input: result %+v, err %v", result, err)
+	}
+
+	_, err = client.BatchGetCompaniesMCP(ctx(), BatchGetCompaniesArgs{CVRs: make([]string, MaxBatchSize+1)})
+	if !apierrors.IsValidation(err) {
+		t.Errorf("above MaxBatchSize: err = %v, want a validation error", err)
+	}
+}
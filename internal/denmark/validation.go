@@ -34,6 +34,10 @@ func ValidateAndNormalizeCVR(cvr string) (string, error) {
 	return normalized, nil
 }
 
+// MaxBatchSize is the maximum number of CVR numbers per batch lookup. Each one
+// costs a CVR API request, unlike Norway's single-request batch endpoint.
+const MaxBatchSize = 100
+
 // MaxQueryLength is the maximum allowed search query length
 const MaxQueryLength = 500
 
@@ -80,3 +84,21 @@ func ValidatePNumber(pnumber string) error {
 	}
 	return nil
 }
+
+// cvrWeights are the modulus-11 weights applied to the eight CVR digits.
+var cvrWeights = []int{2, 7, 6, 5, 4, 3, 2, 1}
+
+// ValidCVRChecksum reports whether an 8-digit CVR number passes the
+// modulus-11 check (the weighted digit sum must be divisible by 11). The input
+// must already be normalized; anything that is not exactly 8 digits is
+// reported as invalid.
+func ValidCVRChecksum(cvr string) bool {
+	if !cvrRegex.MatchString(cvr) {
+		return false
+	}
+	sum := 0
+	for i, w := range cvrWeights {
+		sum += int(cvr[i]-'0') * w
+	}
+	return sum%11 == 0
+}
@@ -161,3 +161,24 @@ func TestValidatePNumber(t *testing.T) {
 		})
 	}
 }
+
+func TestValidCVRChecksum(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{"valid", "10150817", true},
+		{"wrong check digit", "10150818", false},
+		{"too short", "1015081", false},
+		{"not normalized", "DK10150817", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidCVRChecksum(tt.input); got != tt.want {
+				t.Errorf("ValidCVRChecksum(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
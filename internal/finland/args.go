@@ -1,12 +1,21 @@
 package finland
 
+import (
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/base"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/legalform"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/lei"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/nace"
+)
+
 // Tag convention (verified against google/jsonschema-go, which the go-sdk uses
-// to derive input schemas): the `jsonschema:"..."` tag VALUE becomes the
-// property description, and a field is REQUIRED unless its `json` tag carries
-// `,omitempty`. There is no `required` keyword — `jsonschema:"required"` merely
-// sets the description to the literal word "required". Enum, minimum, maximum
-// and pattern constraints are not derivable from tags; they are enforced in the
-// handlers (see validation.go) and described in the tag text.
+// to derive input and output schemas): the `jsonschema:"..."` tag VALUE becomes
+// the property description, and a field is REQUIRED unless its `json` tag
+// carries `,omitempty` (for result types: always present). There is no
+// `required` keyword — `jsonschema:"required"` merely sets the description to
+// the literal word "required". Enum, minimum, maximum and pattern constraints
+// are not derivable from tags; they are enforced in the handlers
+// (see validation.go) and described in the tag text.
 
 // SearchCompaniesArgs contains parameters for company search
 type SearchCompaniesArgs struct {
@@ -19,97 +28,136 @@ type SearchCompaniesArgs struct {
 
 // SearchCompaniesResult is the result of a company search
 type SearchCompaniesResult struct {
-	Companies    []CompanySummary `json:"companies"`
-	TotalResults int              `json:"total_results"`
-	Page         int              `json:"page"`
-	Size         int              `json:"size"`
-	HasMore      bool             `json:"has_more"`
+	Companies    []CompanySummary `json:"companies" jsonschema:"Companies on this page"`
+	TotalResults int              `json:"total_results" jsonschema:"Total number of matches"`
+	Page         int              `json:"page" jsonschema:"Page number, 0-indexed"`
+	Size         int              `json:"size" jsonschema:"Page size used"`
+	HasMore      bool             `json:"has_more" jsonschema:"Whether more pages follow"`
+	PickedByUser bool             `json:"picked_by_user,omitempty" jsonschema:"Whether the user picked the one company in companies from the matches, when the client supports elicitation"`
 }
 
 // CompanySummary is a simplified company representation for search results
 type CompanySummary struct {
-	BusinessID       string `json:"business_id"`
-	Name             string `json:"name"`
-	CompanyForm      string `json:"company_form,omitempty"`
-	CompanyFormDesc  string `json:"company_form_desc,omitempty"`
-	City             string `json:"city,omitempty"`
-	PostCode         string `json:"post_code,omitempty"`
-	StreetAddress    string `json:"street_address,omitempty"`
-	Industry         string `json:"industry,omitempty"`
-	IndustryCode     string `json:"industry_code,omitempty"`
-	Website          string `json:"website,omitempty"`
-	RegistrationDate string `json:"registration_date,omitempty"`
-	Status           string `json:"status,omitempty"`
+	BusinessID       string          `json:"business_id" jsonschema:"Finnish business ID (Y-tunnus), e.g. 0112038-9"`
+	Name             string          `json:"name" jsonschema:"Current company name"`
+	CompanyForm      string          `json:"company_form,omitempty" jsonschema:"Current company form code, e.g. OY or OYJ"`
+	CompanyFormDesc  string          `json:"company_form_desc,omitempty" jsonschema:"English description of the company form"`
+	City             string          `json:"city,omitempty" jsonschema:"City of the street address"`
+	PostCode         string          `json:"post_code,omitempty" jsonschema:"Postal code of the street address"`
+	StreetAddress    string          `json:"street_address,omitempty" jsonschema:"Street address"`
+	Industry         string          `json:"industry,omitempty" jsonschema:"Main line of business, in English where available"`
+	IndustryCode     string          `json:"industry_code,omitempty" jsonschema:"TOL 2008 code of the main line of business"`
+	Website          string          `json:"website,omitempty" jsonschema:"Company website"`
+	RegistrationDate string          `json:"registration_date,omitempty" jsonschema:"Registration date, YYYY-MM-DD"`
+	Status           string          `json:"status,omitempty" jsonschema:"Trade register status: Registered, Active, Dissolved, Liquidation or Bankruptcy"`
+	NACE             *nace.Code      `json:"nace,omitempty" jsonschema:"Main line of business mapped to NACE"`
+	LegalFormClass   *legalform.Form `json:"legal_form_class,omitempty" jsonschema:"Legal form mapped to category, owner liability and ELF code"`
 }
 
 // GetCompanyArgs contains parameters for getting a company by business ID
 type GetCompanyArgs struct {
 	BusinessID string `json:"business_id" jsonschema:"Finnish business ID (Y-tunnus): 7 digits, a hyphen, then a mod-11 check digit, e.g. 0112038-9. A leading FI prefix is stripped automatically and the check digit is verified"`
 	Full       bool   `json:"full,omitempty" jsonschema:"Return the full company record (previous names, auxiliary names, registry entries, situations) instead of the compact summary (default false)"`
+
+	infra.CacheControl
 }
 
 // GetCompanyResult is the result of getting a company
 type GetCompanyResult struct {
-	Company *CompanyDetails       `json:"company,omitempty"` // Full company (when full=true)
-	Summary *CompanyDetailSummary `json:"summary,omitempty"` // Summary (default)
+	Company *CompanyDetails       `json:"company,omitempty" jsonschema:"Full company record, when full=true"`
+	Summary *CompanyDetailSummary `json:"summary,omitempty" jsonschema:"Compact company record, the default"`
+	LEI     *lei.Entity           `json:"lei,omitempty" jsonschema:"Legal Entity Identifier from the GLEIF golden copy, when configured and the company has one"`
+
+	infra.Freshness
 }
 
+// LEIKey returns the jurisdiction and business ID for LEI enrichment.
+func (r *GetCompanyResult) LEIKey() (string, string) {
+	switch {
+	case r.Summary != nil:
+		return "FI", r.Summary.BusinessID
+	case r.Company != nil:
+		return "FI", r.Company.BusinessID
+	}
+	return "", ""
+}
+
+// SetLEI attaches the company's LEI entity.
+func (r *GetCompanyResult) SetLEI(e *lei.Entity) { r.LEI = e }
+
 // CompanyDetailSummary is a compact company representation for get_company responses
 type CompanyDetailSummary struct {
-	BusinessID       string `json:"business_id"`
-	Name             string `json:"name"`
-	CompanyForm      string `json:"company_form,omitempty"`
-	Industry         string `json:"industry,omitempty"`
-	City             string `json:"city,omitempty"`
-	StreetAddress    string `json:"street_address,omitempty"`
-	Website          string `json:"website,omitempty"`
-	RegistrationDate string `json:"registration_date,omitempty"`
-	Status           string `json:"status,omitempty"`
+	BusinessID       string          `json:"business_id" jsonschema:"Finnish business ID (Y-tunnus), e.g. 0112038-9"`
+	Name             string          `json:"name" jsonschema:"Current company name"`
+	CompanyForm      string          `json:"company_form,omitempty" jsonschema:"Company form code and description"`
+	Industry         string          `json:"industry,omitempty" jsonschema:"TOL 2008 code and description of the main line of business"`
+	City             string          `json:"city,omitempty" jsonschema:"City of the street address"`
+	StreetAddress    string          `json:"street_address,omitempty" jsonschema:"Street address"`
+	Website          string          `json:"website,omitempty" jsonschema:"Company website"`
+	RegistrationDate string          `json:"registration_date,omitempty" jsonschema:"Registration date, YYYY-MM-DD"`
+	Status           string          `json:"status,omitempty" jsonschema:"Trade register status: Registered, Active, Dissolved, Liquidation or Bankruptcy"`
+	NACE             *nace.Code      `json:"nace,omitempty" jsonschema:"Main line of business mapped to NACE"`
+	LegalFormClass   *legalform.Form `json:"legal_form_class,omitempty" jsonschema:"Legal form mapped to category, owner liability and ELF code"`
 }
 
 // CompanyDetails contains full company information
 type CompanyDetails struct {
-	BusinessID       string             `json:"business_id"`
-	EUID             string             `json:"eu_id,omitempty"`
-	Name             string             `json:"name"`
-	PreviousNames    []string           `json:"previous_names,omitempty"`
-	AuxiliaryNames   []string           `json:"auxiliary_names,omitempty"`
-	CompanyForm      string             `json:"company_form,omitempty"`
-	CompanyFormDesc  string             `json:"company_form_desc,omitempty"`
-	Industry         string             `json:"industry,omitempty"`
-	IndustryCode     string             `json:"industry_code,omitempty"`
-	Website          string             `json:"website,omitempty"`
-	StreetAddress    *AddressSummary    `json:"street_address,omitempty"`
-	PostalAddress    *AddressSummary    `json:"postal_address,omitempty"`
-	RegistrationDate string             `json:"registration_date,omitempty"`
-	BusinessIDDate   string             `json:"business_id_date,omitempty"`
-	Status           string             `json:"status,omitempty"`
-	StatusDesc       string             `json:"status_desc,omitempty"`
-	Situations       []SituationInfo    `json:"situations,omitempty"`
-	Registrations    []RegistrationInfo `json:"registrations,omitempty"`
-	LastModified     string             `json:"last_modified,omitempty"`
+	BusinessID       string             `json:"business_id" jsonschema:"Finnish business ID (Y-tunnus), e.g. 0112038-9"`
+	EUID             string             `json:"eu_id,omitempty" jsonschema:"European Unique Identifier (EUID)"`
+	Name             string             `json:"name" jsonschema:"Current company name"`
+	PreviousNames    []string           `json:"previous_names,omitempty" jsonschema:"Earlier company names"`
+	AuxiliaryNames   []string           `json:"auxiliary_names,omitempty" jsonschema:"Auxiliary business names (aputoiminimet)"`
+	CompanyForm      string             `json:"company_form,omitempty" jsonschema:"Current company form code, e.g. OY or OYJ"`
+	CompanyFormDesc  string             `json:"company_form_desc,omitempty" jsonschema:"English description of the company form"`
+	Industry         string             `json:"industry,omitempty" jsonschema:"Main line of business, in English where available"`
+	IndustryCode     string             `json:"industry_code,omitempty" jsonschema:"TOL 2008 code of the main line of business"`
+	NACE             *nace.Code         `json:"nace,omitempty" jsonschema:"Main line of business mapped to NACE"`
+	LegalFormClass   *legalform.Form    `json:"legal_form_class,omitempty" jsonschema:"Legal form mapped to category, owner liability and ELF code"`
+	Website          string             `json:"website,omitempty" jsonschema:"Company website"`
+	StreetAddress    *AddressSummary    `json:"street_address,omitempty" jsonschema:"Current street address"`
+	PostalAddress    *AddressSummary    `json:"postal_address,omitempty" jsonschema:"Current postal address"`
+	RegistrationDate string             `json:"registration_date,omitempty" jsonschema:"Trade register registration date, YYYY-MM-DD"`
+	BusinessIDDate   string             `json:"business_id_date,omitempty" jsonschema:"Date the business ID was assigned, YYYY-MM-DD"`
+	Status           string             `json:"status,omitempty" jsonschema:"Trade register status code: 1 registered, 2 active, 3 dissolved, 4 liquidation, 5 bankruptcy"`
+	StatusDesc       string             `json:"status_desc,omitempty" jsonschema:"Trade register status in words"`
+	Situations       []SituationInfo    `json:"situations,omitempty" jsonschema:"Ongoing or past situations such as liquidation, bankruptcy or restructuring"`
+	Registrations    []RegistrationInfo `json:"registrations,omitempty" jsonschema:"Registers the company is entered in, such as the trade register or VAT register"`
+	LastModified     string             `json:"last_modified,omitempty" jsonschema:"When PRH last modified the record"`
 }
 
 // AddressSummary is a simplified address for MCP responses
 type AddressSummary struct {
-	Street   string `json:"street,omitempty"`
-	PostCode string `json:"post_code,omitempty"`
-	City     string `json:"city,omitempty"`
+	Street   string `json:"street,omitempty" jsonschema:"Street, building and apartment"`
+	PostCode string `json:"post_code,omitempty" jsonschema:"Postal code"`
+	City     string `json:"city,omitempty" jsonschema:"City"`
 }
 
 // SituationInfo describes company situations (liquidation, bankruptcy, etc.)
 type SituationInfo struct {
-	Type      string `json:"type"`
-	StartDate string `json:"start_date,omitempty"`
-	EndDate   string `json:"end_date,omitempty"`
+	Type      string `json:"type" jsonschema:"Situation, e.g. liquidation, bankruptcy or restructuring"`
+	StartDate string `json:"start_date,omitempty" jsonschema:"Date the situation was registered, YYYY-MM-DD"`
+	EndDate   string `json:"end_date,omitempty" jsonschema:"Date the situation ended, YYYY-MM-DD"`
 }
 
 // RegistrationInfo describes registry entries
 type RegistrationInfo struct {
-	Register  string `json:"register"`
-	Status    string `json:"status"`
-	Authority string `json:"authority,omitempty"`
-	Date      string `json:"date,omitempty"`
+	Register  string `json:"register" jsonschema:"Register name, in English where available"`
+	Status    string `json:"status" jsonschema:"Registration status code in the register"`
+	Authority string `json:"authority,omitempty" jsonschema:"Authority keeping the register"`
+	Date      string `json:"date,omitempty" jsonschema:"Registration date, YYYY-MM-DD"`
+}
+
+// BatchGetCompaniesArgs contains parameters for batch company lookup
+type BatchGetCompaniesArgs struct {
+	BusinessIDs []string `json:"business_ids" jsonschema:"Finnish business IDs (Y-tunnus) to look up, max 100, e.g. 0112038-9. A leading FI prefix is stripped and the check digit is verified. Malformed or unknown entries are reported under errors and missing rather than failing the call"`
+}
+
+// BatchGetCompaniesResult is the result of batch company lookup
+type BatchGetCompaniesResult struct {
+	Companies    []CompanySummary      `json:"companies" jsonschema:"Companies found, in input order"`
+	TotalResults int                   `json:"total_results" jsonschema:"Number of companies found"`
+	Missing      []string              `json:"missing,omitempty" jsonschema:"Input business IDs with no company, as given by the caller"`
+	Errors       []base.BatchItemError `json:"errors,omitempty" jsonschema:"Why each missing business ID failed"`
 }
 
 // LogAttrs implementations expose each tool's structured-log attributes so
@@ -125,3 +173,13 @@ func (a GetCompanyArgs) LogAttrs() []any { return []any{"business_id", a.Busines
 func (r SearchCompaniesResult) LogAttrs() []any {
 	return []any{"results_count", len(r.Companies), "total_results", r.TotalResults}
 }
+
+// LogAttrs returns structured-log attributes for the batch lookup.
+func (a BatchGetCompaniesArgs) LogAttrs() []any {
+	return []any{"business_ids_count", len(a.BusinessIDs)}
+}
+
+// LogAttrs returns structured-log attributes for the batch result.
+func (r BatchGetCompaniesResult) LogAttrs() []any {
+	return []any{"companies", len(r.Companies), "missing", len(r.Missing)}
+}
@@ -36,6 +36,16 @@ func TestLogAttrs(t *testing.T) {
 			SearchCompaniesResult{}.LogAttrs(),
 			[]any{"results_count", 0, "total_results", 0},
 		},
+		{
+			"BatchGetCompaniesArgs",
+			BatchGetCompaniesArgs{BusinessIDs: []string{"0112038-9"}}.LogAttrs(),
+			[]any{"business_ids_count", 1},
+		},
+		{
+			"BatchGetCompaniesResult",
+			BatchGetCompaniesResult{Companies: []CompanySummary{{BusinessID: "0112038-9"}}}.LogAttrs(),
+			[]any{"companies", 1, "missing", 0},
+		},
 	}
 
 	for _, tt := range tests {
@@ -9,7 +9,6 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
-	"time"
 
 	"github.com/olgasafonova/nordic-registry-mcp-server/internal/base"
 	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
@@ -19,9 +18,6 @@ import (
 const (
 	// DefaultBaseURL is the PRH open data API base URL
 	DefaultBaseURL = "https://avoindata.prh.fi/opendata-ytj-api/v3"
-
-	// DefaultCacheTTL for cached responses
-	DefaultCacheTTL = 15 * time.Minute
 )
 
 // Client is a Finnish PRH API client with caching and resilience
@@ -43,13 +39,39 @@ func WithLogger(l *slog.Logger) ClientOption {
 	return base.WithLogger(l)
 }
 
-// WithCache sets a custom cache
+// WithCache sets a custom cache, closing the one it replaces
 func WithCache(c *infra.Cache) ClientOption {
 	return base.WithCache(c)
 }
 
+// WithCachePolicy sets the cache TTLs of the client's operations
+func WithCachePolicy(p *infra.CachePolicy) ClientOption {
+	return base.WithCachePolicy(p)
+}
+
+// WithBreakerPolicy sets the client's circuit breaker from the policy
+func WithBreakerPolicy(p *infra.BreakerPolicy) ClientOption {
+	return base.WithCircuitBreaker(p.NewCircuitBreaker("finland"))
+}
+
+// WithHedging turns on hedged requests with cfg; nil turns them off
+func WithHedging(cfg *base.HedgeConfig) ClientOption {
+	return base.WithHedging(cfg)
+}
+
+// WithRateLimitPolicy sets the request budget of the client's host from the
+// policy
+func WithRateLimitPolicy(p *infra.RateLimitPolicy) ClientOption {
+	return base.WithRateLimiter(p.NewRateLimiter("finland"))
+}
+
 // NewClient creates a new Finnish PRH API client
 func NewClient(opts ...ClientOption) *Client {
+	opts = append([]ClientOption{
+		base.WithRegistry("finland"),
+		WithBreakerPolicy(infra.DefaultBreakerPolicy()),
+		WithRateLimitPolicy(infra.DefaultRateLimitPolicy()),
+	}, opts...)
 	return &Client{
 		Client:  base.NewClient(opts...),
 		baseURL: DefaultBaseURL,
@@ -101,31 +123,26 @@ func (c *Client) SearchCompanies(ctx context.Context, args SearchCompaniesArgs)
 
 	cacheKey := "fi:search:" + params.Encode()
 
-	// Check cache
-	if cached, ok := c.Cache.Get(cacheKey); ok {
-		return cached.(*CompanySearchResponse), nil
-	}
-
-	// Deduplicate concurrent requests
-	result, _, err := c.Dedup.Do(ctx, cacheKey, func() (interface{}, error) {
-		return c.doSearch(ctx, params)
+	return infra.Fetch(ctx, c.Cache, cacheKey, c.CachePolicy.TTL("finland", "search"), func(ctx context.Context) (*CompanySearchResponse, error) {
+		// Deduplicate concurrent requests
+		result, _, err := c.Dedup.Do(ctx, cacheKey, func() (interface{}, error) {
+			return c.doSearch(ctx, params)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result.(*CompanySearchResponse), nil
 	})
-	if err != nil {
-		return nil, err
-	}
-
-	resp := result.(*CompanySearchResponse)
-	c.Cache.Set(cacheKey, resp, DefaultCacheTTL)
-	return resp, nil
 }
 
 func (c *Client) doSearch(ctx context.Context, params url.Values) (*CompanySearchResponse, error) {
 	reqURL := fmt.Sprintf("%s/companies?%s", c.baseURL, params.Encode())
 
-	body, statusCode, err := c.Client.DoRequest(ctx, base.RequestConfig{URL: reqURL})
+	resp, err := c.Client.DoRequest(ctx, base.RequestConfig{URL: reqURL})
 	if err != nil {
 		return nil, err
 	}
+	body, statusCode := resp.Body, resp.StatusCode
 
 	if statusCode != http.StatusOK {
 		c.RecordSuccess() // Client errors don't indicate service issues
@@ -150,31 +167,35 @@ func (c *Client) GetCompany(ctx context.Context, businessID string) (*Company, e
 
 	cacheKey := "fi:company:" + normalized
 
-	// Check cache
-	if cached, ok := c.Cache.Get(cacheKey); ok {
-		return cached.(*Company), nil
-	}
-
-	// Deduplicate concurrent requests
-	result, _, err := c.Dedup.Do(ctx, cacheKey, func() (interface{}, error) {
-		return c.doGetCompany(ctx, normalized)
+	return infra.Fetch(ctx, c.Cache, cacheKey, c.CachePolicy.TTL("finland", "company"), func(ctx context.Context) (*Company, error) {
+		// Deduplicate concurrent requests
+		result, _, err := c.Dedup.Do(ctx, cacheKey, func() (interface{}, error) {
+			return c.doGetCompany(ctx, normalized)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result.(*Company), nil
 	})
-	if err != nil {
-		return nil, err
-	}
+}
 
-	company := result.(*Company)
-	c.Cache.Set(cacheKey, company, DefaultCacheTTL)
-	return company, nil
+// InvalidateCompany drops the cached record of a company so the next read
+// goes to the registry.
+func (c *Client) InvalidateCompany(businessID string) {
+	if normalized, err := NormalizeBusinessID(businessID); err == nil {
+		businessID = normalized
+	}
+	c.Cache.Delete("fi:company:" + businessID)
 }
 
 func (c *Client) doGetCompany(ctx context.Context, businessID string) (*Company, error) {
 	reqURL := fmt.Sprintf("%s/companies?businessId=%s", c.baseURL, url.QueryEscape(businessID))
 
-	body, statusCode, err := c.Client.DoRequest(ctx, base.RequestConfig{URL: reqURL})
+	resp, err := c.Client.DoRequest(ctx, base.RequestConfig{URL: reqURL})
 	if err != nil {
 		return nil, err
 	}
+	body, statusCode := resp.Body, resp.StatusCode
 
 	if statusCode != http.StatusOK {
 		c.RecordSuccess() // Client errors don't indicate service issues
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/olgasafonova/nordic-registry-mcp-server/internal/base"
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
 	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
 )
 
@@ -556,6 +557,17 @@ func TestToCompanySummary(t *testing.T) {
 	if summary.IndustryCode != "26110" {
 		t.Errorf("IndustryCode = %q, want %q", summary.IndustryCode, "26110")
 	}
+	if summary.NACE == nil || summary.NACE.Code != "26.11" || summary.NACE.Section != "C" {
+		t.Errorf("NACE = %+v, want 26.11 in section C", summary.NACE)
+	}
+
+	company.MainBusinessLine = &BusinessLine{Type: "62100", TypeCodeSet: "TOL2025"}
+	if n := toCompanySummary(company).NACE; n == nil || n.Version != "2.1" || n.NationalScheme != "tol2025" {
+		t.Errorf("NACE = %+v, want TOL 2025 code in Rev.2.1", n)
+	}
+	if f := summary.LegalFormClass; f == nil || f.Code != "OYJ" {
+		t.Errorf("LegalFormClass = %+v, want OYJ", f)
+	}
 	if summary.Website != "www.nokia.com" {
 		t.Errorf("Website = %q, want %q", summary.Website, "www.nokia.com")
 	}
@@ -1837,3 +1849,53 @@ func TestSearchCompanies_DedupConcurrentRequests(t *testing.T) {
 		t.Errorf("Expected 1 API call (deduplicated), got %d", callCount)
 	}
 }
+
+func TestBatchGetCompaniesMCP_PerItemOutcomes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := CompanySearchResponse{}
+		if id := r.URL.Query().Get("businessId"); id == "0112038-9" {
+			resp.Companies = []Company{{
+				BusinessID: BusinessID{Value: id},
+				Names:      []CompanyName{{Name: "Nokia Oyj", Type: "1"}},
+				Status:     "2",
+			}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient().WithBaseURL(server.URL)
+	defer client.Close()
+
+	result, err := client.BatchGetCompaniesMCP(context.Background(), BatchGetCompaniesArgs{
+		BusinessIDs: []string{"FI0112038-9", "0112038-8", "2331972-7", "0112038-9"},
+	})
+	if err != nil {
+		t.Fatalf("BatchGetCompaniesMCP failed: %v", err)
+	}
+
+	if len(result.Companies) != 1 || result.Companies[0].Name != "Nokia Oyj" {
+		t.Fatalf("unexpected companies: %+v", result.Companies)
+	}
+	wantMissing := []string{"0112038-8", "2331972-7"}
+	wantReasons := []string{"invalid", "not_found"}
+	if len(result.Errors) != len(wantMissing) {
+		t.Fatalf("Errors = %+v, want %d entries", result.Errors, len(wantMissing))
+	}
+	for i, e := range result.Errors {
+		if e.ID != wantMissing[i] || e.Reason != wantReasons[i] || result.Missing[i] != wantMissing[i] {
+			t.Errorf("Errors[%d] = %+v, want id %q reason %q", i, e, wantMissing[i], wantReasons[i])
+		}
+	}
+}
+
+func TestBatchGetCompaniesMCP_TooMany(t *testing.T) {
+	client := NewClient()
+	defer client.Close()
+
+	_, err := client.BatchGetCompaniesMCP(context.Background(), BatchGetCompaniesArgs{BusinessIDs: make([]string, MaxBatchSize+1)})
+	if !apierrors.IsValidation(err) {
+		t.Errorf("above MaxBatchSize: err = %v, want a validation error", err)
+	}
+}
@@ -2,9 +2,28 @@ package finland
 
 import (
 	"context"
+	"fmt"
 	"strings"
+
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/base"
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/legalform"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/nace"
+)
+
+// PRH names the classification of each mainBusinessLine in typeCodeSet; when
+// it is missing or unknown, nace.FromRegistry tells TOL 2008 and TOL 2025
+// apart by the code.
+const (
+	industryScheme     = nace.SchemeTOL2008
+	industryScheme2025 = nace.SchemeTOL2025
 )
 
+// legalformCountry keys the companyForm codes and descriptions in the
+// legal-form table.
+const legalformCountry = "finland"
+
 // Default and max page sizes for Finland search
 const (
 	DefaultPageSize = 20
@@ -53,9 +72,65 @@ func (c *Client) SearchCompaniesMCP(ctx context.Context, args SearchCompaniesArg
 		result.Companies = append(result.Companies, toCompanySummary(company))
 	}
 
+	if args.Page == 0 && ambiguousMatch(query, result.Companies) {
+		picked, err := askCompany(ctx, query, result)
+		if err != nil {
+			return SearchCompaniesResult{}, err
+		}
+		if picked != nil {
+			return SearchCompaniesResult{Companies: []CompanySummary{*picked}, TotalResults: 1, Page: 0, Size: size, PickedByUser: true}, nil
+		}
+	}
+
 	return result, nil
 }
 
+// maxChoices bounds the candidates offered when asking the user to pick a
+// company; a form with hundreds of entries helps nobody.
+const maxChoices = 10
+
+// ambiguousMatch reports whether a search matched several companies without
+// one of them carrying exactly the queried name.
+func ambiguousMatch(query string, companies []CompanySummary) bool {
+	if len(companies) < 2 {
+		return false
+	}
+	exact := 0
+	for _, co := range companies {
+		if strings.EqualFold(co.Name, query) {
+			exact++
+		}
+	}
+	return exact != 1
+}
+
+// askCompany asks the user, when the client supports it, which of the first
+// matches they mean. It returns nil when the user was not asked or did not
+// pick one.
+func askCompany(ctx context.Context, query string, result SearchCompaniesResult) (*CompanySummary, error) {
+	candidates := result.Companies[:min(len(result.Companies), maxChoices)]
+	q := infra.Question{
+		Key:     "company",
+		Message: fmt.Sprintf("PRH has %d companies matching %q. Which one do you mean? Decline to get the list of matches instead.", result.TotalResults, query),
+	}
+	for _, co := range candidates {
+		q.Choices = append(q.Choices, infra.Choice{
+			Value: co.BusinessID,
+			Label: infra.Label(co.Name, co.BusinessID, co.CompanyForm, co.City, co.Status),
+		})
+	}
+	answer, err := infra.Ask(ctx, q)
+	if err != nil || !answer.Answered {
+		return nil, err
+	}
+	for i := range candidates {
+		if candidates[i].BusinessID == answer.Value {
+			return &candidates[i], nil
+		}
+	}
+	return nil, nil
+}
+
 // GetCompanyMCP wraps GetCompany for MCP tool handlers
 func (c *Client) GetCompanyMCP(ctx context.Context, args GetCompanyArgs) (GetCompanyResult, error) {
 	if err := ValidateBusinessID(args.BusinessID); err != nil {
@@ -79,6 +154,43 @@ func (c *Client) GetCompanyMCP(ctx context.Context, args GetCompanyArgs) (GetCom
 	return GetCompanyResult{Summary: summary}, nil
 }
 
+// BatchGetCompaniesMCP looks up several business IDs by fanning out over
+// GetCompany. At most Concurrency() lookups run at once, so a batch queues
+// behind the same semaphore as every other Finnish request. Malformed, unknown
+// and failed IDs are reported per item instead of failing the call.
+func (c *Client) BatchGetCompaniesMCP(ctx context.Context, args BatchGetCompaniesArgs) (BatchGetCompaniesResult, error) {
+	if len(args.BusinessIDs) == 0 {
+		return BatchGetCompaniesResult{}, nil
+	}
+	if len(args.BusinessIDs) > MaxBatchSize {
+		return BatchGetCompaniesResult{}, apierrors.NewValidationError("business_ids", fmt.Sprintf("%d items", len(args.BusinessIDs)),
+			fmt.Sprintf("exceeds maximum of %d business IDs per request", MaxBatchSize))
+	}
+
+	res := base.BatchLookup(ctx, args.BusinessIDs, base.BatchSpec[CompanySummary]{
+		Workers: c.Concurrency(),
+		Normalize: func(id string) string {
+			normalized, _ := NormalizeBusinessID(id) // Validate has already accepted id
+			return normalized
+		},
+		Validate: ValidateBusinessID,
+		Fetch: func(ctx context.Context, businessID string) (CompanySummary, error) {
+			company, err := c.GetCompany(ctx, businessID)
+			if err != nil {
+				return CompanySummary{}, err
+			}
+			return toCompanySummary(*company), nil
+		},
+	})
+
+	return BatchGetCompaniesResult{
+		Companies:    res.Found,
+		TotalResults: len(res.Found),
+		Missing:      res.Missing,
+		Errors:       res.Errors,
+	}, nil
+}
+
 // currentCompanyName returns the active name (type=1, no end date) for a
 // PRH Company, falling back to the first name if no active name is recorded.
 func currentCompanyName(c *Company) string {
@@ -132,6 +244,8 @@ func toCompanyDetailSummary(c *Company) *CompanyDetailSummary {
 		Name:             s.Name,
 		CompanyForm:      formatCodeAndDesc(s.CompanyForm, s.CompanyFormDesc),
 		Industry:         formatCodeAndDesc(s.IndustryCode, s.Industry),
+		NACE:             s.NACE,
+		LegalFormClass:   s.LegalFormClass,
 		Website:          s.Website,
 		StreetAddress:    s.StreetAddress,
 		City:             s.City,
@@ -147,6 +261,15 @@ func formatCodeAndDesc(code, desc string) string {
 	return code
 }
 
+// businessLineNACE maps a main business line to NACE in the scheme PRH names
+// for it.
+func businessLineNACE(line *BusinessLine, label string) *nace.Code {
+	if s, ok := nace.SchemeByName(line.TypeCodeSet); ok && s.Country == legalformCountry {
+		return nace.FromNational(s.ID, line.Type, label)
+	}
+	return nace.FromRegistry(industryScheme, industryScheme2025, line.Type, label)
+}
+
 // toCompanySummary converts a Company to CompanySummary
 func toCompanySummary(c Company) CompanySummary {
 	summary := CompanySummary{
@@ -157,10 +280,12 @@ func toCompanySummary(c Company) CompanySummary {
 	}
 
 	summary.CompanyForm, summary.CompanyFormDesc = currentCompanyForm(&c)
+	summary.LegalFormClass = legalform.Lookup(legalformCountry, summary.CompanyForm, summary.CompanyFormDesc)
 
 	if c.MainBusinessLine != nil {
 		summary.IndustryCode = c.MainBusinessLine.Type
 		summary.Industry = getEnglishDesc(c.MainBusinessLine.Descriptions)
+		summary.NACE = businessLineNACE(c.MainBusinessLine, summary.Industry)
 	}
 
 	if c.Website != nil {
@@ -200,11 +325,13 @@ func toCompanyDetails(c *Company) *CompanyDetails {
 	if code, desc := currentCompanyForm(c); code != "" {
 		details.CompanyForm = code
 		details.CompanyFormDesc = desc
+		details.LegalFormClass = legalform.Lookup(legalformCountry, code, desc)
 	}
 
 	if c.MainBusinessLine != nil {
 		details.IndustryCode = c.MainBusinessLine.Type
 		details.Industry = getEnglishDesc(c.MainBusinessLine.Descriptions)
+		details.NACE = businessLineNACE(c.MainBusinessLine, details.Industry)
 	}
 
 	if c.Website != nil {
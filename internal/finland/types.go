@@ -47,9 +47,9 @@ type CompanyName struct {
 	Source           string `json:"source,omitempty"`
 }
 
-// BusinessLine represents the main business activity (TOL 2008)
+// BusinessLine represents the main business activity (TOL 2008 or TOL 2025)
 type BusinessLine struct {
-	Type             string        `json:"type"` // TOL 2008 code
+	Type             string        `json:"type"` // TOL 2008 or TOL 2025 code, per TypeCodeSet
 	Descriptions     []Description `json:"descriptions,omitempty"`
 	TypeCodeSet      string        `json:"typeCodeSet,omitempty"`
 	RegistrationDate string        `json:"registrationDate,omitempty"`
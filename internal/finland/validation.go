@@ -76,6 +76,10 @@ func verifyCheckDigit(businessID string, sum, checkDigit int) error {
 	return nil
 }
 
+// MaxBatchSize is the maximum number of business IDs per batch lookup. Each
+// one costs a PRH API request.
+const MaxBatchSize = 100
+
 // MaxQueryLength is the maximum allowed search query length
 const MaxQueryLength = 500
 
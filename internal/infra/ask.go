@@ -0,0 +1,80 @@
+package infra
+
+import (
+	"context"
+	"strings"
+)
+
+// Question asks the user to settle an ambiguous lookup: pick one of Choices,
+// or, when Choices is empty, confirm or reject what Message describes.
+type Question struct {
+	// Key identifies the question within one tool call. A call that is
+	// suspended for the answer runs again with it, and the answer is matched
+	// back to the question by Key, so it must not change between runs.
+	Key string
+
+	// Message tells the user what is being asked and why.
+	Message string
+
+	// Choices are the candidates to pick from. Their Values must be unique
+	// and non-empty.
+	Choices []Choice
+}
+
+// Choice is one candidate offered in a Question.
+type Choice struct {
+	Value string // Returned in Answer.Value when picked
+	Label string // Shown to the user, see Label
+}
+
+// Answer is the user's response to a Question.
+type Answer struct {
+	// Answered is false when the user could not be asked (no AskFunc in the
+	// context) or declined or dismissed the question. Callers then fall back
+	// to their non-interactive behavior.
+	Answered bool
+
+	// Value is the picked Choice.Value.
+	Value string
+
+	// Confirmed is the user's yes or no to a confirmation.
+	Confirmed bool
+}
+
+// AskFunc puts a Question to the user. A non-nil error must be returned
+// unchanged by the caller: the tool layer uses it to suspend the call until
+// the client has the answer.
+type AskFunc func(ctx context.Context, q Question) (Answer, error)
+
+type askKey struct{}
+
+// WithAsk returns a context that carries fn. The tool layer installs one for
+// tools that may ask and whose client supports elicitation; nested lookups
+// (batches, cross-registry checks) never ask, because they run without it.
+func WithAsk(ctx context.Context, fn AskFunc) context.Context {
+	return context.WithValue(ctx, askKey{}, fn)
+}
+
+// Ask puts q to the user through the AskFunc carried by ctx. Without one it
+// returns an unanswered Answer.
+func Ask(ctx context.Context, q Question) (Answer, error) {
+	if fn, ok := ctx.Value(askKey{}).(AskFunc); ok {
+		return fn(ctx, q)
+	}
+	return Answer{}, nil
+}
+
+// Label formats a candidate for a Question as "name (detail, detail)",
+// leaving out empty details.
+func Label(name string, details ...string) string {
+	kept := details[:0:0]
+	for _, d := range details {
+		if d = strings.TrimSpace(d); d != "" {
+			kept = append(kept, d)
+		}
+	}
+	if len(kept) == 0 {
+		return name
+	}
+	return name + " (" + strings.Join(kept, ", ") + ")"
+}
@@ -0,0 +1,40 @@
+package infra
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAsk_NoAskFunc(t *testing.T) {
+	answer, err := Ask(context.Background(), Question{Key: "company", Message: "Which one?"})
+	if err != nil || answer.Answered {
+		t.Errorf("Ask without an AskFunc = %+v, %v; want unanswered, nil", answer, err)
+	}
+}
+
+func TestAsk_ForwardsToAskFunc(t *testing.T) {
+	ctx := WithAsk(context.Background(), func(_ context.Context, q Question) (Answer, error) {
+		return Answer{Answered: true, Value: q.Choices[1].Value}, nil
+	})
+	answer, err := Ask(ctx, Question{Key: "company", Choices: []Choice{{Value: "a"}, {Value: "b"}}})
+	if err != nil || answer.Value != "b" {
+		t.Errorf("Ask = %+v, %v; want the AskFunc's answer b", answer, err)
+	}
+}
+
+func TestLabel(t *testing.T) {
+	tests := []struct {
+		name    string
+		details []string
+		want    string
+	}{
+		{"Nokia Oyj", []string{"0112038-9", "OYJ", "", " Espoo "}, "Nokia Oyj (0112038-9, OYJ, Espoo)"},
+		{"Nokia Oyj", []string{"", " "}, "Nokia Oyj"},
+		{"Nokia Oyj", nil, "Nokia Oyj"},
+	}
+	for _, tt := range tests {
+		if got := Label(tt.name, tt.details...); got != tt.want {
+			t.Errorf("Label(%q, %q) = %q, want %q", tt.name, tt.details, got, tt.want)
+		}
+	}
+}
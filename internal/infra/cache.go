@@ -1,7 +1,11 @@
 package infra
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -11,23 +15,70 @@ import (
 const (
 	DefaultMaxCacheEntries = 1000            // Maximum number of cache entries
 	DefaultCacheCleanup    = 5 * time.Minute // How often to run cache cleanup
+
+	// DefaultStaleTTL is how long Fetch keeps an entry past its TTL, to fall
+	// back on when the registry cannot be reached
+	DefaultStaleTTL = time.Hour
+
+	// DefaultRefreshTimeout bounds a background refresh started by Fetch
+	DefaultRefreshTimeout = 30 * time.Second
+
+	// DefaultNegativeTTL is how long Fetch remembers a not-found or
+	// validation outcome, so retries of the same miss stay local
+	DefaultNegativeTTL = time.Minute
 )
 
 // CacheEntry holds cached data with expiration and LRU tracking
 type CacheEntry struct {
 	Data       interface{}
-	ExpiresAt  time.Time
-	AccessedAt time.Time // For LRU eviction
-	Key        string    // Store key for eviction
+	FetchedAt  time.Time  // When Data was stored
+	ExpiresAt  time.Time  // Fresh until; Get misses after this
+	StaleUntil time.Time  // Kept until, for Fetch to fall back on; never before ExpiresAt
+	Validators Validators // For refreshing Data with a conditional request
+	AccessedAt time.Time  // For LRU eviction
+	Key        string     // Store key for eviction
 	mu         sync.Mutex
 }
 
-// Cache provides an LRU cache with TTL support
+// StoredEntry is a cache entry as held by a Store: the value serialized as
+// JSON, its lifetimes and its validators.
+type StoredEntry struct {
+	Data       []byte
+	FetchedAt  time.Time
+	ExpiresAt  time.Time // Fresh until
+	StaleUntil time.Time // Kept until
+	Validators Validators
+}
+
+// Store is a second cache tier behind the in-memory LRU. It holds entries
+// serialized as JSON so they can outlive the process; Lookup decodes them
+// back into the cached type. A Store failure only ever costs a cache miss.
+type Store interface {
+	// Get returns the entry stored under key. Entries past their StaleUntil
+	// are reported as missing.
+	Get(key string) (StoredEntry, bool)
+	Set(key string, entry StoredEntry) error
+	Delete(key string) error
+	DeletePrefix(prefix string) error
+	Close() error
+}
+
+// Cache provides an LRU cache with TTL support, optionally backed by a
+// second-tier Store.
 type Cache struct {
 	entries    sync.Map // key (string) -> *CacheEntry
 	count      int64    // Atomic counter for cache size
 	maxEntries int64
 	mu         sync.Mutex // Protects eviction operations
+	l2         Store      // Second tier; nil for memory only
+	refreshing sync.Map   // Keys with a background refresh in flight
+
+	// Negative cache: not-found and validation outcomes, under the same keys
+	// as values. Memory only, and bounded by maxEntries on its own.
+	negatives      sync.Map // key (string) -> *negativeEntry
+	negativeCount  int64    // Atomic counter for negative entries
+	negativeHits   int64    // Atomic: Fetch calls answered by a negative entry
+	negativeStores int64    // Atomic: outcomes remembered
 
 	// Eviction deduplication
 	evicting int32 // Atomic flag to prevent duplicate eviction goroutines
@@ -37,6 +88,19 @@ type Cache struct {
 	stopOnce sync.Once
 }
 
+// negativeEntry is a remembered not-found or validation outcome.
+type negativeEntry struct {
+	err       error
+	expiresAt time.Time
+}
+
+// NegativeCacheStats reports on a cache's negative entries.
+type NegativeCacheStats struct {
+	Entries int64 // Held now
+	Hits    int64 // Fetch calls answered by one, since the cache was created
+	Stores  int64 // Outcomes remembered, since the cache was created
+}
+
 // NewCache creates a new LRU cache with the specified max entries
 func NewCache(maxEntries int) *Cache {
 	if maxEntries <= 0 {
@@ -50,27 +114,228 @@ func NewCache(maxEntries int) *Cache {
 	return c
 }
 
+// NewTieredCache creates an LRU cache with the specified max entries whose
+// Set also writes through to l2, and whose Lookup falls back to l2 on a miss.
+// The cache takes ownership of l2 and closes it on Close.
+func NewTieredCache(maxEntries int, l2 Store) *Cache {
+	c := NewCache(maxEntries)
+	c.l2 = l2
+	return c
+}
+
+// Lookup retrieves a cached *T. On an in-memory miss it decodes the entry
+// from the second tier, if any, and keeps it in memory for the rest of its
+// TTL. Values must have been stored as *T.
+func Lookup[T any](c *Cache, key string) (*T, bool) {
+	if e, ok := lookupEntry[T](c, key); ok && time.Now().Before(e.expiresAt) {
+		return e.value, true
+	}
+	return nil, false
+}
+
+// cachedValue is a *T found in either tier, with its lifetimes and
+// validators.
+type cachedValue[T any] struct {
+	value      *T
+	fetchedAt  time.Time
+	expiresAt  time.Time
+	staleUntil time.Time
+	validators Validators
+}
+
+// lookupEntry retrieves a cached *T that is fresh or still within its stale
+// TTL, from memory or else from the second tier.
+func lookupEntry[T any](c *Cache, key string) (cachedValue[T], bool) {
+	if ce, ok := c.load(key); ok {
+		v, ok := ce.Data.(*T)
+		return cachedValue[T]{value: v, fetchedAt: ce.FetchedAt, expiresAt: ce.ExpiresAt, staleUntil: ce.StaleUntil, validators: ce.Validators}, ok
+	}
+	if c.l2 == nil {
+		return cachedValue[T]{}, false
+	}
+	stored, ok := c.l2.Get(key)
+	if !ok {
+		return cachedValue[T]{}, false
+	}
+	var v T
+	if err := json.Unmarshal(stored.Data, &v); err != nil {
+		_ = c.l2.Delete(key)
+		return cachedValue[T]{}, false
+	}
+	c.setMemory(key, &v, stored.FetchedAt, stored.ExpiresAt, stored.StaleUntil, stored.Validators)
+	return cachedValue[T]{value: &v, fetchedAt: stored.FetchedAt, expiresAt: stored.ExpiresAt, staleUntil: stored.StaleUntil, validators: stored.Validators}, true
+}
+
+// Fetch returns the *T cached under key, or calls fetch and caches its result
+// for ttl, keeping it DefaultStaleTTL longer to fall back on. A not-found or
+// validation error from fetch is cached too, for DefaultNegativeTTL, and
+// returned again until then.
+//
+// When ctx tracks provenance (see TrackProvenance), an entry past its TTL by
+// less than one more TTL is returned at once, reported as stale, and
+// refreshed in the background (stale-while-revalidate). An older one is
+// returned, and reported as stale too, only when fetch fails with an
+// upstream error such as an open circuit. Callers that do not track
+// provenance cannot tell a stale answer, so they always get fresh ones or
+// the error. Not-found and validation errors are answers and are never
+// covered up.
+//
+// An entry stored with validators is refreshed with a conditional request
+// (see Conditional). When the registry answers that it is current, it is
+// kept for another ttl and counts as fetched now.
+//
+// The cache mode carried by ctx (see WithCacheMode) can skip the cache or
+// the registry. Every answer is recorded in the Provenance ctx carries.
+func Fetch[T any](ctx context.Context, c *Cache, key string, ttl time.Duration, fetch func(context.Context) (*T, error)) (*T, error) {
+	mode := cacheModeFrom(ctx)
+	var (
+		cached cachedValue[T]
+		found  bool
+	)
+	if mode != CacheNoCache {
+		if err, ok := c.negative(key); ok {
+			return nil, err
+		}
+		cached, found = lookupEntry[T](c, key)
+	}
+	now := time.Now()
+	if found && now.Before(cached.expiresAt) {
+		record(ctx, cached.fetchedAt, true, false)
+		return cached.value, nil
+	}
+	if mode == CacheOnlyIfCached {
+		return nil, ErrNotCached
+	}
+	if found && now.Before(cached.expiresAt.Add(cached.expiresAt.Sub(cached.fetchedAt))) && record(ctx, cached.fetchedAt, true, true) {
+		revalidate(c, key, ttl, cached, fetch)
+		return cached.value, nil
+	}
+
+	v, err := fetchConditional(ctx, c, key, ttl, cached, fetch)
+	if err != nil {
+		if isNegative(err) {
+			c.setNegative(key, err, DefaultNegativeTTL)
+			return nil, err
+		}
+		if found && ctx.Err() == nil && record(ctx, cached.fetchedAt, true, true) {
+			return cached.value, nil
+		}
+		return nil, err
+	}
+	record(ctx, time.Now(), false, false)
+	return v, nil
+}
+
+// fetchConditional calls fetch to refresh cached, if there is a cached
+// value, and stores the answer for ttl with its validators. When the
+// registry answers that cached is current, cached is stored again for ttl
+// and returned.
+func fetchConditional[T any](ctx context.Context, c *Cache, key string, ttl time.Duration, cached cachedValue[T], fetch func(context.Context) (*T, error)) (*T, error) {
+	fetchCtx, cond := withConditional(ctx, cached.validators)
+	v, err := fetch(fetchCtx)
+	if errors.Is(err, ErrNotModified) && cached.value == nil {
+		// The 304 answered a deduplicated request made for another call,
+		// whose entry is the one confirmed. Should that entry be gone, ask
+		// again without validators.
+		var ok bool
+		if cached, ok = lookupEntry[T](c, key); !ok {
+			fetchCtx, cond = withConditional(ctx, Validators{})
+			v, err = fetch(fetchCtx)
+		}
+	}
+	if errors.Is(err, ErrNotModified) && cached.value != nil {
+		validators := cond.validators()
+		if validators.IsZero() {
+			validators = cached.validators
+		}
+		c.store(key, cached.value, ttl, DefaultStaleTTL, validators)
+		return cached.value, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.store(key, v, ttl, DefaultStaleTTL, cond.validators())
+	return v, nil
+}
+
+// revalidate refreshes the entry cached under key in the background unless
+// a refresh of it is already running. A refresh that fails with an upstream
+// error leaves the entry as it is.
+func revalidate[T any](c *Cache, key string, ttl time.Duration, cached cachedValue[T], fetch func(context.Context) (*T, error)) {
+	if _, running := c.refreshing.LoadOrStore(key, struct{}{}); running {
+		return
+	}
+	go func() {
+		defer c.refreshing.Delete(key)
+		// Not the caller's context: the refresh outlives the call that
+		// started it.
+		ctx, cancel := context.WithTimeout(context.Background(), DefaultRefreshTimeout)
+		defer cancel()
+		if _, err := fetchConditional(ctx, c, key, ttl, cached, fetch); isNegative(err) {
+			c.setNegative(key, err, DefaultNegativeTTL)
+		}
+	}()
+}
+
+// load returns the entry under key if it is fresh or within its stale TTL,
+// deleting it once it is past both.
+func (c *Cache) load(key string) (*CacheEntry, bool) {
+	entry, ok := c.entries.Load(key)
+	if !ok {
+		return nil, false
+	}
+	ce := entry.(*CacheEntry)
+	now := time.Now()
+	if now.Before(ce.ExpiresAt) || now.Before(ce.StaleUntil) {
+		// Update access time for LRU tracking
+		ce.mu.Lock()
+		ce.AccessedAt = now
+		ce.mu.Unlock()
+		return ce, true
+	}
+	// Expired, delete it
+	c.entries.Delete(key)
+	atomic.AddInt64(&c.count, -1)
+	return nil, false
+}
+
 // Get retrieves a cached value if it exists and hasn't expired
 func (c *Cache) Get(key string) (interface{}, bool) {
-	if entry, ok := c.entries.Load(key); ok {
-		ce := entry.(*CacheEntry)
-		now := time.Now()
-		if now.Before(ce.ExpiresAt) {
-			// Update access time for LRU tracking
-			ce.mu.Lock()
-			ce.AccessedAt = now
-			ce.mu.Unlock()
-			return ce.Data, true
-		}
-		// Expired, delete it
-		c.entries.Delete(key)
-		atomic.AddInt64(&c.count, -1)
+	if ce, ok := c.load(key); ok && time.Now().Before(ce.ExpiresAt) {
+		return ce.Data, true
 	}
 	return nil, false
 }
 
-// Set stores a value in the cache with the specified TTL
+// Set stores a value in the cache with the specified TTL, writing it through
+// to the second tier when there is one
 func (c *Cache) Set(key string, data interface{}, ttl time.Duration) {
+	c.SetWithStale(key, data, ttl, 0)
+}
+
+// SetWithStale stores a value that is fresh for ttl and kept for staleTTL
+// after that, for Fetch to fall back on. Get misses once ttl has passed.
+func (c *Cache) SetWithStale(key string, data interface{}, ttl, staleTTL time.Duration) {
+	c.store(key, data, ttl, staleTTL, Validators{})
+}
+
+// store is SetWithStale keeping the validators the value was answered with.
+func (c *Cache) store(key string, data interface{}, ttl, staleTTL time.Duration, validators Validators) {
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	staleUntil := expiresAt.Add(max(staleTTL, 0))
+	c.setMemory(key, data, now, expiresAt, staleUntil, validators)
+	c.deleteNegative(key)
+
+	if c.l2 != nil {
+		if encoded, err := json.Marshal(data); err == nil {
+			_ = c.l2.Set(key, StoredEntry{Data: encoded, FetchedAt: now, ExpiresAt: expiresAt, StaleUntil: staleUntil, Validators: validators})
+		}
+	}
+}
+
+// setMemory stores a value in the in-memory tier only
+func (c *Cache) setMemory(key string, data interface{}, fetchedAt, expiresAt, staleUntil time.Time, validators Validators) {
 	now := time.Now()
 
 	// Check if this is a new entry or update
@@ -78,7 +343,10 @@ func (c *Cache) Set(key string, data interface{}, ttl time.Duration) {
 
 	c.entries.Store(key, &CacheEntry{
 		Data:       data,
-		ExpiresAt:  now.Add(ttl),
+		FetchedAt:  fetchedAt,
+		ExpiresAt:  expiresAt,
+		StaleUntil: staleUntil,
+		Validators: validators,
 		AccessedAt: now,
 		Key:        key,
 	})
@@ -94,6 +362,57 @@ func (c *Cache) Set(key string, data interface{}, ttl time.Duration) {
 	}
 }
 
+// negative returns the error remembered under key, if it has not expired.
+func (c *Cache) negative(key string) (error, bool) {
+	entry, ok := c.negatives.Load(key)
+	if !ok {
+		return nil, false
+	}
+	ne := entry.(*negativeEntry)
+	if time.Now().Before(ne.expiresAt) {
+		atomic.AddInt64(&c.negativeHits, 1)
+		return ne.err, true
+	}
+	c.deleteNegative(key)
+	return nil, false
+}
+
+// setNegative remembers err under key for ttl. The registry has answered, so
+// a value cached under key is out of date and is dropped from both tiers.
+// When the negative cache is full the outcome is not remembered.
+func (c *Cache) setNegative(key string, err error, ttl time.Duration) {
+	if _, existed := c.entries.LoadAndDelete(key); existed {
+		atomic.AddInt64(&c.count, -1)
+	}
+	if c.l2 != nil {
+		_ = c.l2.Delete(key)
+	}
+
+	if _, existed := c.negatives.Load(key); !existed && atomic.LoadInt64(&c.negativeCount) >= c.maxEntries {
+		return
+	}
+	if _, existed := c.negatives.Swap(key, &negativeEntry{err: err, expiresAt: time.Now().Add(ttl)}); !existed {
+		atomic.AddInt64(&c.negativeCount, 1)
+	}
+	atomic.AddInt64(&c.negativeStores, 1)
+}
+
+// deleteNegative forgets the outcome remembered under key.
+func (c *Cache) deleteNegative(key string) {
+	if _, existed := c.negatives.LoadAndDelete(key); existed {
+		atomic.AddInt64(&c.negativeCount, -1)
+	}
+}
+
+// NegativeStats returns the negative-cache counters.
+func (c *Cache) NegativeStats() NegativeCacheStats {
+	return NegativeCacheStats{
+		Entries: atomic.LoadInt64(&c.negativeCount),
+		Hits:    atomic.LoadInt64(&c.negativeHits),
+		Stores:  atomic.LoadInt64(&c.negativeStores),
+	}
+}
+
 // maybeTriggerEviction launches a single async eviction pass if one isn't
 // already running. The compare-and-swap ensures only one goroutine evicts at
 // a time, even under concurrent Set pressure.
@@ -111,15 +430,30 @@ func (c *Cache) maybeTriggerEviction() {
 	}()
 }
 
-// Delete removes a key from the cache
+// Delete removes a key from the cache, in both tiers, along with any
+// outcome remembered under it
 func (c *Cache) Delete(key string) {
 	if _, existed := c.entries.LoadAndDelete(key); existed {
 		atomic.AddInt64(&c.count, -1)
 	}
+	c.deleteNegative(key)
+	if c.l2 != nil {
+		_ = c.l2.Delete(key)
+	}
 }
 
-// DeletePrefix removes all cache entries with keys starting with prefix
+// DeletePrefix removes all cache entries with keys starting with prefix, in
+// both tiers, along with any outcomes remembered under them
 func (c *Cache) DeletePrefix(prefix string) {
+	if c.l2 != nil {
+		_ = c.l2.DeletePrefix(prefix)
+	}
+	c.negatives.Range(func(key, _ interface{}) bool {
+		if k := key.(string); strings.HasPrefix(k, prefix) {
+			c.deleteNegative(k)
+		}
+		return true
+	})
 	var deletedCount int64
 	c.entries.Range(func(key, value interface{}) bool {
 		if k := key.(string); len(k) >= len(prefix) && k[:len(prefix)] == prefix {
@@ -133,15 +467,18 @@ func (c *Cache) DeletePrefix(prefix string) {
 	}
 }
 
-// Size returns the current number of entries in the cache
+// Size returns the current number of entries in the in-memory tier
 func (c *Cache) Size() int64 {
 	return atomic.LoadInt64(&c.count)
 }
 
-// Close stops the background cleanup goroutine
+// Close stops the background cleanup goroutine and closes the second tier
 func (c *Cache) Close() {
 	c.stopOnce.Do(func() {
 		close(c.stopCh)
+		if c.l2 != nil {
+			_ = c.l2.Close()
+		}
 	})
 }
 
@@ -160,7 +497,8 @@ func (c *Cache) cleanupLoop() {
 	}
 }
 
-// cleanup removes expired entries and evicts LRU entries if over limit
+// cleanup removes entries past their TTL and stale TTL, expired negative
+// entries, and evicts LRU entries if over limit
 func (c *Cache) cleanup() {
 	now := time.Now()
 	var expiredCount int64
@@ -168,7 +506,7 @@ func (c *Cache) cleanup() {
 	// First pass: remove expired entries
 	c.entries.Range(func(key, value interface{}) bool {
 		ce := value.(*CacheEntry)
-		if now.After(ce.ExpiresAt) {
+		if now.After(ce.ExpiresAt) && now.After(ce.StaleUntil) {
 			c.entries.Delete(key)
 			expiredCount++
 		}
@@ -180,6 +518,13 @@ func (c *Cache) cleanup() {
 		atomic.AddInt64(&c.count, -expiredCount)
 	}
 
+	c.negatives.Range(func(key, value interface{}) bool {
+		if now.After(value.(*negativeEntry).expiresAt) {
+			c.deleteNegative(key.(string))
+		}
+		return true
+	})
+
 	// Check if we need to evict for size limit
 	currentCount := atomic.LoadInt64(&c.count)
 	if currentCount > c.maxEntries {
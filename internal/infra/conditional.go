@@ -0,0 +1,85 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Validators are the HTTP cache validators a registry sent with an answer,
+// its ETag and Last-Modified headers. A cache entry keeps them so that the
+// entry can be refreshed with a conditional request, which the registry
+// answers with 304 Not Modified and no body while the answer is current.
+type Validators struct {
+	ETag         string
+	LastModified string
+}
+
+// IsZero reports whether the registry sent no validators.
+func (v Validators) IsZero() bool {
+	return v == Validators{}
+}
+
+// ErrNotModified is returned by a fetch whose conditional request the
+// registry answered with 304 Not Modified: the cached answer is current.
+// Fetch keeps the cached answer for another TTL instead of parsing a new one.
+var ErrNotModified = errors.New("registry answer not modified")
+
+// Conditional carries the validators of one Fetch call between the cache
+// and the HTTP client making the registry request. The client sends the
+// cached answer's validators with the first request of the fetch and
+// reports those of the response. A fetch making several requests has no
+// one response standing for its answer, so it is stored without
+// validators and refreshed unconditionally.
+type Conditional struct {
+	mu       sync.Mutex
+	cached   Validators // Of the cached answer being refreshed
+	answer   Validators // Of the registry's response
+	requests int
+}
+
+type conditionalKey struct{}
+
+// withConditional returns a context under which the registry client sends
+// cached with the fetch's request, and the Conditional it reports back to.
+func withConditional(ctx context.Context, cached Validators) (context.Context, *Conditional) {
+	cond := &Conditional{cached: cached}
+	return context.WithValue(ctx, conditionalKey{}, cond), cond
+}
+
+// ConditionalFrom returns the Conditional of the fetch ctx belongs to, or
+// false outside Fetch.
+func ConditionalFrom(ctx context.Context) (*Conditional, bool) {
+	cond, ok := ctx.Value(conditionalKey{}).(*Conditional)
+	return cond, ok
+}
+
+// Begin counts a request of the fetch and returns the validators to send
+// with it: the cached answer's for the first request, none for later ones.
+func (c *Conditional) Begin() Validators {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests++
+	if c.requests > 1 {
+		return Validators{}
+	}
+	return c.cached
+}
+
+// Answered records the validators the registry sent with its response.
+func (c *Conditional) Answered(v Validators) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.answer = v
+}
+
+// validators returns the validators to store with the fetched answer: the
+// response's, or none when the fetch made other than one request.
+func (c *Conditional) validators() Validators {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.requests != 1 {
+		return Validators{}
+	}
+	return c.answer
+}
@@ -0,0 +1,120 @@
+package infra
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// conditionalFetch answers like a registry client: it sends the validators
+// Begin hands it, answers ErrNotModified when they match etag and reports
+// etag with a full answer. It records what it was sent.
+type conditionalFetch struct {
+	etag  string
+	sent  []Validators
+	calls int
+}
+
+func (f *conditionalFetch) fetch(ctx context.Context) (*cachedCompany, error) {
+	f.calls++
+	cond, ok := ConditionalFrom(ctx)
+	if !ok {
+		return nil, nil
+	}
+	sent := cond.Begin()
+	f.sent = append(f.sent, sent)
+	if sent.ETag != "" && sent.ETag == f.etag {
+		cond.Answered(Validators{ETag: f.etag})
+		return nil, ErrNotModified
+	}
+	cond.Answered(Validators{ETag: f.etag})
+	return &cachedCompany{Name: "EQUINOR ASA", Number: f.etag}, nil
+}
+
+func TestFetch_Revalidates(t *testing.T) {
+	c := NewCache(100)
+	defer c.Close()
+	f := &conditionalFetch{etag: `"v1"`}
+
+	first, err := Fetch(context.Background(), c, "company:1", time.Hour, f.fetch)
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if !f.sent[0].IsZero() {
+		t.Errorf("first fetch sent %+v, want no validators", f.sent[0])
+	}
+
+	// Age the entry past twice its TTL, so Fetch refreshes it in the call.
+	c.store("company:1", first, time.Hour, DefaultStaleTTL, Validators{ETag: `"v1"`})
+	ce, _ := c.entries.Load("company:1")
+	entry := ce.(*CacheEntry)
+	entry.FetchedAt = time.Now().Add(-3 * time.Hour)
+	entry.ExpiresAt = time.Now().Add(-2 * time.Hour)
+
+	ctx, prov := TrackProvenance(context.Background())
+	got, err := Fetch(ctx, c, "company:1", time.Hour, f.fetch)
+	if err != nil || got != first {
+		t.Fatalf("Fetch after 304 = %+v, %v; want the cached value", got, err)
+	}
+	if f.sent[1].ETag != `"v1"` {
+		t.Errorf("refresh sent %+v, want the cached ETag", f.sent[1])
+	}
+	if v, ok := Lookup[cachedCompany](c, "company:1"); !ok || v != first {
+		t.Error("a 304 did not make the cached value fresh again")
+	}
+	if fresh, _ := prov.Freshness(); fresh.CacheHit || fresh.Stale {
+		t.Errorf("Freshness = %+v, want a revalidated answer to count as fetched", fresh)
+	}
+
+	// A changed answer replaces the entry and its validators.
+	f.etag = `"v2"`
+	got, err = Fetch(WithCacheMode(context.Background(), CacheNoCache), c, "company:1", time.Hour, f.fetch)
+	if err != nil || got.Number != `"v2"` {
+		t.Fatalf("Fetch = %+v, %v; want the new answer", got, err)
+	}
+	if v, _ := lookupEntry[cachedCompany](c, "company:1"); v.validators.ETag != `"v2"` {
+		t.Errorf("stored validators = %+v, want the new ETag", v.validators)
+	}
+}
+
+func TestFetch_SeveralRequestsStoreNoValidators(t *testing.T) {
+	c := NewCache(100)
+	defer c.Close()
+
+	fetch := func(ctx context.Context) (*cachedCompany, error) {
+		cond, _ := ConditionalFrom(ctx)
+		for range 2 {
+			cond.Begin()
+			cond.Answered(Validators{ETag: `"page"`})
+		}
+		return &cachedCompany{Name: "EQUINOR ASA"}, nil
+	}
+	if _, err := Fetch(context.Background(), c, "search:equinor", time.Hour, fetch); err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if v, _ := lookupEntry[cachedCompany](c, "search:equinor"); !v.validators.IsZero() {
+		t.Errorf("stored validators = %+v, want none for an answer of two requests", v.validators)
+	}
+}
+
+func TestTieredCache_KeepsValidators(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewDiskStore(dir, 0)
+	if err != nil {
+		t.Fatalf("NewDiskStore: %v", err)
+	}
+	c := NewTieredCache(100, store)
+	want := Validators{ETag: `W/"abc"`, LastModified: "Mon, 12 Oct 2026 08:00:00 GMT"}
+	c.store("municipalities", &cachedCompany{Name: "Oslo"}, time.Hour, 0, want)
+	c.Close()
+
+	store, err = NewDiskStore(dir, 0)
+	if err != nil {
+		t.Fatalf("NewDiskStore: %v", err)
+	}
+	restarted := NewTieredCache(100, store)
+	defer restarted.Close()
+	if v, ok := lookupEntry[cachedCompany](restarted, "municipalities"); !ok || v.validators != want {
+		t.Errorf("validators after restart = %+v, %v; want %+v", v.validators, ok, want)
+	}
+}
@@ -0,0 +1,268 @@
+package infra
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultDiskCacheBytes bounds a DiskStore when no size is given (100 MB)
+	DefaultDiskCacheBytes = 100 << 20
+
+	diskEntrySuffix = ".json"
+	diskTempPrefix  = ".tmp-"
+
+	// diskTempMaxAge is how old a temp file must be before pruning treats it
+	// as left behind by a crashed writer rather than one in progress.
+	diskTempMaxAge = time.Minute
+)
+
+// DiskStore is a Store keeping one JSON file per entry in a directory.
+//
+// Entries are written to a temp file and renamed into place, so a crash never
+// leaves a half-written entry and several processes can share the directory:
+// readers see either the old or the new file. The directory is kept under
+// maxBytes by removing expired entries, then the least recently used ones.
+type DiskStore struct {
+	dir      string
+	maxBytes int64
+
+	mu      sync.Mutex
+	size    int64 // Bytes on disk at the last scan plus bytes written since
+	pruning bool
+	wg      sync.WaitGroup // Background prunes
+}
+
+// diskEntry is the on-disk form of an entry. Key is kept so that a read can
+// tell its entry from a hash collision.
+type diskEntry struct {
+	Key          string          `json:"key"`
+	FetchedAt    time.Time       `json:"fetched_at"`
+	ExpiresAt    time.Time       `json:"expires_at"`
+	StaleUntil   time.Time       `json:"stale_until"`
+	ETag         string          `json:"etag,omitempty"`
+	LastModified string          `json:"last_modified,omitempty"`
+	Data         json.RawMessage `json:"data"`
+}
+
+// NewDiskStore opens (creating if needed) a disk store in dir holding at most
+// maxBytes of entries; maxBytes <= 0 uses DefaultDiskCacheBytes.
+func NewDiskStore(dir string, maxBytes int64) (*DiskStore, error) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultDiskCacheBytes
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("creating cache directory: %w", err)
+	}
+	s := &DiskStore{dir: dir, maxBytes: maxBytes}
+	s.prune()
+	return s, nil
+}
+
+// path returns the file holding key.
+func (s *DiskStore) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+diskEntrySuffix)
+}
+
+// Get returns the entry stored under key. Entries past their StaleUntil and
+// unreadable ones are removed and reported as missing. A hit marks the entry
+// as recently used.
+func (s *DiskStore) Get(key string) (StoredEntry, bool) {
+	path := s.path(key)
+	entry, err := readDiskEntry(path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			_ = os.Remove(path)
+		}
+		return StoredEntry{}, false
+	}
+	if entry.Key != key {
+		return StoredEntry{}, false
+	}
+	now := time.Now()
+	if !now.Before(entry.StaleUntil) {
+		_ = os.Remove(path)
+		return StoredEntry{}, false
+	}
+	_ = os.Chtimes(path, now, now)
+	return StoredEntry{
+		Data:       entry.Data,
+		FetchedAt:  entry.FetchedAt,
+		ExpiresAt:  entry.ExpiresAt,
+		StaleUntil: entry.StaleUntil,
+		Validators: Validators{ETag: entry.ETag, LastModified: entry.LastModified},
+	}, true
+}
+
+// Set stores entry under key until its StaleUntil.
+func (s *DiskStore) Set(key string, entry StoredEntry) error {
+	encoded, err := json.Marshal(diskEntry{
+		Key:          key,
+		FetchedAt:    entry.FetchedAt,
+		ExpiresAt:    entry.ExpiresAt,
+		StaleUntil:   entry.StaleUntil,
+		ETag:         entry.Validators.ETag,
+		LastModified: entry.Validators.LastModified,
+		Data:         entry.Data,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding cache entry: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(s.dir, diskTempPrefix+"*")
+	if err != nil {
+		return fmt.Errorf("creating cache entry: %w", err)
+	}
+	_, err = tmp.Write(encoded)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), s.path(key))
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("writing cache entry: %w", err)
+	}
+
+	s.mu.Lock()
+	s.size += int64(len(encoded))
+	over := s.size > s.maxBytes && !s.pruning
+	if over {
+		s.pruning = true
+	}
+	s.mu.Unlock()
+	if over {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.prune()
+		}()
+	}
+	return nil
+}
+
+// Delete removes the entry stored under key.
+func (s *DiskStore) Delete(key string) error {
+	if err := os.Remove(s.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// DeletePrefix removes all entries whose keys start with prefix. File names
+// are hashes, so this reads every entry.
+func (s *DiskStore) DeletePrefix(prefix string) error {
+	files, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if !strings.HasSuffix(f.Name(), diskEntrySuffix) {
+			continue
+		}
+		path := filepath.Join(s.dir, f.Name())
+		if entry, err := readDiskEntry(path); err == nil && strings.HasPrefix(entry.Key, prefix) {
+			_ = os.Remove(path)
+		}
+	}
+	return nil
+}
+
+// Close waits for a background prune to finish. Every Set is already on
+// disk, so there is nothing to flush.
+func (s *DiskStore) Close() error {
+	s.wg.Wait()
+	return nil
+}
+
+// prune removes entries past their StaleUntil, temp files left by crashed
+// writers and, if the directory is still over maxBytes, the least recently
+// used entries until it is under 90% of it.
+func (s *DiskStore) prune() {
+	defer func() {
+		s.mu.Lock()
+		s.pruning = false
+		s.mu.Unlock()
+	}()
+
+	files, err := os.ReadDir(s.dir)
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	type fileInfo struct {
+		path   string
+		size   int64
+		usedAt time.Time
+	}
+	var entries []fileInfo
+	var total int64
+	for _, f := range files {
+		info, err := f.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		path := filepath.Join(s.dir, f.Name())
+		switch {
+		case strings.HasPrefix(f.Name(), diskTempPrefix):
+			if now.Sub(info.ModTime()) > diskTempMaxAge {
+				_ = os.Remove(path)
+			}
+			continue
+		case !strings.HasSuffix(f.Name(), diskEntrySuffix):
+			continue
+		}
+		entry, err := readDiskEntry(path)
+		if err != nil || !now.Before(entry.StaleUntil) {
+			_ = os.Remove(path)
+			continue
+		}
+		entries = append(entries, fileInfo{path: path, size: info.Size(), usedAt: info.ModTime()})
+		total += info.Size()
+	}
+
+	if total > s.maxBytes {
+		sort.Slice(entries, func(i, j int) bool { return entries[i].usedAt.Before(entries[j].usedAt) })
+		target := s.maxBytes / 10 * 9
+		for _, e := range entries {
+			if total <= target {
+				break
+			}
+			if err := os.Remove(e.path); err == nil || errors.Is(err, fs.ErrNotExist) {
+				total -= e.size
+			}
+		}
+	}
+
+	s.mu.Lock()
+	s.size = total
+	s.mu.Unlock()
+}
+
+// readDiskEntry reads and decodes the entry file at path.
+func readDiskEntry(path string) (diskEntry, error) {
+	var entry diskEntry
+	data, err := os.ReadFile(path) // #nosec G304 -- path is the store directory plus a hex hash
+	if err != nil {
+		return entry, err
+	}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return entry, err
+	}
+	return entry, nil
+}
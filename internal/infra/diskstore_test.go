@@ -0,0 +1,235 @@
+package infra
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type cachedCompany struct {
+	Name   string `json:"name"`
+	Number string `json:"number"`
+}
+
+// storedUntil returns a store entry holding data that is fresh, and kept,
+// until expiresAt.
+func storedUntil(data []byte, expiresAt time.Time) StoredEntry {
+	return StoredEntry{Data: data, FetchedAt: time.Now(), ExpiresAt: expiresAt, StaleUntil: expiresAt}
+}
+
+func TestDiskStore_SetAndGet(t *testing.T) {
+	s, err := NewDiskStore(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("NewDiskStore: %v", err)
+	}
+	defer func() { _ = s.Close() }()
+
+	expiresAt := time.Now().Add(time.Hour).Round(0)
+	if err := s.Set("company:1", storedUntil([]byte(`{"name":"A"}`), expiresAt)); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	got, ok := s.Get("company:1")
+	if !ok || string(got.Data) != `{"name":"A"}` || !got.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("Get = %+v, %v; want the stored entry", got, ok)
+	}
+	if _, ok := s.Get("company:2"); ok {
+		t.Error("Get found a key that was never set")
+	}
+
+	if err := s.Delete("company:1"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, ok := s.Get("company:1"); ok {
+		t.Error("Get found a deleted key")
+	}
+	if err := s.Delete("company:1"); err != nil {
+		t.Errorf("Delete of a missing key = %v, want nil", err)
+	}
+}
+
+func TestDiskStore_Expired(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewDiskStore(dir, 0)
+	if err != nil {
+		t.Fatalf("NewDiskStore: %v", err)
+	}
+	defer func() { _ = s.Close() }()
+
+	if err := s.Set("old", storedUntil([]byte(`1`), time.Now().Add(-time.Second))); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if _, ok := s.Get("old"); ok {
+		t.Error("Get returned an expired entry")
+	}
+	if _, err := os.Stat(s.path("old")); !os.IsNotExist(err) {
+		t.Errorf("expired entry still on disk (stat error %v)", err)
+	}
+}
+
+func TestDiskStore_SharedAcrossStores(t *testing.T) {
+	dir := t.TempDir()
+	first, err := NewDiskStore(dir, 0)
+	if err != nil {
+		t.Fatalf("NewDiskStore: %v", err)
+	}
+	if err := first.Set("orgforms", storedUntil([]byte(`["AS"]`), time.Now().Add(time.Hour))); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	_ = first.Close()
+
+	// A second store on the same directory stands in for a restarted or
+	// concurrent process.
+	second, err := NewDiskStore(dir, 0)
+	if err != nil {
+		t.Fatalf("NewDiskStore: %v", err)
+	}
+	defer func() { _ = second.Close() }()
+	if got, ok := second.Get("orgforms"); !ok || string(got.Data) != `["AS"]` {
+		t.Errorf("Get = %s, %v; want the entry written by the first store", got.Data, ok)
+	}
+}
+
+func TestDiskStore_CorruptAndLeftoverFiles(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewDiskStore(dir, 0)
+	if err != nil {
+		t.Fatalf("NewDiskStore: %v", err)
+	}
+	defer func() { _ = s.Close() }()
+
+	if err := os.WriteFile(s.path("broken"), []byte(`{"key":"broken","data":`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.Get("broken"); ok {
+		t.Error("Get returned a corrupt entry")
+	}
+	if _, err := os.Stat(s.path("broken")); !os.IsNotExist(err) {
+		t.Error("corrupt entry was not removed")
+	}
+
+	// A temp file from a writer that crashed before the rename.
+	leftover := filepath.Join(dir, diskTempPrefix+"crashed")
+	if err := os.WriteFile(leftover, []byte(`{`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * diskTempMaxAge)
+	if err := os.Chtimes(leftover, old, old); err != nil {
+		t.Fatal(err)
+	}
+	s.prune()
+	if _, err := os.Stat(leftover); !os.IsNotExist(err) {
+		t.Error("prune kept a stale temp file")
+	}
+}
+
+func TestDiskStore_SizeBound(t *testing.T) {
+	s, err := NewDiskStore(t.TempDir(), 1000)
+	if err != nil {
+		t.Fatalf("NewDiskStore: %v", err)
+	}
+	value := []byte(`"` + strings.Repeat("x", 100) + `"`)
+	expiresAt := time.Now().Add(time.Hour)
+
+	// Entries are used in key order, so "k0" is the least recently used.
+	for i, key := range []string{"k0", "k1", "k2", "k3", "k4", "k5", "k6", "k7", "k8", "k9"} {
+		if err := s.Set(key, storedUntil(value, expiresAt)); err != nil {
+			t.Fatalf("Set: %v", err)
+		}
+		used := time.Now().Add(time.Duration(i-20) * time.Second)
+		_ = os.Chtimes(s.path(key), used, used)
+	}
+	_ = s.Close() // Waits for the prune started by going over the limit
+	s.prune()
+
+	if s.size > 1000 {
+		t.Errorf("store holds %d bytes, want at most 1000", s.size)
+	}
+	if _, ok := s.Get("k0"); ok {
+		t.Error("least recently used entry survived pruning")
+	}
+	if _, ok := s.Get("k9"); !ok {
+		t.Error("most recently used entry was pruned")
+	}
+}
+
+func TestDiskStore_DeletePrefix(t *testing.T) {
+	s, err := NewDiskStore(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("NewDiskStore: %v", err)
+	}
+	defer func() { _ = s.Close() }()
+
+	expiresAt := time.Now().Add(time.Hour)
+	for _, key := range []string{"search:a", "search:b", "company:1"} {
+		if err := s.Set(key, storedUntil([]byte(`1`), expiresAt)); err != nil {
+			t.Fatalf("Set: %v", err)
+		}
+	}
+	if err := s.DeletePrefix("search:"); err != nil {
+		t.Fatalf("DeletePrefix: %v", err)
+	}
+	for key, want := range map[string]bool{"search:a": false, "search:b": false, "company:1": true} {
+		if _, ok := s.Get(key); ok != want {
+			t.Errorf("Get(%s) found = %v, want %v", key, ok, want)
+		}
+	}
+}
+
+func TestTieredCache_Lookup(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewDiskStore(dir, 0)
+	if err != nil {
+		t.Fatalf("NewDiskStore: %v", err)
+	}
+	c := NewTieredCache(100, store)
+	c.Set("company:1", &cachedCompany{Name: "EQUINOR ASA", Number: "923609016"}, time.Hour)
+
+	// In-memory hit returns the stored pointer itself.
+	got, ok := Lookup[cachedCompany](c, "company:1")
+	if !ok || got.Name != "EQUINOR ASA" {
+		t.Fatalf("Lookup = %+v, %v; want the cached company", got, ok)
+	}
+	c.Close()
+
+	// A fresh cache on the same directory has an empty memory tier and
+	// decodes the entry from disk.
+	store, err = NewDiskStore(dir, 0)
+	if err != nil {
+		t.Fatalf("NewDiskStore: %v", err)
+	}
+	restarted := NewTieredCache(100, store)
+	defer restarted.Close()
+	if restarted.Size() != 0 {
+		t.Fatalf("restarted cache holds %d entries in memory, want 0", restarted.Size())
+	}
+	got, ok = Lookup[cachedCompany](restarted, "company:1")
+	if !ok || got.Number != "923609016" {
+		t.Fatalf("Lookup after restart = %+v, %v; want the company from disk", got, ok)
+	}
+	if restarted.Size() != 1 {
+		t.Errorf("disk hit was not kept in memory (size %d)", restarted.Size())
+	}
+
+	restarted.Delete("company:1")
+	if _, ok := store.Get("company:1"); ok {
+		t.Error("Delete left the entry on disk")
+	}
+	if _, ok := Lookup[cachedCompany](restarted, "company:1"); ok {
+		t.Error("Lookup found a deleted entry")
+	}
+}
+
+func TestLookup_MemoryOnly(t *testing.T) {
+	c := NewCache(100)
+	defer c.Close()
+
+	if _, ok := Lookup[cachedCompany](c, "missing"); ok {
+		t.Error("Lookup found a missing key")
+	}
+	c.Set("wrong-type", "a string", time.Hour)
+	if _, ok := Lookup[cachedCompany](c, "wrong-type"); ok {
+		t.Error("Lookup returned a value of another type")
+	}
+}
@@ -0,0 +1,328 @@
+package infra
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultCacheTTLs is how long each registry operation's answers are cached,
+// keyed by "country/operation". Searches are kept short so new registrations
+// show up quickly; reference data that changes a few times a year is kept
+// for a week, which with a disk cache survives restarts.
+var defaultCacheTTLs = map[string]time.Duration{
+	"norway/search":          2 * time.Minute,
+	"norway/company":         5 * time.Minute,
+	"norway/roles":           5 * time.Minute,
+	"norway/subunits":        5 * time.Minute,
+	"norway/subunit":         5 * time.Minute,
+	"norway/search_subunits": 2 * time.Minute,
+	"norway/batch":           5 * time.Minute,
+	"norway/municipalities":  7 * 24 * time.Hour,
+	"norway/org_forms":       7 * 24 * time.Hour,
+
+	"denmark/search":  2 * time.Minute,
+	"denmark/company": 5 * time.Minute,
+	"denmark/pnumber": 5 * time.Minute,
+	"denmark/phone":   5 * time.Minute,
+
+	"finland/search":  15 * time.Minute,
+	"finland/company": 15 * time.Minute,
+
+	"sweden/company":   15 * time.Minute,
+	"sweden/documents": 30 * time.Minute,
+
+	// Kept short: the point of a VIES check is that the number is live now.
+	"vies/vat": 15 * time.Minute,
+}
+
+// CachePolicy holds the cache TTL of every registry operation. Clients ask it
+// for the TTL of each call; the server builds one at startup from
+// DefaultCachePolicy and the operator's overrides. A nil *CachePolicy
+// answers with the defaults.
+type CachePolicy struct {
+	ttls map[string]time.Duration
+}
+
+// DefaultCachePolicy returns a policy with the built-in TTLs.
+func DefaultCachePolicy() *CachePolicy {
+	return &CachePolicy{ttls: maps.Clone(defaultCacheTTLs)}
+}
+
+// ParseCachePolicy returns the default policy with the overrides in spec
+// applied: comma-separated "country/operation=duration" pairs such as
+// "norway/search=30s,vies/vat=5m". A zero duration keeps answers only as a
+// fallback for when the registry fails. Unknown operations and negative or
+// malformed durations are errors, so a typo does not go unnoticed.
+func ParseCachePolicy(spec string) (*CachePolicy, error) {
+	p := DefaultCachePolicy()
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("cache TTL %q: want country/operation=duration", pair)
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		if _, known := p.ttls[key]; !known {
+			return nil, fmt.Errorf("cache TTL %q: unknown operation %q (known: %s)", pair, key, strings.Join(p.Operations(), ", "))
+		}
+		ttl, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil || ttl < 0 {
+			return nil, fmt.Errorf("cache TTL %q: %q is not a non-negative duration", pair, value)
+		}
+		p.ttls[key] = ttl
+	}
+	return p, nil
+}
+
+// TTL returns how long answers of operation in country are cached. It
+// panics on an operation missing from the table: every cached call site
+// must have an entry, and a missing one is a programming error.
+func (p *CachePolicy) TTL(country, operation string) time.Duration {
+	key := country + "/" + operation
+	ttls := defaultCacheTTLs
+	if p != nil {
+		ttls = p.ttls
+	}
+	ttl, ok := ttls[key]
+	if !ok {
+		panic("infra: no cache TTL for " + key)
+	}
+	return ttl
+}
+
+// Operations returns the "country/operation" keys of the policy, sorted.
+func (p *CachePolicy) Operations() []string {
+	ttls := defaultCacheTTLs
+	if p != nil {
+		ttls = p.ttls
+	}
+	return slices.Sorted(maps.Keys(ttls))
+}
+
+// defaultBreakerConfigs is the circuit breaker of each registry client. A
+// failed recovery probe doubles the wait before the next, up to five
+// minutes. Finland's PRH has slow spells in which a few calls in a row time
+// out while most succeed, so it opens on the failure rate over its last 20
+// calls instead, with a longer run of consecutive failures as a backstop for
+// a quiet outage.
+var defaultBreakerConfigs = map[string]CircuitBreakerConfig{
+	"norway":  {FailureThreshold: 5, ResetTimeout: 30 * time.Second, MaxResetTimeout: 5 * time.Minute, HalfOpenMax: 2},
+	"denmark": {FailureThreshold: 5, ResetTimeout: 30 * time.Second, MaxResetTimeout: 5 * time.Minute, HalfOpenMax: 2},
+	"finland": {FailureThreshold: 10, FailureRate: 0.5, WindowSize: 20, MinRequests: 10, ResetTimeout: 30 * time.Second, MaxResetTimeout: 5 * time.Minute, HalfOpenMax: 2},
+	"sweden":  {FailureThreshold: 5, ResetTimeout: 30 * time.Second, MaxResetTimeout: 5 * time.Minute, HalfOpenMax: 2},
+	"vies":    {FailureThreshold: 5, ResetTimeout: 30 * time.Second, MaxResetTimeout: 5 * time.Minute, HalfOpenMax: 2},
+}
+
+// breakerSettings are the keys ParseBreakerPolicy accepts after the country,
+// each with the function that applies a value to a config.
+var breakerSettings = map[string]func(cfg *CircuitBreakerConfig, value string) error{
+	"failures":        intSetting(func(c *CircuitBreakerConfig) *int { return &c.FailureThreshold }),
+	"window":          intSetting(func(c *CircuitBreakerConfig) *int { return &c.WindowSize }),
+	"min_requests":    intSetting(func(c *CircuitBreakerConfig) *int { return &c.MinRequests }),
+	"half_open":       intSetting(func(c *CircuitBreakerConfig) *int { return &c.HalfOpenMax }),
+	"window_duration": durationSetting(func(c *CircuitBreakerConfig) *time.Duration { return &c.WindowDuration }),
+	"reset":           durationSetting(func(c *CircuitBreakerConfig) *time.Duration { return &c.ResetTimeout }),
+	"max_reset":       durationSetting(func(c *CircuitBreakerConfig) *time.Duration { return &c.MaxResetTimeout }),
+	"failure_rate": func(cfg *CircuitBreakerConfig, value string) error {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return fmt.Errorf("%q is not a ratio between 0 and 1", value)
+		}
+		cfg.FailureRate = rate
+		return nil
+	},
+}
+
+func intSetting[C any](field func(*C) *int) func(*C, string) error {
+	return func(cfg *C, value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("%q is not a non-negative integer", value)
+		}
+		*field(cfg) = n
+		return nil
+	}
+}
+
+func durationSetting[C any](field func(*C) *time.Duration) func(*C, string) error {
+	return func(cfg *C, value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil || d < 0 {
+			return fmt.Errorf("%q is not a non-negative duration", value)
+		}
+		*field(cfg) = d
+		return nil
+	}
+}
+
+// BreakerPolicy holds the circuit breaker configuration of every registry
+// client. Like CachePolicy it is built at startup from the defaults and the
+// operator's overrides; a nil *BreakerPolicy answers with the defaults.
+type BreakerPolicy struct {
+	configs map[string]CircuitBreakerConfig
+}
+
+// DefaultBreakerPolicy returns a policy with the built-in configurations.
+func DefaultBreakerPolicy() *BreakerPolicy {
+	return &BreakerPolicy{configs: maps.Clone(defaultBreakerConfigs)}
+}
+
+// ParseBreakerPolicy returns the default policy with the overrides in spec
+// applied: comma-separated "country/setting=value" pairs such as
+// "finland/failure_rate=0.6,finland/window_duration=2m,norway/reset=1m".
+// Settings are failures, failure_rate, window, window_duration,
+// min_requests, reset, max_reset and half_open. Unknown countries or
+// settings, malformed values and a configuration that could never open or
+// never recover are errors.
+func ParseBreakerPolicy(spec string) (*BreakerPolicy, error) {
+	p := DefaultBreakerPolicy()
+	if err := applySettings(spec, "circuit breaker", p.configs, breakerSettings); err != nil {
+		return nil, err
+	}
+	for _, country := range slices.Sorted(maps.Keys(p.configs)) {
+		cfg := p.configs[country]
+		switch {
+		case cfg.FailureThreshold == 0 && cfg.FailureRate == 0:
+			return nil, fmt.Errorf("circuit breaker of %s: set failures or failure_rate, or it never opens", country)
+		case cfg.FailureRate > 0 && cfg.WindowSize == 0 && cfg.WindowDuration == 0:
+			return nil, fmt.Errorf("circuit breaker of %s: failure_rate needs a window or window_duration", country)
+		case cfg.ResetTimeout == 0 || cfg.HalfOpenMax == 0:
+			return nil, fmt.Errorf("circuit breaker of %s: reset and half_open must be positive, or it never recovers", country)
+		}
+	}
+	return p, nil
+}
+
+// Config returns the circuit breaker configuration of country's client. It
+// panics on a country missing from the table, a programming error.
+func (p *BreakerPolicy) Config(country string) CircuitBreakerConfig {
+	configs := defaultBreakerConfigs
+	if p != nil {
+		configs = p.configs
+	}
+	cfg, ok := configs[country]
+	if !ok {
+		panic("infra: no circuit breaker configuration for " + country)
+	}
+	return cfg
+}
+
+// NewCircuitBreaker returns a circuit breaker configured for country.
+func (p *BreakerPolicy) NewCircuitBreaker(country string) *CircuitBreaker {
+	return NewCircuitBreakerFromConfig(p.Config(country))
+}
+
+// applySettings applies the comma-separated "country/setting=value" pairs
+// in spec to the per-country configs, naming what in its errors.
+func applySettings[C any](spec, what string, configs map[string]C, settings map[string]func(*C, string) error) error {
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		country, setting, okKey := strings.Cut(strings.ToLower(strings.TrimSpace(key)), "/")
+		if !ok || !okKey {
+			return fmt.Errorf("%s %q: want country/setting=value", what, pair)
+		}
+		cfg, known := configs[country]
+		if !known {
+			return fmt.Errorf("%s %q: unknown country %q (known: %s)", what, pair, country, strings.Join(slices.Sorted(maps.Keys(configs)), ", "))
+		}
+		apply, known := settings[setting]
+		if !known {
+			return fmt.Errorf("%s %q: unknown setting %q (known: %s)", what, pair, setting, strings.Join(slices.Sorted(maps.Keys(settings)), ", "))
+		}
+		if err := apply(&cfg, strings.TrimSpace(value)); err != nil {
+			return fmt.Errorf("%s %q: %w", what, pair, err)
+		}
+		configs[country] = cfg
+	}
+	return nil
+}
+
+// defaultRateLimits is the request budget of each registry's hosts. No
+// registry publishes a daily quota, so none is set by default; operators who
+// know theirs (a cvrapi.dk subscription, say) set it with daily_quota.
+// cvrapi.dk, a free service, gets the tightest rate; the other budgets keep
+// batch tools from bursting at the registries.
+var defaultRateLimits = map[string]RateLimitConfig{
+	"norway":  {Rate: 10, Burst: 20},
+	"denmark": {Rate: 1, Burst: 3},
+	"finland": {Rate: 5, Burst: 10},
+	"sweden":  {Rate: 5, Burst: 10},
+	"vies":    {Rate: 2, Burst: 5},
+}
+
+// rateLimitSettings are the keys ParseRateLimitPolicy accepts after the
+// country.
+var rateLimitSettings = map[string]func(cfg *RateLimitConfig, value string) error{
+	"burst":       intSetting(func(c *RateLimitConfig) *int { return &c.Burst }),
+	"daily_quota": intSetting(func(c *RateLimitConfig) *int { return &c.DailyQuota }),
+	"rate": func(cfg *RateLimitConfig, value string) error {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate < 0 {
+			return fmt.Errorf("%q is not a non-negative number of requests per second", value)
+		}
+		cfg.Rate = rate
+		return nil
+	},
+}
+
+// RateLimitPolicy holds the request budget of every registry client's
+// upstream hosts. Like CachePolicy it is built at startup from the defaults
+// and the operator's overrides; a nil *RateLimitPolicy answers with the
+// defaults.
+type RateLimitPolicy struct {
+	configs map[string]RateLimitConfig
+}
+
+// DefaultRateLimitPolicy returns a policy with the built-in budgets.
+func DefaultRateLimitPolicy() *RateLimitPolicy {
+	return &RateLimitPolicy{configs: maps.Clone(defaultRateLimits)}
+}
+
+// ParseRateLimitPolicy returns the default policy with the overrides in spec
+// applied: comma-separated "country/setting=value" pairs such as
+// "denmark/daily_quota=1000,norway/rate=5". Settings are rate (requests per
+// second, 0 for no limit), burst and daily_quota (0 for no quota). Unknown
+// countries or settings, malformed values and a rate without a burst are
+// errors.
+func ParseRateLimitPolicy(spec string) (*RateLimitPolicy, error) {
+	p := DefaultRateLimitPolicy()
+	if err := applySettings(spec, "rate limit", p.configs, rateLimitSettings); err != nil {
+		return nil, err
+	}
+	for _, country := range slices.Sorted(maps.Keys(p.configs)) {
+		if cfg := p.configs[country]; cfg.Rate > 0 && cfg.Burst == 0 {
+			return nil, fmt.Errorf("rate limit of %s: burst must be positive when rate is set", country)
+		}
+	}
+	return p, nil
+}
+
+// Config returns the request budget of country's client. It panics on a
+// country missing from the table, a programming error.
+func (p *RateLimitPolicy) Config(country string) RateLimitConfig {
+	configs := defaultRateLimits
+	if p != nil {
+		configs = p.configs
+	}
+	cfg, ok := configs[country]
+	if !ok {
+		panic("infra: no rate limit for " + country)
+	}
+	return cfg
+}
+
+// NewRateLimiter returns a rate limiter with country's budget.
+func (p *RateLimitPolicy) NewRateLimiter(country string) *RateLimiter {
+	return NewRateLimiter(p.Config(country))
+}
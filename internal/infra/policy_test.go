@@ -0,0 +1,138 @@
+package infra
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCachePolicy(t *testing.T) {
+	p, err := ParseCachePolicy(" norway/search=30s, VIES/vat=0s ,")
+	if err != nil {
+		t.Fatalf("ParseCachePolicy: %v", err)
+	}
+	if got := p.TTL("norway", "search"); got != 30*time.Second {
+		t.Errorf("norway/search = %v, want 30s", got)
+	}
+	if got := p.TTL("vies", "vat"); got != 0 {
+		t.Errorf("vies/vat = %v, want 0", got)
+	}
+	if got := p.TTL("sweden", "documents"); got != 30*time.Minute {
+		t.Errorf("sweden/documents = %v, want the default 30m", got)
+	}
+	// The defaults are not changed by an override.
+	if got := DefaultCachePolicy().TTL("norway", "search"); got != 2*time.Minute {
+		t.Errorf("default norway/search = %v after an override, want 2m", got)
+	}
+
+	for _, spec := range []string{"norway/serch=1m", "norway/search", "norway/search=soon", "norway/search=-1m"} {
+		if _, err := ParseCachePolicy(spec); err == nil {
+			t.Errorf("ParseCachePolicy(%q) succeeded, want an error", spec)
+		}
+	}
+	if _, err := ParseCachePolicy("norway/serch=1m"); err == nil || !strings.Contains(err.Error(), "norway/search") {
+		t.Errorf("unknown operation error = %v, want it to list the known ones", err)
+	}
+}
+
+func TestCachePolicy_NilAndUnknown(t *testing.T) {
+	var p *CachePolicy
+	if got := p.TTL("finland", "company"); got != 15*time.Minute {
+		t.Errorf("nil policy finland/company = %v, want 15m", got)
+	}
+	if got := len(p.Operations()); got != len(defaultCacheTTLs) {
+		t.Errorf("nil policy has %d operations, want %d", got, len(defaultCacheTTLs))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("TTL of an operation missing from the table did not panic")
+		}
+	}()
+	p.TTL("iceland", "company")
+}
+
+func TestParseBreakerPolicy(t *testing.T) {
+	p, err := ParseBreakerPolicy("finland/failure_rate=0.6, finland/window_duration=2m,Norway/reset=1m")
+	if err != nil {
+		t.Fatalf("ParseBreakerPolicy: %v", err)
+	}
+	fi := p.Config("finland")
+	if fi.FailureRate != 0.6 || fi.WindowDuration != 2*time.Minute || fi.WindowSize != 20 {
+		t.Errorf("finland = %+v, want the overrides on the defaults", fi)
+	}
+	if got := p.Config("norway").ResetTimeout; got != time.Minute {
+		t.Errorf("norway reset = %v, want 1m", got)
+	}
+	if got := DefaultBreakerPolicy().Config("norway").ResetTimeout; got != 30*time.Second {
+		t.Errorf("default norway reset = %v after an override, want 30s", got)
+	}
+
+	for _, spec := range []string{
+		"iceland/reset=1m",           // Unknown country
+		"norway/timeout=1m",          // Unknown setting
+		"norway/reset",               // No value
+		"norway=1m",                  // No setting
+		"norway/failure_rate=1.5",    // Not a ratio
+		"norway/failures=-1",         // Negative
+		"norway/reset=0s",            // Never recovers
+		"norway/failures=0",          // Never opens
+		"norway/failure_rate=0.5",    // No window
+		"finland/half_open=0",        // Never recovers
+		"denmark/window_duration=xs", // Not a duration
+	} {
+		if _, err := ParseBreakerPolicy(spec); err == nil {
+			t.Errorf("ParseBreakerPolicy(%q) succeeded, want an error", spec)
+		}
+	}
+}
+
+func TestBreakerPolicy_NilAndUnknown(t *testing.T) {
+	var p *BreakerPolicy
+	if got := p.Config("finland"); got.FailureRate != 0.5 {
+		t.Errorf("nil policy finland = %+v, want the default failure rate", got)
+	}
+	if cb := p.NewCircuitBreaker("sweden"); cb.State() != CircuitClosed {
+		t.Errorf("new breaker state = %v, want closed", cb.State())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Config of a country missing from the table did not panic")
+		}
+	}()
+	p.Config("iceland")
+}
+
+func TestParseRateLimitPolicy(t *testing.T) {
+	p, err := ParseRateLimitPolicy("denmark/daily_quota=1000, Norway/rate=2.5,norway/burst=4")
+	if err != nil {
+		t.Fatalf("ParseRateLimitPolicy: %v", err)
+	}
+	if got := p.Config("denmark"); got.DailyQuota != 1000 || got.Rate != 1 {
+		t.Errorf("denmark = %+v, want the quota override on the defaults", got)
+	}
+	if got := p.Config("norway"); got.Rate != 2.5 || got.Burst != 4 {
+		t.Errorf("norway = %+v, want rate 2.5 and burst 4", got)
+	}
+	if got := DefaultRateLimitPolicy().Config("denmark").DailyQuota; got != 0 {
+		t.Errorf("default denmark quota = %d after an override, want none", got)
+	}
+
+	for _, spec := range []string{
+		"iceland/rate=1",    // Unknown country
+		"norway/requests=1", // Unknown setting
+		"norway/rate=-1",    // Negative
+		"norway/burst=x",    // Not a number
+		"norway/burst=0",    // A rate without a burst
+	} {
+		if _, err := ParseRateLimitPolicy(spec); err == nil {
+			t.Errorf("ParseRateLimitPolicy(%q) succeeded, want an error", spec)
+		}
+	}
+
+	// A zero rate lifts the limit, so it needs no burst.
+	if _, err := ParseRateLimitPolicy("norway/rate=0,norway/burst=0"); err != nil {
+		t.Errorf("no limit: %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+package infra
+
+import (
+	"context"
+	"sync"
+)
+
+// RunBounded calls fn once for every index in [0, n) using at most workers
+// goroutines, and returns when all calls have finished. Every index is
+// visited even after ctx is canceled: fn receives ctx and is expected to fail
+// fast (the registry clients do, via their semaphore and dedup waits), so each
+// item records its own outcome instead of being silently skipped.
+//
+// workers <= 0 or workers > n is clamped, so callers can pass a client's
+// concurrency limit directly without special-casing small inputs.
+func RunBounded(ctx context.Context, n, workers int, fn func(ctx context.Context, i int)) {
+	if n <= 0 {
+		return
+	}
+	if workers <= 0 || workers > n {
+		workers = n
+	}
+
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				fn(ctx, i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+}
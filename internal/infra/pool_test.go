@@ -0,0 +1,60 @@
+package infra
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunBounded_VisitsEveryIndex(t *testing.T) {
+	seen := make([]int32, 50)
+	RunBounded(context.Background(), len(seen), 4, func(_ context.Context, i int) {
+		atomic.AddInt32(&seen[i], 1)
+	})
+	for i, n := range seen {
+		if n != 1 {
+			t.Errorf("index %d visited %d times, want 1", i, n)
+		}
+	}
+}
+
+func TestRunBounded_RespectsWorkerLimit(t *testing.T) {
+	var active, peak int32
+	RunBounded(context.Background(), 20, 3, func(_ context.Context, _ int) {
+		cur := atomic.AddInt32(&active, 1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if cur <= old || atomic.CompareAndSwapInt32(&peak, old, cur) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&active, -1)
+	})
+	if peak > 3 {
+		t.Errorf("peak concurrency = %d, want <= 3", peak)
+	}
+}
+
+func TestRunBounded_CanceledContextStillVisits(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var calls, sawCanceled int32
+	RunBounded(ctx, 10, 2, func(ctx context.Context, _ int) {
+		atomic.AddInt32(&calls, 1)
+		if ctx.Err() != nil {
+			atomic.AddInt32(&sawCanceled, 1)
+		}
+	})
+	if calls != 10 || sawCanceled != 10 {
+		t.Errorf("calls = %d, canceled = %d; want 10 and 10", calls, sawCanceled)
+	}
+}
+
+func TestRunBounded_ZeroItems(t *testing.T) {
+	RunBounded(context.Background(), 0, 4, func(context.Context, int) {
+		t.Fatal("fn must not be called for n=0")
+	})
+}
@@ -0,0 +1,76 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync/atomic"
+)
+
+// ProgressFunc receives progress for a long-running operation: done units out
+// of total (0 when unknown) and a short human-readable message. It may be
+// called from several goroutines at once and must not block for long.
+type ProgressFunc func(done, total int64, message string)
+
+type progressKey struct{}
+
+// WithProgress returns a context that carries fn, so client code several
+// calls deep can report progress without a parameter on every signature. The
+// tool layer installs one when the caller asked for progress; everywhere else
+// ReportProgress is a no-op.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// ReportProgress forwards progress to the ProgressFunc carried by ctx, if any.
+func ReportProgress(ctx context.Context, done, total int64, message string) {
+	if fn, ok := ctx.Value(progressKey{}).(ProgressFunc); ok {
+		fn(done, total, message)
+	}
+}
+
+// ProgressCounter counts completed items across concurrent workers and
+// reports each completion as "<done> of <total> <unit>".
+type ProgressCounter struct {
+	ctx   context.Context
+	total int64
+	unit  string
+	done  atomic.Int64
+}
+
+// NewProgressCounter returns a counter for total items of the given unit
+// (e.g. "companies") that reports through ctx.
+func NewProgressCounter(ctx context.Context, total int, unit string) *ProgressCounter {
+	return &ProgressCounter{ctx: ctx, total: int64(total), unit: unit}
+}
+
+// Add records n completed items and reports the new count.
+func (p *ProgressCounter) Add(n int) {
+	done := p.done.Add(int64(n))
+	ReportProgress(p.ctx, done, p.total, fmt.Sprintf("%d of %d %s", done, p.total, p.unit))
+}
+
+// ProgressReader wraps r and reports the bytes read so far through ctx.
+// total is the expected size, or 0 when unknown (no Content-Length).
+func ProgressReader(ctx context.Context, r io.Reader, total int64) io.Reader {
+	if _, ok := ctx.Value(progressKey{}).(ProgressFunc); !ok {
+		return r
+	}
+	return &progressReader{ctx: ctx, r: r, total: total}
+}
+
+type progressReader struct {
+	ctx   context.Context
+	r     io.Reader
+	total int64
+	read  int64
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.read += int64(n)
+		ReportProgress(p.ctx, p.read, p.total, fmt.Sprintf("%d bytes downloaded", p.read))
+	}
+	return n, err
+}
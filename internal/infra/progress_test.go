@@ -0,0 +1,76 @@
+package infra
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestReportProgress_NoReporter(t *testing.T) {
+	// Must be a no-op, not a panic, when nobody asked for progress.
+	ReportProgress(context.Background(), 1, 2, "ignored")
+	NewProgressCounter(context.Background(), 2, "items").Add(1)
+}
+
+func TestProgressCounter_ConcurrentAdds(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		calls   int
+		highest int64
+		last    string
+	)
+	ctx := WithProgress(context.Background(), func(done, total int64, message string) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		if total != 20 {
+			t.Errorf("total = %d, want 20", total)
+		}
+		if done > highest {
+			highest, last = done, message
+		}
+	})
+
+	counter := NewProgressCounter(ctx, 20, "companies")
+	RunBounded(ctx, 20, 4, func(context.Context, int) { counter.Add(1) })
+
+	if calls != 20 {
+		t.Errorf("reported %d times, want 20", calls)
+	}
+	if highest != 20 || last != "20 of 20 companies" {
+		t.Errorf("final report = %d %q, want 20 %q", highest, last, "20 of 20 companies")
+	}
+}
+
+func TestProgressReader(t *testing.T) {
+	data := strings.Repeat("x", 10000)
+
+	var reports []int64
+	ctx := WithProgress(context.Background(), func(done, total int64, _ string) {
+		if total != int64(len(data)) {
+			t.Errorf("total = %d, want %d", total, len(data))
+		}
+		reports = append(reports, done)
+	})
+
+	got, err := io.ReadAll(ProgressReader(ctx, strings.NewReader(data), int64(len(data))))
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if string(got) != data {
+		t.Fatal("ProgressReader altered the data")
+	}
+	if len(reports) == 0 || reports[len(reports)-1] != int64(len(data)) {
+		t.Errorf("reports = %v, want last %d", reports, len(data))
+	}
+}
+
+func TestProgressReader_NoReporterPassesThrough(t *testing.T) {
+	r := bytes.NewReader([]byte("abc"))
+	if got := ProgressReader(context.Background(), r, 3); got != io.Reader(r) {
+		t.Error("ProgressReader should return the reader unchanged without a reporter")
+	}
+}
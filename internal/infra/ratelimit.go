@@ -0,0 +1,230 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// RateLimitConfig configures the request budget of one upstream host.
+type RateLimitConfig struct {
+	Rate       float64 // Requests per second; 0 for no limit
+	Burst      int     // Requests allowed back to back after a quiet spell
+	DailyQuota int     // Requests per UTC day; 0 for no quota
+}
+
+// RateLimiter keeps registry clients within the request budget of the hosts
+// they call: a token bucket per host refilled at Rate up to Burst, and a
+// count of the day's requests against DailyQuota. Callers wait for a token
+// rather than fail, unless the wait would outlast their deadline. The quota
+// is counted by this process only, so it is a guard against bursts, not an
+// exact mirror of the registry's own count.
+type RateLimiter struct {
+	cfg   RateLimitConfig
+	mu    sync.Mutex
+	hosts map[string]*hostBudget
+	waits int64
+}
+
+// hostBudget is the bucket and quota count of one host.
+type hostBudget struct {
+	tokens float64
+	last   time.Time // When tokens was last refilled
+	day    time.Time // UTC midnight starting the day counted in used
+	used   int
+}
+
+// ErrQuotaExhausted is returned when a host's daily quota is used up.
+type ErrQuotaExhausted struct {
+	Host    string
+	Quota   int
+	ResetAt time.Time
+}
+
+func (e *ErrQuotaExhausted) Error() string {
+	return fmt.Sprintf("daily quota of %d requests to %s is used up, resets at %s", e.Quota, e.Host, e.ResetAt.Format(time.RFC3339))
+}
+
+// ErrRateLimitDeadline is returned when the wait for a request slot would
+// end after the caller's deadline.
+type ErrRateLimitDeadline struct {
+	Host string
+	Wait time.Duration
+}
+
+func (e *ErrRateLimitDeadline) Error() string {
+	return fmt.Sprintf("rate limit for %s: next request slot in %s, after the deadline", e.Host, e.Wait.Round(time.Millisecond))
+}
+
+// NewRateLimiter creates a rate limiter applying cfg to every host.
+func NewRateLimiter(cfg RateLimitConfig) *RateLimiter {
+	return &RateLimiter{
+		cfg:   cfg,
+		hosts: make(map[string]*hostBudget),
+	}
+}
+
+// Wait blocks until a request to host may be sent and counts it against the
+// host's quota. It returns an *ErrQuotaExhausted when the quota is used up,
+// an *ErrRateLimitDeadline when the wait would outlast ctx's deadline, and
+// ctx's error when ctx ends while waiting. The returned duration is how long
+// it waited. A nil *RateLimiter never waits.
+func (r *RateLimiter) Wait(ctx context.Context, host string) (time.Duration, error) {
+	if r == nil {
+		return 0, nil
+	}
+
+	delay, err := r.reserve(ctx, host)
+	if err != nil || delay == 0 {
+		return 0, err
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return delay, nil
+	case <-ctx.Done():
+		r.release(host)
+		return 0, fmt.Errorf("context canceled while waiting for rate limit: %w", ctx.Err())
+	}
+}
+
+// Allow takes a token and a quota unit for host if both are available now,
+// without waiting. It suits optional requests, such as hedges, that are
+// better skipped than delayed. A nil *RateLimiter always allows.
+func (r *RateLimiter) Allow(host string) bool {
+	if r == nil {
+		return true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b := r.budget(host, time.Now())
+	if r.cfg.DailyQuota > 0 && b.used >= r.cfg.DailyQuota {
+		return false
+	}
+	if r.cfg.Rate > 0 {
+		if b.tokens < 1 {
+			return false
+		}
+		b.tokens--
+	}
+	b.used++
+	return true
+}
+
+// reserve takes a token and a quota unit for host and returns how long the
+// caller must wait before using them. A token taken from an empty bucket
+// puts it in debt, so callers queue up in arrival order.
+func (r *RateLimiter) reserve(ctx context.Context, host string) (time.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	b := r.budget(host, now)
+
+	if r.cfg.DailyQuota > 0 && b.used >= r.cfg.DailyQuota {
+		return 0, &ErrQuotaExhausted{Host: host, Quota: r.cfg.DailyQuota, ResetAt: b.day.AddDate(0, 0, 1)}
+	}
+
+	var delay time.Duration
+	if r.cfg.Rate > 0 {
+		if b.tokens < 1 {
+			delay = time.Duration((1 - b.tokens) / r.cfg.Rate * float64(time.Second))
+		}
+		if deadline, ok := ctx.Deadline(); ok && now.Add(delay).After(deadline) {
+			return 0, &ErrRateLimitDeadline{Host: host, Wait: delay}
+		}
+		b.tokens--
+	}
+	b.used++
+	if delay > 0 {
+		r.waits++
+	}
+	return delay, nil
+}
+
+// release hands back a reservation the caller did not use.
+func (r *RateLimiter) release(host string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b := r.budget(host, time.Now())
+	if r.cfg.Rate > 0 {
+		b.tokens = min(b.tokens+1, float64(r.cfg.Burst))
+	}
+	if b.used > 0 {
+		b.used--
+	}
+}
+
+// budget returns host's budget, refilled and rolled over to the current day.
+// The caller holds r.mu.
+func (r *RateLimiter) budget(host string, now time.Time) *hostBudget {
+	today := now.UTC().Truncate(24 * time.Hour)
+	b, ok := r.hosts[host]
+	if !ok {
+		b = &hostBudget{tokens: float64(r.cfg.Burst), last: now, day: today}
+		r.hosts[host] = b
+	}
+	if r.cfg.Rate > 0 {
+		b.tokens = min(b.tokens+now.Sub(b.last).Seconds()*r.cfg.Rate, float64(r.cfg.Burst))
+	}
+	b.last = now
+	if today.After(b.day) {
+		b.day = today
+		b.used = 0
+	}
+	return b
+}
+
+// RateLimitStats is a snapshot of a rate limiter.
+type RateLimitStats struct {
+	Rate       float64          `json:"rate"`
+	Burst      int              `json:"burst"`
+	DailyQuota int              `json:"daily_quota,omitempty"`
+	Waits      int64            `json:"waits"` // Requests that waited for a token
+	Hosts      []HostQuotaStats `json:"hosts,omitempty"`
+}
+
+// HostQuotaStats is the state of one host's budget.
+type HostQuotaStats struct {
+	Host           string    `json:"host"`
+	Tokens         float64   `json:"tokens"`
+	QuotaUsed      int       `json:"quota_used"`
+	QuotaRemaining int       `json:"quota_remaining"` // Meaningful only with a DailyQuota
+	QuotaResetAt   time.Time `json:"quota_reset_at"`
+}
+
+// Stats returns the limiter's configuration, wait count and the budget of
+// every host it has seen, sorted by host. A nil *RateLimiter reports none.
+func (r *RateLimiter) Stats() RateLimitStats {
+	if r == nil {
+		return RateLimitStats{}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := RateLimitStats{
+		Rate:       r.cfg.Rate,
+		Burst:      r.cfg.Burst,
+		DailyQuota: r.cfg.DailyQuota,
+		Waits:      r.waits,
+	}
+	now := time.Now()
+	for _, host := range slices.Sorted(maps.Keys(r.hosts)) {
+		b := r.budget(host, now)
+		stats.Hosts = append(stats.Hosts, HostQuotaStats{
+			Host:           host,
+			Tokens:         b.tokens,
+			QuotaUsed:      b.used,
+			QuotaRemaining: max(r.cfg.DailyQuota-b.used, 0),
+			QuotaResetAt:   b.day.AddDate(0, 0, 1),
+		})
+	}
+	return stats
+}
@@ -0,0 +1,109 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_BurstThenWait(t *testing.T) {
+	r := NewRateLimiter(RateLimitConfig{Rate: 20, Burst: 2})
+	ctx := context.Background()
+
+	for i := range 2 {
+		if waited, err := r.Wait(ctx, "cvrapi.dk"); err != nil || waited != 0 {
+			t.Fatalf("request %d within the burst: waited %v, err %v", i, waited, err)
+		}
+	}
+	waited, err := r.Wait(ctx, "cvrapi.dk")
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if waited < 40*time.Millisecond {
+		t.Errorf("waited %v past the burst, want about 50ms", waited)
+	}
+
+	// Other hosts have their own bucket.
+	if waited, _ := r.Wait(ctx, "data.brreg.no"); waited != 0 {
+		t.Errorf("waited %v for a fresh host", waited)
+	}
+	if got := r.Stats().Waits; got != 1 {
+		t.Errorf("waits = %d, want 1", got)
+	}
+}
+
+func TestRateLimiter_Deadline(t *testing.T) {
+	r := NewRateLimiter(RateLimitConfig{Rate: 1, Burst: 1})
+	if _, err := r.Wait(context.Background(), "host"); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := r.Wait(ctx, "host")
+	var deadline *ErrRateLimitDeadline
+	if !errors.As(err, &deadline) {
+		t.Fatalf("err = %v, want an ErrRateLimitDeadline", err)
+	}
+	if time.Since(start) > 50*time.Millisecond {
+		t.Error("waited for a slot it could not use")
+	}
+	if got := r.Stats().Hosts[0].QuotaUsed; got != 1 {
+		t.Errorf("quota used = %d, want the refused request uncounted", got)
+	}
+}
+
+func TestRateLimiter_CancelReleases(t *testing.T) {
+	r := NewRateLimiter(RateLimitConfig{Rate: 1, Burst: 1})
+	_, _ = r.Wait(context.Background(), "host")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	if _, err := r.Wait(ctx, "host"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if tokens := r.Stats().Hosts[0].Tokens; tokens < 0 {
+		t.Errorf("tokens = %v, want the cancelled reservation handed back", tokens)
+	}
+}
+
+func TestRateLimiter_DailyQuota(t *testing.T) {
+	r := NewRateLimiter(RateLimitConfig{DailyQuota: 2})
+	ctx := context.Background()
+	for range 2 {
+		if _, err := r.Wait(ctx, "cvrapi.dk"); err != nil {
+			t.Fatalf("Wait within the quota: %v", err)
+		}
+	}
+
+	_, err := r.Wait(ctx, "cvrapi.dk")
+	var exhausted *ErrQuotaExhausted
+	if !errors.As(err, &exhausted) || exhausted.Quota != 2 {
+		t.Fatalf("err = %v, want an ErrQuotaExhausted", err)
+	}
+	stats := r.Stats().Hosts[0]
+	if stats.QuotaRemaining != 0 || !stats.QuotaResetAt.Equal(exhausted.ResetAt) {
+		t.Errorf("stats = %+v, want no quota left until %v", stats, exhausted.ResetAt)
+	}
+
+	// The count starts over on the next UTC day.
+	r.hosts["cvrapi.dk"].day = r.hosts["cvrapi.dk"].day.AddDate(0, 0, -1)
+	if _, err := r.Wait(ctx, "cvrapi.dk"); err != nil {
+		t.Errorf("Wait on a new day: %v", err)
+	}
+	if got := r.Stats().Hosts[0].QuotaRemaining; got != 1 {
+		t.Errorf("quota remaining = %d, want 1", got)
+	}
+}
+
+func TestRateLimiter_Nil(t *testing.T) {
+	var r *RateLimiter
+	if waited, err := r.Wait(context.Background(), "host"); waited != 0 || err != nil {
+		t.Errorf("nil limiter: waited %v, err %v", waited, err)
+	}
+	if stats := r.Stats(); stats.Hosts != nil {
+		t.Errorf("nil limiter stats = %+v", stats)
+	}
+}
@@ -80,13 +80,20 @@ func (d *RequestDeduplicator) Stats() int {
 }
 
 // CircuitBreaker prevents cascading failures by failing fast when an API is unresponsive.
-// It tracks consecutive failures and opens the circuit after a threshold is reached.
+// It opens after a run of consecutive failures or, when configured with a
+// window, when the share of failures among recent requests gets too high.
+// Each time a recovery probe fails it stays open longer, up to a cap.
 type CircuitBreaker struct {
 	mu sync.RWMutex
 
 	// Configuration
-	failureThreshold int           // Consecutive failures before opening
+	failureThreshold int           // Consecutive failures before opening; 0 disables
+	failureRate      float64       // Failure ratio within the window that opens it; 0 disables
+	windowSize       int           // Count window: the last N outcomes
+	windowDuration   time.Duration // Time window: outcomes of the last T
+	minRequests      int           // Outcomes the window needs before failureRate applies
 	resetTimeout     time.Duration // Time to wait before attempting recovery
+	maxResetTimeout  time.Duration // Cap on resetTimeout doubling after failed probes
 	halfOpenMax      int           // Max requests allowed in half-open state
 
 	// State
@@ -94,6 +101,44 @@ type CircuitBreaker struct {
 	consecutiveFails int
 	lastFailure      time.Time
 	halfOpenCount    int
+	forced           bool      // Opened by ForceOpen; stays open until Reset
+	trips            int       // Openings since the circuit last closed
+	retryAt          time.Time // When an open circuit lets a probe through
+	window           []outcome // Recent outcomes, oldest first
+}
+
+// outcome is one request result in a CircuitBreaker's window.
+type outcome struct {
+	at     time.Time
+	failed bool
+}
+
+// maxWindowOutcomes bounds a time-only window under heavy traffic; the
+// failure ratio is then over the most recent outcomes in it.
+const maxWindowOutcomes = 1000
+
+// CircuitBreakerConfig configures a CircuitBreaker. The circuit opens when
+// either trigger fires: FailureThreshold consecutive failures, or a failure
+// ratio of at least FailureRate among the outcomes in the window once it
+// holds MinRequests of them. The window is the last WindowSize outcomes, the
+// outcomes of the last WindowDuration, or both limits together.
+type CircuitBreakerConfig struct {
+	FailureThreshold int           // Consecutive failures that open the circuit; 0 disables
+	FailureRate      float64       // Failure ratio in the window that opens the circuit, 0-1; 0 disables
+	WindowSize       int           // Outcomes in the window
+	WindowDuration   time.Duration // Age limit of outcomes in the window
+	MinRequests      int           // Outcomes the window must hold before FailureRate applies
+	ResetTimeout     time.Duration // Time open before the first recovery probe
+	MaxResetTimeout  time.Duration // Cap on ResetTimeout doubling after each failed probe; at or below ResetTimeout, no doubling
+	HalfOpenMax      int           // Probe requests allowed while half-open
+}
+
+// DefaultCircuitBreakerConfig opens after 5 consecutive failures and probes
+// again after 30 seconds.
+var DefaultCircuitBreakerConfig = CircuitBreakerConfig{
+	FailureThreshold: 5,
+	ResetTimeout:     30 * time.Second,
+	HalfOpenMax:      2,
 }
 
 // CircuitState represents the current state of the circuit breaker
@@ -120,20 +165,29 @@ func (s CircuitState) String() string {
 
 // NewCircuitBreaker creates a new circuit breaker with sensible defaults
 func NewCircuitBreaker() *CircuitBreaker {
-	return &CircuitBreaker{
-		failureThreshold: 5,                // Open after 5 consecutive failures
-		resetTimeout:     30 * time.Second, // Try recovery after 30 seconds
-		halfOpenMax:      2,                // Allow 2 test requests in half-open
-		state:            CircuitClosed,
-	}
+	return NewCircuitBreakerFromConfig(DefaultCircuitBreakerConfig)
 }
 
 // NewCircuitBreakerWithConfig creates a circuit breaker with custom configuration
 func NewCircuitBreakerWithConfig(failureThreshold int, resetTimeout time.Duration, halfOpenMax int) *CircuitBreaker {
+	return NewCircuitBreakerFromConfig(CircuitBreakerConfig{
+		FailureThreshold: failureThreshold,
+		ResetTimeout:     resetTimeout,
+		HalfOpenMax:      halfOpenMax,
+	})
+}
+
+// NewCircuitBreakerFromConfig creates a circuit breaker configured by cfg
+func NewCircuitBreakerFromConfig(cfg CircuitBreakerConfig) *CircuitBreaker {
 	return &CircuitBreaker{
-		failureThreshold: failureThreshold,
-		resetTimeout:     resetTimeout,
-		halfOpenMax:      halfOpenMax,
+		failureThreshold: cfg.FailureThreshold,
+		failureRate:      cfg.FailureRate,
+		windowSize:       cfg.WindowSize,
+		windowDuration:   cfg.WindowDuration,
+		minRequests:      cfg.MinRequests,
+		resetTimeout:     cfg.ResetTimeout,
+		maxResetTimeout:  cfg.MaxResetTimeout,
+		halfOpenMax:      cfg.HalfOpenMax,
 		state:            CircuitClosed,
 	}
 }
@@ -149,8 +203,11 @@ func (cb *CircuitBreaker) Allow() bool {
 		return true
 
 	case CircuitOpen:
+		if cb.forced {
+			return false
+		}
 		// Check if we should transition to half-open
-		if time.Since(cb.lastFailure) > cb.resetTimeout {
+		if !time.Now().Before(cb.retryAt) {
 			cb.state = CircuitHalfOpen
 			cb.halfOpenCount = 0
 			return true
@@ -177,10 +234,19 @@ func (cb *CircuitBreaker) RecordSuccess() {
 
 	cb.consecutiveFails = 0
 
-	if cb.state == CircuitHalfOpen {
+	switch cb.state {
+	case CircuitClosed:
+		cb.observe(false)
+		if cb.rateExceeded() {
+			cb.open()
+		}
+
+	case CircuitHalfOpen:
 		// Successful request in half-open state closes the circuit
 		cb.state = CircuitClosed
 		cb.halfOpenCount = 0
+		cb.trips = 0
+		cb.window = nil
 	}
 }
 
@@ -194,15 +260,123 @@ func (cb *CircuitBreaker) RecordFailure() {
 
 	switch cb.state {
 	case CircuitClosed:
-		if cb.consecutiveFails >= cb.failureThreshold {
-			cb.state = CircuitOpen
+		cb.observe(true)
+		if (cb.failureThreshold > 0 && cb.consecutiveFails >= cb.failureThreshold) || cb.rateExceeded() {
+			cb.open()
 		}
 
 	case CircuitHalfOpen:
-		// Any failure in half-open goes back to open
-		cb.state = CircuitOpen
-		cb.halfOpenCount = 0
+		// Any failure in half-open goes back to open, for longer
+		cb.open()
+	}
+}
+
+// RecordIgnored records a request whose outcome says nothing about the
+// registry's health, such as one the caller cancelled. It counts as neither
+// success nor failure; in half-open it hands back the probe slot.
+func (cb *CircuitBreaker) RecordIgnored() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == CircuitHalfOpen && cb.halfOpenCount > 0 {
+		cb.halfOpenCount--
+	}
+}
+
+// open trips the circuit. The wait before the first probe doubles with each
+// opening since the circuit last closed, up to maxResetTimeout. The window
+// starts empty after recovery. cb.mu must be held.
+func (cb *CircuitBreaker) open() {
+	cb.state = CircuitOpen
+	cb.halfOpenCount = 0
+	cb.trips++
+	cb.window = nil
+
+	wait := cb.resetTimeout
+	for i := 1; i < cb.trips && wait < cb.maxResetTimeout; i++ {
+		wait *= 2
+	}
+	if cb.maxResetTimeout > cb.resetTimeout && wait > cb.maxResetTimeout {
+		wait = cb.maxResetTimeout
+	}
+	cb.retryAt = time.Now().Add(wait)
+}
+
+// observe adds an outcome to the window, if the breaker has one. cb.mu must
+// be held.
+func (cb *CircuitBreaker) observe(failed bool) {
+	if cb.failureRate <= 0 {
+		return
 	}
+	now := time.Now()
+	cb.window = append(cb.window, outcome{at: now, failed: failed})
+	cb.prune(now)
+}
+
+// prune drops outcomes that fell out of the window. cb.mu must be held.
+func (cb *CircuitBreaker) prune(now time.Time) {
+	limit := cb.windowSize
+	if limit <= 0 || limit > maxWindowOutcomes {
+		limit = maxWindowOutcomes
+	}
+	drop := max(len(cb.window)-limit, 0)
+	if cb.windowDuration > 0 {
+		cutoff := now.Add(-cb.windowDuration)
+		for drop < len(cb.window) && cb.window[drop].at.Before(cutoff) {
+			drop++
+		}
+	}
+	if drop > 0 {
+		cb.window = append(cb.window[:0], cb.window[drop:]...)
+	}
+}
+
+// windowCounts returns the outcomes and failures in the window. cb.mu must
+// be held.
+func (cb *CircuitBreaker) windowCounts() (requests, failures int) {
+	for _, o := range cb.window {
+		if o.failed {
+			failures++
+		}
+	}
+	return len(cb.window), failures
+}
+
+// rateExceeded reports whether the window holds enough outcomes and too
+// large a share of them failed. cb.mu must be held.
+func (cb *CircuitBreaker) rateExceeded() bool {
+	if cb.failureRate <= 0 {
+		return false
+	}
+	requests, failures := cb.windowCounts()
+	return requests > 0 && requests >= cb.minRequests && float64(failures)/float64(requests) >= cb.failureRate
+}
+
+// ForceOpen opens the circuit until Reset, for an operator who knows the
+// registry is down and wants calls to fail fast (or be answered from stale
+// cache entries) without waiting for failures to trip it.
+func (cb *CircuitBreaker) ForceOpen() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.state = CircuitOpen
+	cb.forced = true
+	cb.halfOpenCount = 0
+}
+
+// Reset closes the circuit and forgets its failures, including after
+// ForceOpen.
+func (cb *CircuitBreaker) Reset() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.state = CircuitClosed
+	cb.forced = false
+	cb.consecutiveFails = 0
+	cb.halfOpenCount = 0
+	cb.trips = 0
+	cb.retryAt = time.Time{}
+	cb.window = nil
 }
 
 // State returns the current circuit state
@@ -214,13 +388,22 @@ func (cb *CircuitBreaker) State() CircuitState {
 
 // Stats returns circuit breaker statistics
 func (cb *CircuitBreaker) Stats() CircuitBreakerStats {
-	cb.mu.RLock()
-	defer cb.mu.RUnlock()
-	return CircuitBreakerStats{
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.prune(time.Now())
+	requests, failures := cb.windowCounts()
+	stats := CircuitBreakerStats{
 		State:            cb.state.String(),
 		ConsecutiveFails: cb.consecutiveFails,
 		LastFailure:      cb.lastFailure,
+		Forced:           cb.forced,
+		WindowRequests:   requests,
+		WindowFailures:   failures,
+	}
+	if cb.state == CircuitOpen && !cb.forced {
+		stats.RetryAt = cb.retryAt
 	}
+	return stats
 }
 
 // CircuitBreakerStats contains circuit breaker statistics
@@ -228,6 +411,10 @@ type CircuitBreakerStats struct {
 	State            string    `json:"state"`
 	ConsecutiveFails int       `json:"consecutive_failures"`
 	LastFailure      time.Time `json:"last_failure,omitempty"`
+	Forced           bool      `json:"forced,omitempty"`          // Opened by ForceOpen
+	RetryAt          time.Time `json:"retry_at,omitempty"`        // When an open circuit probes again; zero otherwise
+	WindowRequests   int       `json:"window_requests,omitempty"` // Outcomes in the failure-rate window
+	WindowFailures   int       `json:"window_failures,omitempty"` // Failures among them
 }
 
 // ErrCircuitOpen is returned when the circuit breaker is open
@@ -238,5 +425,8 @@ type ErrCircuitOpen struct {
 }
 
 func (e ErrCircuitOpen) Error() string {
+	if e.RetryAt.IsZero() {
+		return "circuit breaker is open: API calls are suspended until an operator resets the circuit"
+	}
 	return "circuit breaker is open: API is experiencing issues, retry after " + e.RetryAt.Format(time.RFC3339)
 }
@@ -278,6 +278,154 @@ func TestCircuitBreaker_TransitionToHalfOpen(t *testing.T) {
 	}
 }
 
+func TestCircuitBreaker_FailureRate(t *testing.T) {
+	cb := NewCircuitBreakerFromConfig(CircuitBreakerConfig{
+		FailureRate:  0.5,
+		WindowSize:   10,
+		MinRequests:  6,
+		ResetTimeout: time.Minute,
+		HalfOpenMax:  1,
+	})
+
+	// Runs of failures between successes never open a consecutive-count
+	// breaker without a threshold; the rate does once the window is full
+	// enough.
+	cb.RecordFailure()
+	cb.RecordFailure()
+	cb.RecordFailure()
+	if cb.State() != CircuitClosed {
+		t.Fatal("opened below the minimum volume")
+	}
+	cb.RecordSuccess()
+	cb.RecordSuccess()
+	if cb.State() != CircuitClosed {
+		t.Fatal("opened at 3 of 5 below the minimum volume")
+	}
+	cb.RecordSuccess()
+	if cb.State() != CircuitOpen {
+		t.Fatalf("state = %v at 3 failures of 6, want open", cb.State())
+	}
+	if stats := cb.Stats(); stats.RetryAt.IsZero() || stats.WindowRequests != 0 {
+		t.Errorf("stats = %+v, want a retry time and an empty window", stats)
+	}
+
+	// Mostly successful traffic stays closed.
+	cb.Reset()
+	for i := range 30 {
+		if i%3 == 0 {
+			cb.RecordFailure()
+		} else {
+			cb.RecordSuccess()
+		}
+	}
+	if cb.State() != CircuitClosed {
+		t.Errorf("state = %v at a 1-in-3 failure rate, want closed", cb.State())
+	}
+	if stats := cb.Stats(); stats.WindowRequests != 10 {
+		t.Errorf("window holds %d outcomes, want the last 10", stats.WindowRequests)
+	}
+}
+
+func TestCircuitBreaker_WindowDuration(t *testing.T) {
+	cb := NewCircuitBreakerFromConfig(CircuitBreakerConfig{
+		FailureRate:    0.5,
+		WindowDuration: 50 * time.Millisecond,
+		MinRequests:    2,
+		ResetTimeout:   time.Minute,
+		HalfOpenMax:    1,
+	})
+
+	cb.RecordFailure()
+	time.Sleep(60 * time.Millisecond)
+	cb.RecordSuccess()
+	if got := cb.Stats().WindowRequests; got != 1 {
+		t.Errorf("window holds %d outcomes, want the failure aged out", got)
+	}
+	cb.RecordFailure()
+	if cb.State() != CircuitOpen {
+		t.Errorf("state = %v at 1 failure of 2 within the window, want open", cb.State())
+	}
+}
+
+func TestCircuitBreaker_ResetBackoff(t *testing.T) {
+	cb := NewCircuitBreakerFromConfig(CircuitBreakerConfig{
+		FailureThreshold: 1,
+		ResetTimeout:     10 * time.Millisecond,
+		MaxResetTimeout:  30 * time.Millisecond,
+		HalfOpenMax:      1,
+	})
+
+	waits := make([]time.Duration, 0, 4)
+	cb.RecordFailure()
+	for range 4 {
+		wait := time.Until(cb.Stats().RetryAt)
+		waits = append(waits, wait)
+		time.Sleep(wait + 5*time.Millisecond)
+		if !cb.Allow() {
+			t.Fatal("no probe after the reset timeout")
+		}
+		cb.RecordFailure() // The probe fails
+	}
+	if waits[1] < 15*time.Millisecond || waits[2] < 25*time.Millisecond || waits[3] > 30*time.Millisecond {
+		t.Errorf("waits = %v, want about 10ms, 20ms, 30ms, 30ms", waits)
+	}
+
+	// A successful probe closes the circuit and starts over.
+	time.Sleep(time.Until(cb.Stats().RetryAt) + 5*time.Millisecond)
+	cb.Allow()
+	cb.RecordSuccess()
+	cb.RecordFailure()
+	if wait := time.Until(cb.Stats().RetryAt); wait > 10*time.Millisecond {
+		t.Errorf("wait after recovery = %v, want the base 10ms", wait)
+	}
+}
+
+func TestCircuitBreaker_RecordIgnored(t *testing.T) {
+	cb := NewCircuitBreakerWithConfig(1, 10*time.Millisecond, 1)
+	cb.RecordFailure()
+	time.Sleep(15 * time.Millisecond)
+
+	if !cb.Allow() { // Moves to half-open
+		t.Fatal("no probe after the reset timeout")
+	}
+	if !cb.Allow() {
+		t.Fatal("first counted probe refused")
+	}
+	if cb.Allow() {
+		t.Fatal("probe beyond halfOpenMax allowed")
+	}
+	cb.RecordIgnored() // A cancelled probe hands its slot back
+	if !cb.Allow() {
+		t.Error("slot of an ignored probe was not handed back")
+	}
+	if got := cb.Stats().ConsecutiveFails; got != 1 {
+		t.Errorf("consecutive failures = %d, want the ignored call uncounted", got)
+	}
+}
+
+func TestCircuitBreaker_ForceOpenAndReset(t *testing.T) {
+	cb := NewCircuitBreakerWithConfig(2, 10*time.Millisecond, 1)
+
+	cb.ForceOpen()
+	time.Sleep(20 * time.Millisecond)
+	// A forced circuit does not go half-open after the reset timeout.
+	if cb.Allow() {
+		t.Error("forced-open circuit allowed a request")
+	}
+	if stats := cb.Stats(); stats.State != "open" || !stats.Forced {
+		t.Errorf("Stats = %+v, want forced open", stats)
+	}
+
+	cb.RecordFailure()
+	cb.Reset()
+	if !cb.Allow() || cb.State() != CircuitClosed {
+		t.Errorf("circuit is %v after Reset, want closed", cb.State())
+	}
+	if stats := cb.Stats(); stats.Forced || stats.ConsecutiveFails != 0 {
+		t.Errorf("Stats after Reset = %+v, want no failures and not forced", stats)
+	}
+}
+
 func TestCircuitBreaker_HalfOpenToClose(t *testing.T) {
 	cb := NewCircuitBreakerWithConfig(2, 10*time.Millisecond, 1)
 
@@ -0,0 +1,150 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+)
+
+// CacheMode says how Fetch may use the cache for one call.
+type CacheMode string
+
+const (
+	// CacheDefault answers from a fresh entry, revalidates a recent one in
+	// the background and asks the registry otherwise.
+	CacheDefault CacheMode = "default"
+	// CacheNoCache always asks the registry and stores its answer; a stale
+	// entry is never served in its place.
+	CacheNoCache CacheMode = "no-cache"
+	// CacheOnlyIfCached answers from a fresh entry and never asks the
+	// registry; without one Fetch returns ErrNotCached.
+	CacheOnlyIfCached CacheMode = "only-if-cached"
+)
+
+// ErrNotCached is returned by Fetch under CacheOnlyIfCached when no fresh
+// answer is cached.
+var ErrNotCached = errors.New("no fresh answer in the cache")
+
+// ParseCacheMode parses a cache mode from a tool argument. The empty string
+// is CacheDefault.
+func ParseCacheMode(s string) (CacheMode, error) {
+	switch mode := CacheMode(s); mode {
+	case "":
+		return CacheDefault, nil
+	case CacheDefault, CacheNoCache, CacheOnlyIfCached:
+		return mode, nil
+	}
+	return "", apierrors.NewValidationError("cache", s, "must be default, no-cache or only-if-cached")
+}
+
+type cacheModeKey struct{}
+
+// WithCacheMode returns a context under which Fetch uses the cache as mode
+// says.
+func WithCacheMode(ctx context.Context, mode CacheMode) context.Context {
+	return context.WithValue(ctx, cacheModeKey{}, mode)
+}
+
+// cacheModeFrom returns the cache mode carried by ctx, CacheDefault if none.
+func cacheModeFrom(ctx context.Context) CacheMode {
+	if mode, ok := ctx.Value(cacheModeKey{}).(CacheMode); ok {
+		return mode
+	}
+	return CacheDefault
+}
+
+// CacheControl is embedded in the args of tools whose results carry
+// Freshness, letting the caller decide how the cache is used.
+type CacheControl struct {
+	Cache string `json:"cache,omitempty" jsonschema:"How to use the response cache: default; no-cache to ask the registry even when a cached answer exists, for decisions that need current data; or only-if-cached to answer from the cache without contacting the registry, failing when nothing fresh is cached"`
+}
+
+// CacheMode returns the parsed cache argument.
+func (c CacheControl) CacheMode() (CacheMode, error) {
+	return ParseCacheMode(c.Cache)
+}
+
+// Provenance records where the answers behind a call came from: when the
+// oldest of them was fetched from the registry, and whether any came from
+// the cache or from a stale entry.
+type Provenance struct {
+	mu        sync.Mutex
+	recorded  bool
+	cacheHit  bool
+	stale     bool
+	fetchedAt time.Time // Of the oldest answer
+}
+
+type provenanceKey struct{}
+
+// TrackProvenance returns a context under which Fetch records every answer
+// in the returned Provenance and may fall back on a stale entry when the
+// registry fails. The tool layer installs one for results that carry
+// Freshness; everywhere else Fetch returns the error.
+func TrackProvenance(ctx context.Context) (context.Context, *Provenance) {
+	p := &Provenance{}
+	return context.WithValue(ctx, provenanceKey{}, p), p
+}
+
+// Freshness returns what the Provenance recorded, or false when no answer
+// went through Fetch.
+func (p *Provenance) Freshness() (Freshness, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.recorded {
+		return Freshness{}, false
+	}
+	f := Freshness{
+		FetchedAt: p.fetchedAt.UTC().Format(time.RFC3339),
+		CacheHit:  p.cacheHit,
+		Stale:     p.stale,
+	}
+	if p.stale {
+		f.AgeSeconds = int64(time.Since(p.fetchedAt).Seconds())
+	}
+	return f, true
+}
+
+// record adds an answer fetched at fetchedAt to the Provenance carried by
+// ctx. It returns false when ctx carries none, i.e. the caller does not
+// accept stale answers.
+func record(ctx context.Context, fetchedAt time.Time, cacheHit, stale bool) bool {
+	p, ok := ctx.Value(provenanceKey{}).(*Provenance)
+	if !ok {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.recorded || fetchedAt.Before(p.fetchedAt) {
+		p.fetchedAt = fetchedAt
+	}
+	p.recorded = true
+	p.cacheHit = p.cacheHit || cacheHit
+	p.stale = p.stale || stale
+	return true
+}
+
+// isNegative reports whether err is the registry's answer rather than a
+// failure to get one: a not-found or validation error. Fetch caches these
+// and never serves a stale value in their place.
+func isNegative(err error) bool {
+	return apierrors.IsNotFound(err) || apierrors.IsValidation(err)
+}
+
+// Freshness says when the data in a result was fetched from the registry and
+// whether it came from the cache. Result types embed it; the tool layer
+// fills it in from the call's Provenance.
+type Freshness struct {
+	FetchedAt  string `json:"fetched_at,omitempty" jsonschema:"When the registry data in this result was fetched, RFC 3339; the oldest fetch when the result combines several"`
+	CacheHit   bool   `json:"cache_hit" jsonschema:"True when any of the data was answered from the response cache rather than fetched for this call"`
+	Stale      bool   `json:"stale,omitempty" jsonschema:"True when this is an earlier answer from the cache: past its TTL while a refresh runs in the background, or because the registry could not be reached"`
+	AgeSeconds int64  `json:"age_seconds,omitempty" jsonschema:"For stale answers, seconds since the data was fetched"`
+}
+
+// SetFreshness replaces the result's Freshness with f.
+func (f *Freshness) SetFreshness(v Freshness) {
+	*f = v
+}
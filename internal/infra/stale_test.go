@@ -0,0 +1,397 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+)
+
+// setFetchedAgo stores v as if it had been fetched age ago with the given TTL
+// and stale TTL.
+func setFetchedAgo(c *Cache, key string, v *cachedCompany, age, ttl, staleTTL time.Duration) time.Time {
+	fetchedAt := time.Now().Add(-age)
+	c.setMemory(key, v, fetchedAt, fetchedAt.Add(ttl), fetchedAt.Add(ttl+staleTTL), Validators{})
+	return fetchedAt
+}
+
+func TestFetch_MissAndHit(t *testing.T) {
+	c := NewCache(100)
+	defer c.Close()
+
+	calls := 0
+	fetch := func(context.Context) (*cachedCompany, error) {
+		calls++
+		return &cachedCompany{Name: "EQUINOR ASA"}, nil
+	}
+	for range 2 {
+		got, err := Fetch(context.Background(), c, "company:1", time.Hour, fetch)
+		if err != nil || got.Name != "EQUINOR ASA" {
+			t.Fatalf("Fetch = %+v, %v; want the fetched company", got, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("fetch called %d times, want 1", calls)
+	}
+
+	// The entry is kept past its TTL for DefaultStaleTTL.
+	entry, _ := c.entries.Load("company:1")
+	ce := entry.(*CacheEntry)
+	if got := ce.StaleUntil.Sub(ce.ExpiresAt); got != DefaultStaleTTL {
+		t.Errorf("stale TTL = %v, want %v", got, DefaultStaleTTL)
+	}
+}
+
+func TestFetch_StaleWhileRevalidate(t *testing.T) {
+	c := NewCache(100)
+	defer c.Close()
+
+	// Six minutes old with a five-minute TTL: within one more TTL.
+	fetchedAt := setFetchedAgo(c, "company:1", &cachedCompany{Name: "OLD"}, 6*time.Minute, 5*time.Minute, time.Hour)
+
+	ctx, prov := TrackProvenance(context.Background())
+	refreshed := make(chan struct{})
+	got, err := Fetch(ctx, c, "company:1", 5*time.Minute, func(context.Context) (*cachedCompany, error) {
+		defer close(refreshed)
+		return &cachedCompany{Name: "NEW"}, nil
+	})
+	if err != nil || got.Name != "OLD" {
+		t.Fatalf("Fetch = %+v, %v; want the cached company at once", got, err)
+	}
+	f, ok := prov.Freshness()
+	if !ok || !f.CacheHit || !f.Stale || f.FetchedAt != fetchedAt.UTC().Format(time.RFC3339) {
+		t.Errorf("Provenance.Freshness = %+v, %v; want a stale cache hit as of %v", f, ok, fetchedAt)
+	}
+	if f.AgeSeconds < 360 || f.AgeSeconds > 370 {
+		t.Errorf("AgeSeconds = %d, want about six minutes", f.AgeSeconds)
+	}
+
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("no background refresh")
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		if v, ok := Lookup[cachedCompany](c, "company:1"); ok && v.Name == "NEW" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("background refresh did not update the cache")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestFetch_StaleWhileRevalidateNeedsProvenance(t *testing.T) {
+	c := NewCache(100)
+	defer c.Close()
+
+	setFetchedAgo(c, "company:1", &cachedCompany{Name: "OLD"}, 6*time.Minute, 5*time.Minute, time.Hour)
+	got, err := Fetch(context.Background(), c, "company:1", 5*time.Minute, func(context.Context) (*cachedCompany, error) {
+		return &cachedCompany{Name: "NEW"}, nil
+	})
+	if err != nil || got.Name != "NEW" {
+		t.Fatalf("Fetch = %+v, %v; want the company fetched for a caller that cannot tell a stale answer", got, err)
+	}
+}
+
+func TestFetch_ServeStaleOnError(t *testing.T) {
+	c := NewCache(100)
+	defer c.Close()
+
+	// Twenty minutes old with a five-minute TTL: past the revalidate window
+	// but within the stale TTL.
+	fetchedAt := setFetchedAgo(c, "company:1", &cachedCompany{Name: "EQUINOR ASA"}, 20*time.Minute, 5*time.Minute, time.Hour)
+	circuitOpen := func(context.Context) (*cachedCompany, error) {
+		return nil, &ErrCircuitOpen{State: CircuitOpen.String()}
+	}
+
+	// Without TrackProvenance the error is returned.
+	if _, err := Fetch(context.Background(), c, "company:1", 5*time.Minute, circuitOpen); err == nil {
+		t.Fatal("Fetch served a stale entry to a caller that does not accept one")
+	}
+
+	ctx, prov := TrackProvenance(context.Background())
+	if _, ok := prov.Freshness(); ok {
+		t.Fatal("new Provenance reports an answer")
+	}
+	got, err := Fetch(ctx, c, "company:1", 5*time.Minute, circuitOpen)
+	if err != nil || got.Name != "EQUINOR ASA" {
+		t.Fatalf("Fetch = %+v, %v; want the stale company", got, err)
+	}
+	want := Freshness{FetchedAt: fetchedAt.UTC().Format(time.RFC3339), CacheHit: true, Stale: true}
+	f, ok := prov.Freshness()
+	if age := f.AgeSeconds; age < 1200 || age > 1210 {
+		t.Errorf("AgeSeconds = %d, want about twenty minutes", age)
+	}
+	f.AgeSeconds = 0
+	if !ok || f != want {
+		t.Errorf("Provenance.Freshness = %+v, %v; want %+v", f, ok, want)
+	}
+
+	// A not-found answer is not covered up.
+	notFound := func(context.Context) (*cachedCompany, error) {
+		return nil, apierrors.NewNotFoundError("norway", "1")
+	}
+	if _, err := Fetch(ctx, c, "company:1", 5*time.Minute, notFound); !apierrors.IsNotFound(err) {
+		t.Errorf("Fetch with a not-found error = %v, want it returned", err)
+	}
+}
+
+func TestFetch_PastStaleTTL(t *testing.T) {
+	c := NewCache(100)
+	defer c.Close()
+
+	setFetchedAgo(c, "company:1", &cachedCompany{Name: "EQUINOR ASA"}, 2*time.Hour, 5*time.Minute, time.Hour)
+	ctx, prov := TrackProvenance(context.Background())
+	upstreamErr := errors.New("server error 503")
+	_, err := Fetch(ctx, c, "company:1", 5*time.Minute, func(context.Context) (*cachedCompany, error) {
+		return nil, upstreamErr
+	})
+	if !errors.Is(err, upstreamErr) {
+		t.Errorf("Fetch = %v, want the upstream error", err)
+	}
+	if _, ok := prov.Freshness(); ok {
+		t.Error("Provenance reports an answer that was not served")
+	}
+	if c.Size() != 0 {
+		t.Errorf("entry past its stale TTL was kept (size %d)", c.Size())
+	}
+}
+
+func TestFetch_StaleFromDisk(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewDiskStore(dir, 0)
+	if err != nil {
+		t.Fatalf("NewDiskStore: %v", err)
+	}
+	fetchedAt := time.Now().Add(-20 * time.Minute).Round(0)
+	entry := StoredEntry{
+		Data:       []byte(`{"name":"EQUINOR ASA"}`),
+		FetchedAt:  fetchedAt,
+		ExpiresAt:  fetchedAt.Add(5 * time.Minute),
+		StaleUntil: fetchedAt.Add(time.Hour),
+	}
+	if err := store.Set("company:1", entry); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	c := NewTieredCache(100, store)
+	defer c.Close()
+
+	if _, ok := Lookup[cachedCompany](c, "company:1"); ok {
+		t.Error("Lookup returned an entry past its TTL")
+	}
+	ctx, prov := TrackProvenance(context.Background())
+	got, err := Fetch(ctx, c, "company:1", 5*time.Minute, func(context.Context) (*cachedCompany, error) {
+		return nil, errors.New("request failed: connection refused")
+	})
+	if err != nil || got.Name != "EQUINOR ASA" {
+		t.Fatalf("Fetch = %+v, %v; want the stale company from disk", got, err)
+	}
+	if f, _ := prov.Freshness(); !f.Stale || f.FetchedAt != fetchedAt.UTC().Format(time.RFC3339) {
+		t.Errorf("Provenance.Freshness = %+v, want stale as of %v", f, fetchedAt)
+	}
+}
+
+func TestProvenance_Freshness(t *testing.T) {
+	ctx, prov := TrackProvenance(context.Background())
+	cest := time.FixedZone("CEST", 2*3600)
+	record(ctx, time.Date(2026, 10, 18, 9, 30, 0, 0, cest), false, false)
+	record(ctx, time.Date(2026, 10, 18, 9, 0, 0, 0, cest), true, false)
+	record(ctx, time.Date(2026, 10, 18, 9, 45, 0, 0, cest), false, false)
+
+	want := Freshness{FetchedAt: "2026-10-18T07:00:00Z", CacheHit: true}
+	if f, ok := prov.Freshness(); !ok || f != want {
+		t.Errorf("Freshness = %+v, %v; want the oldest fetch in UTC and a cache hit", f, ok)
+	}
+	if record(context.Background(), time.Now(), false, false) {
+		t.Error("record succeeded without a Provenance")
+	}
+}
+
+func TestFetch_CacheModes(t *testing.T) {
+	c := NewCache(100)
+	defer c.Close()
+
+	calls := 0
+	fetch := func(context.Context) (*cachedCompany, error) {
+		calls++
+		return &cachedCompany{Name: "EQUINOR ASA"}, nil
+	}
+
+	// only-if-cached never asks the registry.
+	onlyCached := WithCacheMode(context.Background(), CacheOnlyIfCached)
+	if _, err := Fetch(onlyCached, c, "company:1", time.Hour, fetch); !errors.Is(err, ErrNotCached) {
+		t.Fatalf("only-if-cached miss = %v, want ErrNotCached", err)
+	}
+	if calls != 0 {
+		t.Fatalf("only-if-cached called fetch %d times", calls)
+	}
+
+	ctx, prov := TrackProvenance(context.Background())
+	if _, err := Fetch(ctx, c, "company:1", time.Hour, fetch); err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if f, _ := prov.Freshness(); f.CacheHit || f.FetchedAt == "" {
+		t.Errorf("fetched answer recorded as %+v, want a fetch time and no cache hit", f)
+	}
+
+	ctx, prov = TrackProvenance(onlyCached)
+	if _, err := Fetch(ctx, c, "company:1", time.Hour, fetch); err != nil || calls != 1 {
+		t.Fatalf("only-if-cached hit = %v after %d fetches, want the cached company", err, calls)
+	}
+	if f, _ := prov.Freshness(); !f.CacheHit {
+		t.Errorf("cached answer recorded as %+v, want a cache hit", f)
+	}
+
+	// no-cache asks the registry despite a fresh entry and a cached
+	// not-found, and stores the answer.
+	c.setNegative("company:2", apierrors.NewNotFoundError("norway", "2"), time.Hour)
+	noCache := WithCacheMode(context.Background(), CacheNoCache)
+	for _, key := range []string{"company:1", "company:2"} {
+		ctx, prov = TrackProvenance(noCache)
+		if _, err := Fetch(ctx, c, key, time.Hour, fetch); err != nil {
+			t.Fatalf("no-cache %s: %v", key, err)
+		}
+		if f, _ := prov.Freshness(); f.CacheHit {
+			t.Errorf("no-cache %s recorded a cache hit", key)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("fetch called %d times, want 3", calls)
+	}
+	if _, ok := Lookup[cachedCompany](c, "company:2"); !ok {
+		t.Error("no-cache answer was not stored")
+	}
+}
+
+func TestFetch_NoCacheDoesNotServeStale(t *testing.T) {
+	c := NewCache(100)
+	defer c.Close()
+
+	setFetchedAgo(c, "company:1", &cachedCompany{Name: "EQUINOR ASA"}, 20*time.Minute, 5*time.Minute, time.Hour)
+	ctx, _ := TrackProvenance(WithCacheMode(context.Background(), CacheNoCache))
+	_, err := Fetch(ctx, c, "company:1", 5*time.Minute, func(context.Context) (*cachedCompany, error) {
+		return nil, &ErrCircuitOpen{State: CircuitOpen.String()}
+	})
+	if err == nil {
+		t.Error("no-cache call was answered from a stale entry")
+	}
+}
+
+func TestParseCacheMode(t *testing.T) {
+	for in, want := range map[string]CacheMode{"": CacheDefault, "default": CacheDefault, "no-cache": CacheNoCache, "only-if-cached": CacheOnlyIfCached} {
+		if got, err := ParseCacheMode(in); err != nil || got != want {
+			t.Errorf("ParseCacheMode(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseCacheMode("no-store"); !apierrors.IsValidation(err) {
+		t.Errorf("ParseCacheMode(no-store) = %v, want a validation error", err)
+	}
+}
+
+func TestFetch_NegativeCache(t *testing.T) {
+	c := NewCache(100)
+	defer c.Close()
+
+	calls := 0
+	notFound := func(context.Context) (*cachedCompany, error) {
+		calls++
+		return nil, apierrors.NewNotFoundError("denmark", "12345678")
+	}
+	for range 3 {
+		if _, err := Fetch(context.Background(), c, "company:12345678", time.Hour, notFound); !apierrors.IsNotFound(err) {
+			t.Fatalf("Fetch = %v, want not found", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("fetch called %d times, want 1", calls)
+	}
+	if got := c.NegativeStats(); got != (NegativeCacheStats{Entries: 1, Hits: 2, Stores: 1}) {
+		t.Errorf("NegativeStats = %+v, want 1 entry, 2 hits, 1 store", got)
+	}
+
+	// Upstream failures are not remembered.
+	upstream := func(context.Context) (*cachedCompany, error) {
+		calls++
+		return nil, errors.New("server error 503")
+	}
+	for range 2 {
+		_, _ = Fetch(context.Background(), c, "company:87654321", time.Hour, upstream)
+	}
+	if calls != 3 {
+		t.Errorf("fetch called %d times after upstream failures, want 3", calls)
+	}
+
+	// Deleting the key, as the update feed does, forgets the outcome.
+	c.Delete("company:12345678")
+	if _, err := Fetch(context.Background(), c, "company:12345678", time.Hour, notFound); !apierrors.IsNotFound(err) {
+		t.Fatalf("Fetch = %v, want not found", err)
+	}
+	if calls != 4 {
+		t.Errorf("fetch not called again after Delete (%d calls)", calls)
+	}
+
+	c.DeletePrefix("company:")
+	if got := c.NegativeStats().Entries; got != 0 {
+		t.Errorf("DeletePrefix left %d negative entries", got)
+	}
+}
+
+func TestFetch_NegativeReplacesValue(t *testing.T) {
+	c := NewCache(100)
+	defer c.Close()
+
+	setFetchedAgo(c, "company:1", &cachedCompany{Name: "DISSOLVED AS"}, 20*time.Minute, 5*time.Minute, time.Hour)
+	ctx, _ := TrackProvenance(context.Background())
+	_, err := Fetch(ctx, c, "company:1", 5*time.Minute, func(context.Context) (*cachedCompany, error) {
+		return nil, apierrors.NewNotFoundError("norway", "1")
+	})
+	if !apierrors.IsNotFound(err) {
+		t.Fatalf("Fetch = %v, want not found", err)
+	}
+	if c.Size() != 0 {
+		t.Error("value the registry no longer knows was kept")
+	}
+
+	// A value stored later replaces the outcome.
+	c.Set("company:1", &cachedCompany{Name: "REREGISTERED AS"}, time.Hour)
+	got, err := Fetch(ctx, c, "company:1", time.Hour, func(context.Context) (*cachedCompany, error) {
+		t.Error("fetch called with a fresh value cached")
+		return nil, nil
+	})
+	if err != nil || got.Name != "REREGISTERED AS" {
+		t.Errorf("Fetch = %+v, %v; want the stored value", got, err)
+	}
+	if n := c.NegativeStats().Entries; n != 0 {
+		t.Errorf("Set left %d negative entries", n)
+	}
+}
+
+func TestCache_NegativeExpiryAndBound(t *testing.T) {
+	c := NewCache(2)
+	defer c.Close()
+
+	invalid := apierrors.NewValidationError("cvr", "1", "must be 8 digits")
+	c.setNegative("a", invalid, time.Hour)
+	c.setNegative("b", invalid, -time.Second)
+	c.setNegative("c", invalid, time.Hour) // Over the bound: not remembered
+	if _, ok := c.negative("c"); ok {
+		t.Error("negative cache grew past its bound")
+	}
+	if _, ok := c.negative("b"); ok {
+		t.Error("expired negative entry was returned")
+	}
+
+	c.setNegative("d", invalid, -time.Second)
+	c.cleanup()
+	if got := c.NegativeStats().Entries; got != 1 {
+		t.Errorf("negative entries after cleanup = %d, want 1", got)
+	}
+	if err, ok := c.negative("a"); !ok || !apierrors.IsValidation(err) {
+		t.Errorf("negative(a) = %v, %v; want the validation error", err, ok)
+	}
+}
@@ -0,0 +1,174 @@
+//go:build ignore
+
+// gen_elf fills the elf column of data/legal_forms.csv from the GLEIF ISO
+// 20275 Entity Legal Form code list. Download the CSV edition of the list
+// from gleif.org and run, from this directory:
+//
+//	go run gen_elf.go -elf elf-code-list.csv
+//
+// A form matches an active ELF entry of its country when the local name or
+// one of the local abbreviations equals the form's code, name or an alias,
+// compared the way Lookup compares them. Forms without a match keep their
+// current code and are listed on stderr.
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+const table = "data/legal_forms.csv"
+
+var countryCodes = map[string]string{"norway": "NO", "denmark": "DK", "finland": "FI", "sweden": "SE"}
+
+func main() {
+	elfPath := flag.String("elf", "", "GLEIF ELF code list, CSV edition")
+	flag.Parse()
+	if *elfPath == "" {
+		log.Fatal("gen_elf: -elf is required")
+	}
+
+	candidates, err := readELF(*elfPath)
+	if err != nil {
+		log.Fatalf("gen_elf: %v", err)
+	}
+	rows, err := readCSV(table)
+	if err != nil {
+		log.Fatalf("gen_elf: %v", err)
+	}
+
+	missing := 0
+	for _, row := range rows[1:] {
+		spellings := append([]string{row[1], row[2]}, strings.Split(row[7], "|")...)
+		code := match(candidates[countryCodes[row[0]]], spellings)
+		if code == "" {
+			fmt.Fprintf(os.Stderr, "no ELF code for %s %s (%s)\n", row[0], row[1], row[2])
+			missing++
+			continue
+		}
+		row[4] = code
+	}
+
+	f, err := os.Create(table)
+	if err != nil {
+		log.Fatalf("gen_elf: %v", err)
+	}
+	w := csv.NewWriter(f)
+	if err := w.WriteAll(rows); err != nil {
+		log.Fatalf("gen_elf: writing %s: %v", table, err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatalf("gen_elf: %v", err)
+	}
+	if missing > 0 {
+		os.Exit(1)
+	}
+}
+
+// elfEntry is one language row of an active ELF code.
+type elfEntry struct {
+	code     string
+	spelling []string // normalized local name and abbreviations
+}
+
+// readELF returns the active entries of the four Nordic countries keyed by
+// ISO 3166-1 country code. Columns are found by header so the reader
+// survives the list's occasional column additions.
+func readELF(path string) (map[string][]elfEntry, error) {
+	rows, err := readCSV(path)
+	if err != nil {
+		return nil, err
+	}
+	col := func(prefix string) (int, error) {
+		for i, h := range rows[0] {
+			if strings.HasPrefix(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")), prefix) {
+				return i, nil
+			}
+		}
+		return 0, fmt.Errorf("%s: no %q column", path, prefix)
+	}
+	var idx [5]int
+	for i, prefix := range []string{"ELF Code", "Country Code", "Entity Legal Form name Local name", "Abbreviations Local language", "ELF Status"} {
+		if idx[i], err = col(prefix); err != nil {
+			return nil, err
+		}
+	}
+
+	out := make(map[string][]elfEntry)
+	for _, row := range rows[1:] {
+		country := row[idx[1]]
+		if row[idx[4]] != "ACTV" || !isNordic(country) {
+			continue
+		}
+		e := elfEntry{code: row[idx[0]], spelling: []string{normalize(row[idx[2]])}}
+		for _, abbr := range strings.Split(row[idx[3]], ";") {
+			if abbr = normalize(abbr); abbr != "" {
+				e.spelling = append(e.spelling, abbr)
+			}
+		}
+		out[country] = append(out[country], e)
+	}
+	return out, nil
+}
+
+func isNordic(country string) bool {
+	for _, c := range countryCodes {
+		if c == country {
+			return true
+		}
+	}
+	return false
+}
+
+// match returns the ELF code of the first entry sharing a spelling with the
+// form, or "" when none does.
+func match(entries []elfEntry, spellings []string) string {
+	for _, e := range entries {
+		for _, s := range spellings {
+			s = normalize(s)
+			if s == "" {
+				continue
+			}
+			for _, t := range e.spelling {
+				if s == t {
+					return e.code
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func readCSV(path string) ([][]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%s: empty", path)
+	}
+	return rows, nil
+}
+
+// normalize mirrors legalform.normalize.
+func normalize(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '.', ' ', '-', '(', ')':
+			return -1
+		}
+		return r
+	}, s)
+}
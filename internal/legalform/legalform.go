@@ -0,0 +1,173 @@
+// Package legalform classifies the legal forms used by the Nordic company
+// registries so companies can be compared and filtered across countries.
+//
+// Each registry has its own codes: Brønnøysundregistrene organisasjonsform
+// (AS, ENK), CVR companydesc (Aktieselskab, ApS), PRH companyForm (numeric
+// type with a description) and Bolagsverket organisationsform/juridisk form
+// (AB, 49). The embedded table maps them to an abstract category, the
+// liability of the owners and, where known, the ISO 20275 Entity Legal Form
+// (ELF) code GLEIF uses in LEI records. The elf column is filled from the
+// GLEIF ELF code list by gen_elf.go; rerun it when GLEIF publishes a new
+// version of the list.
+package legalform
+
+import (
+	"embed"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Categories reported in Form.Category.
+const (
+	CategoryLimited         = "limited"           // Private limited company
+	CategoryPublicLimited   = "public_limited"    // Public limited company, may offer shares to the public
+	CategoryPartnership     = "partnership"       // General, limited and shared-liability partnerships
+	CategorySoleTrader      = "sole_trader"       // Business run by one natural person
+	CategoryCooperative     = "cooperative"       // Cooperatives and housing cooperatives
+	CategoryAssociation     = "association"       // Non-profit and voluntary associations
+	CategoryFoundation      = "foundation"        // Foundations, no owners
+	CategoryBranchOfForeign = "branch_of_foreign" // Local registration of a foreign company
+)
+
+// Liability types reported in Form.Liability: how far the owners answer for
+// the entity's debts.
+const (
+	LiabilityLimited      = "limited"      // Owners risk only their contribution
+	LiabilityUnlimited    = "unlimited"    // Owners answer jointly and without limit
+	LiabilityMixed        = "mixed"        // General partners unlimited, limited partners limited
+	LiabilityProportional = "proportional" // Each partner answers for a fixed share (Norwegian DA)
+	LiabilityNone         = "none"         // No owners (foundations)
+	LiabilityParent       = "parent"       // Follows the foreign company the branch belongs to
+)
+
+// Countries covered by the table.
+var Countries = []string{"norway", "denmark", "finland", "sweden"}
+
+//go:embed data/legal_forms.csv
+var dataFS embed.FS
+
+// Form is one national legal form.
+type Form struct {
+	Country   string `json:"country" jsonschema:"Registry country: norway, denmark, finland or sweden"`
+	Code      string `json:"code" jsonschema:"Registry abbreviation of the legal form, e.g. AS, ApS, OY, AB"`
+	Name      string `json:"name" jsonschema:"Name of the legal form in the national language"`
+	NameEN    string `json:"name_en" jsonschema:"English name of the legal form"`
+	ELF       string `json:"elf,omitempty" jsonschema:"ISO 20275 Entity Legal Form code, when known"`
+	Category  string `json:"category" jsonschema:"Abstract category: limited, public_limited, partnership, sole_trader, cooperative, association, foundation or branch_of_foreign"`
+	Liability string `json:"liability" jsonschema:"How far the owners answer for the debts: limited, unlimited, mixed, proportional, none or parent"`
+
+	aliases []string
+}
+
+var (
+	forms   = mustLoad("data/legal_forms.csv")
+	byToken = index(forms)
+)
+
+func mustLoad(name string) []Form {
+	f, err := dataFS.Open(name)
+	if err != nil {
+		panic(fmt.Sprintf("legalform: open %s: %v", name, err))
+	}
+	defer func() { _ = f.Close() }()
+
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("legalform: parse %s: %v", name, err))
+	}
+	out := make([]Form, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		form := Form{
+			Country:   row[0],
+			Code:      row[1],
+			Name:      row[2],
+			NameEN:    row[3],
+			ELF:       row[4],
+			Category:  row[5],
+			Liability: row[6],
+		}
+		if row[7] != "" {
+			form.aliases = strings.Split(row[7], "|")
+		}
+		out = append(out, form)
+	}
+	return out
+}
+
+// index keys every form by country and each spelling a registry may send:
+// the code, the national name, the English name and the aliases.
+func index(forms []Form) map[string]int {
+	m := make(map[string]int)
+	for i, f := range forms {
+		spellings := append([]string{f.Code, f.Name, f.NameEN}, f.aliases...)
+		for _, s := range spellings {
+			k := f.Country + ":" + normalize(s)
+			if _, dup := m[k]; !dup {
+				m[k] = i
+			}
+		}
+	}
+	return m
+}
+
+// normalize folds case and drops the separators registries use
+// inconsistently, so "A/S", "a.s" and "AS" compare equal.
+func normalize(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '.', ' ', '-', '(', ')':
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// Lookup returns the form a registry reports for a company. Each candidate
+// is tried in turn, so callers can pass a code followed by its description
+// (PRH) or the organisationsform followed by the juridisk form (Bolagsverket).
+// It returns nil when no candidate is in the table.
+func Lookup(country string, candidates ...string) *Form {
+	for _, c := range candidates {
+		if strings.TrimSpace(c) == "" {
+			continue
+		}
+		if i, ok := byToken[country+":"+normalize(c)]; ok {
+			f := forms[i]
+			return &f
+		}
+	}
+	return nil
+}
+
+// List returns the forms of a country and category in table order; empty
+// arguments match everything.
+func List(country, category string) []Form {
+	var out []Form
+	for _, f := range forms {
+		if country != "" && f.Country != country {
+			continue
+		}
+		if category != "" && f.Category != category {
+			continue
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+// Categories returns the category identifiers in sorted order.
+func Categories() []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, f := range forms {
+		if !seen[f.Category] {
+			seen[f.Category] = true
+			out = append(out, f.Category)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
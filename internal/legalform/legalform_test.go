@@ -0,0 +1,107 @@
+package legalform
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestTableValid(t *testing.T) {
+	categories := map[string]bool{
+		CategoryLimited: true, CategoryPublicLimited: true, CategoryPartnership: true, CategorySoleTrader: true,
+		CategoryCooperative: true, CategoryAssociation: true, CategoryFoundation: true, CategoryBranchOfForeign: true,
+	}
+	liabilities := map[string]bool{
+		LiabilityLimited: true, LiabilityUnlimited: true, LiabilityMixed: true,
+		LiabilityProportional: true, LiabilityNone: true, LiabilityParent: true,
+	}
+	elf := regexp.MustCompile(`^[A-Z0-9]{4}$`)
+
+	seen := make(map[string]bool)
+	for _, f := range forms {
+		key := f.Country + ":" + f.Code
+		if seen[key] {
+			t.Errorf("duplicate form %s", key)
+		}
+		seen[key] = true
+		if !categories[f.Category] {
+			t.Errorf("%s: unknown category %q", key, f.Category)
+		}
+		if !liabilities[f.Liability] {
+			t.Errorf("%s: unknown liability %q", key, f.Liability)
+		}
+		if f.ELF != "" && !elf.MatchString(f.ELF) {
+			t.Errorf("%s: malformed ELF code %q", key, f.ELF)
+		}
+		if f.Name == "" || f.NameEN == "" {
+			t.Errorf("%s: missing name", key)
+		}
+	}
+	for _, country := range Countries {
+		if len(List(country, "")) == 0 {
+			t.Errorf("no forms for %s", country)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name       string
+		country    string
+		candidates []string
+		wantCode   string
+		wantCat    string
+	}{
+		{"brreg code", "norway", []string{"ASA"}, "ASA", CategoryPublicLimited},
+		{"brreg lowercase", "norway", []string{"enk"}, "ENK", CategorySoleTrader},
+		{"cvr companydesc", "denmark", []string{"Anpartsselskab"}, "ApS", CategoryLimited},
+		{"cvr abbreviation", "denmark", []string{"a/s"}, "A/S", CategoryPublicLimited},
+		{"cvr branch variant", "denmark", []string{"Filial af udenlandsk aktieselskab"}, "FIL", CategoryBranchOfForeign},
+		{"prh description after unknown code", "finland", []string{"16", "Public limited company"}, "OYJ", CategoryPublicLimited},
+		{"prh finnish name", "finland", []string{"Osakeyhtiö"}, "OY", CategoryLimited},
+		{"bolagsverket organisationsform", "sweden", []string{"KB", "31"}, "KB", CategoryPartnership},
+		{"bolagsverket juridisk form fallback", "sweden", []string{"", "49"}, "AB", CategoryLimited},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := Lookup(tt.country, tt.candidates...)
+			if f == nil {
+				t.Fatalf("Lookup(%s, %q) = nil", tt.country, tt.candidates)
+			}
+			if f.Code != tt.wantCode || f.Category != tt.wantCat {
+				t.Errorf("Lookup = %s (%s), want %s (%s)", f.Code, f.Category, tt.wantCode, tt.wantCat)
+			}
+		})
+	}
+}
+
+func TestLookup_Unknown(t *testing.T) {
+	if f := Lookup("norway", "XYZ"); f != nil {
+		t.Errorf("unknown code resolved to %+v", f)
+	}
+	// Codes are scoped to their country: Danish ApS is not a Norwegian form.
+	if f := Lookup("norway", "ApS"); f != nil {
+		t.Errorf("Danish code resolved in Norway to %+v", f)
+	}
+	if f := Lookup("sweden"); f != nil {
+		t.Errorf("no candidates resolved to %+v", f)
+	}
+}
+
+func TestList(t *testing.T) {
+	limited := List("", CategoryLimited)
+	countries := make(map[string]bool)
+	for _, f := range limited {
+		if f.Category != CategoryLimited {
+			t.Errorf("List returned %s in category %s", f.Code, f.Category)
+		}
+		countries[f.Country] = true
+	}
+	for _, c := range Countries {
+		if !countries[c] {
+			t.Errorf("no limited company form for %s", c)
+		}
+	}
+	if got := List("sweden", CategoryFoundation); len(got) != 1 || got[0].Code != "S" {
+		t.Errorf("List(sweden, foundation) = %+v", got)
+	}
+}
@@ -0,0 +1,122 @@
+// Package lei indexes the GLEIF LEI golden copy by national registration
+// number so company lookups can be enriched with Legal Entity Identifiers.
+//
+// The golden copy is read from disk at startup (see Load); the server never
+// calls the GLEIF API. Only entities whose legal jurisdiction is one of the
+// indexed countries are kept, which keeps the in-memory index small even
+// though the full file lists millions of entities.
+package lei
+
+import (
+	"os"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	// envLEIFile names the LEI-CDF golden copy (CSV, JSON or a ZIP of either).
+	envLEIFile = "GLEIF_LEI_FILE"
+
+	// envRRFile names the optional relationship-record (RR-CDF) golden copy
+	// that supplies parent LEIs.
+	envRRFile = "GLEIF_RR_FILE"
+)
+
+// NordicJurisdictions are the legal jurisdictions indexed by default.
+var NordicJurisdictions = []string{"NO", "DK", "FI", "SE"}
+
+// Entity is the LEI data attached to a company lookup.
+type Entity struct {
+	LEI               string `json:"lei" jsonschema:"20-character Legal Entity Identifier"`
+	LegalName         string `json:"legal_name,omitempty" jsonschema:"Legal name registered with GLEIF"`
+	Status            string `json:"status" jsonschema:"LEI registration status: ISSUED, LAPSED, RETIRED and others"`
+	EntityStatus      string `json:"entity_status,omitempty" jsonschema:"Entity status: ACTIVE or INACTIVE"`
+	DirectParentLEI   string `json:"direct_parent_lei,omitempty" jsonschema:"LEI of the direct accounting consolidating parent"`
+	UltimateParentLEI string `json:"ultimate_parent_lei,omitempty" jsonschema:"LEI of the ultimate accounting consolidating parent"`
+}
+
+// Index maps (jurisdiction, registration number) to LEI entities. It is
+// built once by Load and read-only afterwards, so it is safe for concurrent
+// use without locking.
+type Index struct {
+	entities map[string]*Entity // key(jurisdiction, entityID) -> entity
+	byLEI    map[string]*Entity
+	loadedAt time.Time
+}
+
+// newIndex creates an empty index.
+func newIndex() *Index {
+	return &Index{
+		entities: make(map[string]*Entity),
+		byLEI:    make(map[string]*Entity),
+	}
+}
+
+// Lookup returns the LEI entity registered for a company. jurisdiction is the
+// ISO country code (NO, DK, FI, SE); entityID is the national registration
+// number in any common spelling ("0112038-9" and "01120389" are the same).
+// A nil Index finds nothing.
+func (i *Index) Lookup(jurisdiction, entityID string) (*Entity, bool) {
+	if i == nil {
+		return nil, false
+	}
+	e, ok := i.entities[key(jurisdiction, entityID)]
+	return e, ok
+}
+
+// Len returns the number of indexed entities.
+func (i *Index) Len() int {
+	if i == nil {
+		return 0
+	}
+	return len(i.entities)
+}
+
+// LoadedAt returns when the index was built.
+func (i *Index) LoadedAt() time.Time {
+	return i.loadedAt
+}
+
+// Enrichable is implemented by get_company results that can carry LEI data.
+type Enrichable interface {
+	// LEIKey returns the jurisdiction and registration number of the
+	// company in the result, or empty strings when there is none.
+	LEIKey() (jurisdiction, entityID string)
+	// SetLEI attaches the entity found for LEIKey.
+	SetLEI(e *Entity)
+}
+
+// Enrich looks up the company in r and attaches its LEI entity when found.
+func (i *Index) Enrich(r Enrichable) {
+	jurisdiction, id := r.LEIKey()
+	if id == "" {
+		return
+	}
+	if e, ok := i.Lookup(jurisdiction, id); ok {
+		r.SetLEI(e)
+	}
+}
+
+// IsConfigured returns true if a golden-copy file is configured.
+func IsConfigured() bool {
+	return os.Getenv(envLEIFile) != ""
+}
+
+// LoadFromEnv loads the files named by GLEIF_LEI_FILE and, when set,
+// GLEIF_RR_FILE, keeping the Nordic jurisdictions.
+func LoadFromEnv() (*Index, error) {
+	return Load(os.Getenv(envLEIFile), os.Getenv(envRRFile), NordicJurisdictions...)
+}
+
+// key builds the index key: the jurisdiction and the registration number
+// reduced to upper-case letters and digits.
+func key(jurisdiction, entityID string) string {
+	id := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return -1
+	}, entityID)
+	return strings.ToUpper(jurisdiction) + ":" + id
+}
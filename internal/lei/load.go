@@ -0,0 +1,300 @@
+package lei
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// GLEIF relationship types and status that contribute parent LEIs.
+const (
+	relDirectParent   = "IS_DIRECTLY_CONSOLIDATED_BY"
+	relUltimateParent = "IS_ULTIMATELY_CONSOLIDATED_BY"
+	relStatusActive   = "ACTIVE"
+)
+
+// Column names in the GLEIF golden-copy CSV files.
+const (
+	colLEI            = "LEI"
+	colLegalName      = "Entity.LegalName"
+	colJurisdiction   = "Entity.LegalJurisdiction"
+	colEntityStatus   = "Entity.EntityStatus"
+	colRAEntityID     = "Entity.RegistrationAuthority.RegistrationAuthorityEntityID"
+	colRegStatus      = "Registration.RegistrationStatus"
+	colStartNode      = "Relationship.StartNode.NodeID"
+	colEndNode        = "Relationship.EndNode.NodeID"
+	colRelationType   = "Relationship.RelationshipType"
+	colRelationStatus = "Relationship.RelationshipStatus"
+)
+
+// Top-level array names in the GLEIF golden-copy JSON files.
+const (
+	jsonLEIRecordsKey = "records"
+	jsonRelationKey   = "relations"
+)
+
+// Load builds an index from a LEI-CDF golden copy and, when rrPath is not
+// empty, a relationship-record golden copy. Files may be CSV or JSON as
+// published by GLEIF, or a ZIP holding one such file; the format is chosen by
+// extension. Only entities whose legal jurisdiction is in jurisdictions and
+// that carry a registration-authority entity ID are kept.
+func Load(leiPath, rrPath string, jurisdictions ...string) (*Index, error) {
+	if leiPath == "" {
+		return nil, errors.New("lei: no golden-copy file given")
+	}
+
+	keep := make(map[string]bool, len(jurisdictions))
+	for _, j := range jurisdictions {
+		keep[strings.ToUpper(j)] = true
+	}
+
+	idx := newIndex()
+	err := readFile(leiPath, func(name string, r io.Reader) error {
+		return idx.readEntities(name, r, keep)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("lei: reading %s: %w", leiPath, err)
+	}
+
+	if rrPath != "" {
+		err := readFile(rrPath, func(name string, r io.Reader) error {
+			return idx.readRelationships(name, r)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("lei: reading %s: %w", rrPath, err)
+		}
+	}
+
+	idx.loadedAt = time.Now()
+	return idx, nil
+}
+
+// readFile opens path and passes its content to fn, unwrapping a ZIP archive
+// to its single data file. fn receives the inner file name so it can pick a
+// parser by extension.
+func readFile(path string, fn func(name string, r io.Reader) error) error {
+	if strings.EqualFold(filepath.Ext(path), ".zip") {
+		zr, err := zip.OpenReader(path)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = zr.Close() }()
+
+		var files []*zip.File
+		for _, f := range zr.File {
+			if !f.FileInfo().IsDir() {
+				files = append(files, f)
+			}
+		}
+		if len(files) != 1 {
+			return fmt.Errorf("expected one file in ZIP archive, found %d", len(files))
+		}
+		rc, err := files[0].Open()
+		if err != nil {
+			return err
+		}
+		defer func() { _ = rc.Close() }()
+		return fn(files[0].Name, rc)
+	}
+
+	f, err := os.Open(path) // #nosec G304 -- operator-supplied path from server configuration
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	return fn(path, f)
+}
+
+// isJSON reports whether a file name denotes the JSON golden-copy format.
+func isJSON(name string) bool {
+	return strings.EqualFold(filepath.Ext(name), ".json")
+}
+
+// readEntities adds the LEI records in r to the index.
+func (i *Index) readEntities(name string, r io.Reader, keep map[string]bool) error {
+	add := func(lei, legalName, jurisdiction, entityStatus, raEntityID, regStatus string) {
+		if lei == "" || raEntityID == "" || !keep[strings.ToUpper(jurisdiction)] {
+			return
+		}
+		e := &Entity{
+			LEI:          lei,
+			LegalName:    legalName,
+			Status:       regStatus,
+			EntityStatus: entityStatus,
+		}
+		i.entities[key(jurisdiction, raEntityID)] = e
+		i.byLEI[lei] = e
+	}
+
+	if isJSON(name) {
+		return decodeJSONArray(r, jsonLEIRecordsKey, func(rec jsonLEIRecord) {
+			add(rec.LEI.V, rec.Entity.LegalName.V, rec.Entity.LegalJurisdiction.V, rec.Entity.EntityStatus.V,
+				rec.Entity.RegistrationAuthority.RegistrationAuthorityEntityID.V, rec.Registration.RegistrationStatus.V)
+		})
+	}
+	return readCSV(r, []string{colLEI, colLegalName, colJurisdiction, colEntityStatus, colRAEntityID, colRegStatus}, func(f []string) {
+		add(f[0], f[1], f[2], f[3], f[4], f[5])
+	})
+}
+
+// readRelationships sets parent LEIs on indexed entities from the
+// relationship records in r. Relationships of entities outside the index and
+// inactive relationships are ignored.
+func (i *Index) readRelationships(name string, r io.Reader) error {
+	add := func(child, parent, relType, status string) {
+		e, ok := i.byLEI[child]
+		if !ok || status != relStatusActive {
+			return
+		}
+		switch relType {
+		case relDirectParent:
+			e.DirectParentLEI = parent
+		case relUltimateParent:
+			e.UltimateParentLEI = parent
+		}
+	}
+
+	if isJSON(name) {
+		return decodeJSONArray(r, jsonRelationKey, func(rec jsonRelationRecord) {
+			rel := rec.Relationship
+			add(rel.StartNode.NodeID.V, rel.EndNode.NodeID.V, rel.RelationshipType.V, rel.RelationshipStatus.V)
+		})
+	}
+	return readCSV(r, []string{colStartNode, colEndNode, colRelationType, colRelationStatus}, func(f []string) {
+		add(f[0], f[1], f[2], f[3])
+	})
+}
+
+// readCSV streams a CSV file with a header row and calls fn with the values
+// of columns, in that order, for every data row.
+func readCSV(r io.Reader, columns []string, fn func(fields []string)) error {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	cr.FieldsPerRecord = -1
+
+	header, err := cr.Read()
+	if err != nil {
+		return fmt.Errorf("reading CSV header: %w", err)
+	}
+	pos := make(map[string]int, len(header))
+	for n, h := range header {
+		// Tolerate a UTF-8 byte order mark before the first column name.
+		pos[strings.TrimPrefix(h, "\ufeff")] = n
+	}
+	idx := make([]int, len(columns))
+	for n, c := range columns {
+		p, ok := pos[c]
+		if !ok {
+			return fmt.Errorf("CSV is missing column %q", c)
+		}
+		idx[n] = p
+	}
+
+	fields := make([]string, len(columns))
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for n, p := range idx {
+			fields[n] = ""
+			if p < len(rec) {
+				fields[n] = rec[p]
+			}
+		}
+		fn(fields)
+	}
+}
+
+// text is a GLEIF JSON scalar, which is wrapped as {"$": "value"}.
+type text struct {
+	V string `json:"$"`
+}
+
+// jsonLEIRecord is one element of the LEI-CDF JSON "records" array.
+type jsonLEIRecord struct {
+	LEI    text `json:"LEI"`
+	Entity struct {
+		LegalName             text `json:"LegalName"`
+		LegalJurisdiction     text `json:"LegalJurisdiction"`
+		EntityStatus          text `json:"EntityStatus"`
+		RegistrationAuthority struct {
+			RegistrationAuthorityEntityID text `json:"RegistrationAuthorityEntityID"`
+		} `json:"RegistrationAuthority"`
+	} `json:"Entity"`
+	Registration struct {
+		RegistrationStatus text `json:"RegistrationStatus"`
+	} `json:"Registration"`
+}
+
+// jsonRelationRecord is one element of the RR-CDF JSON "relations" array.
+type jsonRelationRecord struct {
+	Relationship struct {
+		StartNode struct {
+			NodeID text `json:"NodeID"`
+		} `json:"StartNode"`
+		EndNode struct {
+			NodeID text `json:"NodeID"`
+		} `json:"EndNode"`
+		RelationshipType   text `json:"RelationshipType"`
+		RelationshipStatus text `json:"RelationshipStatus"`
+	} `json:"Relationship"`
+}
+
+// decodeJSONArray streams the array stored under arrayKey in a top-level JSON
+// object, decoding one element at a time so the multi-gigabyte golden copy
+// is never held in memory. Other top-level members are skipped.
+func decodeJSONArray[T any](r io.Reader, arrayKey string, fn func(T)) error {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if name, _ := tok.(string); name != arrayKey {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := expectDelim(dec, '['); err != nil {
+			return err
+		}
+		for dec.More() {
+			var rec T
+			if err := dec.Decode(&rec); err != nil {
+				return err
+			}
+			fn(rec)
+		}
+		return expectDelim(dec, ']')
+	}
+	return fmt.Errorf("JSON has no %q array", arrayKey)
+}
+
+// expectDelim reads the next token and checks it is the delimiter d.
+func expectDelim(dec *json.Decoder, d json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != d {
+		return fmt.Errorf("unexpected JSON token %v, want %v", tok, d)
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package lei
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testLEICSV = `"LEI","Entity.LegalName","Entity.LegalJurisdiction","Entity.EntityStatus","Entity.RegistrationAuthority.RegistrationAuthorityID","Entity.RegistrationAuthority.RegistrationAuthorityEntityID","Registration.RegistrationStatus"
+"OW6OFBNCKXC4US5C7523","EQUINOR ASA","NO","ACTIVE","RA000472","923609016","ISSUED"
+"743700Y3MVL9NHD15O41","Nokia Oyj","FI","ACTIVE","RA000188","0112038-9","LAPSED"
+"549300DAQ1CVT6CXN342","Volvo Car AB","SE","ACTIVE","RA000544","556074-3089","ISSUED"
+"5493000IBP32UQZ0KL24","Apple Inc.","US-CA","ACTIVE","RA000598","C0806592","ISSUED"
+"529900NO1EIDNOID0001","No Registration Number AS","NO","ACTIVE","RA999999","","ISSUED"
+`
+
+const testRRCSV = `"Relationship.StartNode.NodeID","Relationship.StartNode.NodeIDType","Relationship.EndNode.NodeID","Relationship.EndNode.NodeIDType","Relationship.RelationshipType","Relationship.RelationshipStatus"
+"549300DAQ1CVT6CXN342","LEI","549300HGQXGRVUFBMU41","LEI","IS_DIRECTLY_CONSOLIDATED_BY","ACTIVE"
+"549300DAQ1CVT6CXN342","LEI","5493000LKS7B3UTF7H35","LEI","IS_ULTIMATELY_CONSOLIDATED_BY","ACTIVE"
+"OW6OFBNCKXC4US5C7523","LEI","549300XXXXXXXXXXXX00","LEI","IS_DIRECTLY_CONSOLIDATED_BY","INACTIVE"
+"5493000IBP32UQZ0KL24","LEI","549300YYYYYYYYYYYY00","LEI","IS_DIRECTLY_CONSOLIDATED_BY","ACTIVE"
+`
+
+const testLEIJSON = `{"header":{"ContentDate":"2026-10-18T08:00:00Z","RecordCount":2},"records":[
+{"LEI":{"$":"OW6OFBNCKXC4US5C7523"},"Entity":{"LegalName":{"$":"EQUINOR ASA"},"LegalJurisdiction":{"$":"NO"},"EntityStatus":{"$":"ACTIVE"},"RegistrationAuthority":{"RegistrationAuthorityID":{"$":"RA000472"},"RegistrationAuthorityEntityID":{"$":"923609016"}}},"Registration":{"RegistrationStatus":{"$":"ISSUED"}}},
+{"LEI":{"$":"5493000IBP32UQZ0KL24"},"Entity":{"LegalName":{"$":"Apple Inc."},"LegalJurisdiction":{"$":"US-CA"},"RegistrationAuthority":{"RegistrationAuthorityEntityID":{"$":"C0806592"}}},"Registration":{"RegistrationStatus":{"$":"ISSUED"}}}
+]}`
+
+const testRRJSON = `{"header":{"RecordCount":1},"relations":[
+{"Relationship":{"StartNode":{"NodeID":{"$":"OW6OFBNCKXC4US5C7523"}},"EndNode":{"NodeID":{"$":"549300ZZZZZZZZZZZZ00"}},"RelationshipType":{"$":"IS_ULTIMATELY_CONSOLIDATED_BY"},"RelationshipStatus":{"$":"ACTIVE"}}}
+]}`
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func writeZip(t *testing.T, name, inner, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	w, err := zw.Create(inner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad_CSV(t *testing.T) {
+	idx, err := Load(writeFile(t, "lei.csv", testLEICSV), writeFile(t, "rr.csv", testRRCSV), NordicJurisdictions...)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if idx.Len() != 3 {
+		t.Errorf("Len() = %d, want 3 (foreign and ID-less entities dropped)", idx.Len())
+	}
+
+	tests := []struct {
+		jurisdiction, id string
+		wantLEI          string
+		wantStatus       string
+		wantDirect       string
+		wantUltimate     string
+	}{
+		{"NO", "923 609 016", "OW6OFBNCKXC4US5C7523", "ISSUED", "", ""}, // inactive relationship ignored
+		{"FI", "01120389", "743700Y3MVL9NHD15O41", "LAPSED", "", ""},
+		{"se", "5560743089", "549300DAQ1CVT6CXN342", "ISSUED", "549300HGQXGRVUFBMU41", "5493000LKS7B3UTF7H35"},
+	}
+	for _, tt := range tests {
+		e, ok := idx.Lookup(tt.jurisdiction, tt.id)
+		if !ok {
+			t.Errorf("Lookup(%s, %s) found nothing", tt.jurisdiction, tt.id)
+			continue
+		}
+		if e.LEI != tt.wantLEI || e.Status != tt.wantStatus || e.DirectParentLEI != tt.wantDirect || e.UltimateParentLEI != tt.wantUltimate {
+			t.Errorf("Lookup(%s, %s) = %+v", tt.jurisdiction, tt.id, *e)
+		}
+	}
+
+	if _, ok := idx.Lookup("DK", "923609016"); ok {
+		t.Error("lookup must be scoped to the jurisdiction")
+	}
+}
+
+func TestLoad_JSON(t *testing.T) {
+	idx, err := Load(writeFile(t, "lei.json", testLEIJSON), writeFile(t, "rr.json", testRRJSON), NordicJurisdictions...)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if idx.Len() != 1 {
+		t.Fatalf("Len() = %d, want 1", idx.Len())
+	}
+	e, ok := idx.Lookup("NO", "923609016")
+	if !ok {
+		t.Fatal("Equinor not indexed")
+	}
+	if e.LegalName != "EQUINOR ASA" || e.EntityStatus != "ACTIVE" || e.UltimateParentLEI != "549300ZZZZZZZZZZZZ00" {
+		t.Errorf("unexpected entity: %+v", *e)
+	}
+}
+
+func TestLoad_Zip(t *testing.T) {
+	idx, err := Load(writeZip(t, "golden-copy.zip", "20261018-gleif-concatenated-file-lei2.csv", testLEICSV), "", NordicJurisdictions...)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if idx.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", idx.Len())
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		leiPath string
+		rrPath  string
+	}{
+		{"no file", "", ""},
+		{"missing file", filepath.Join(t.TempDir(), "absent.csv"), ""},
+		{"missing column", writeFile(t, "lei.csv", "LEI,Entity.LegalName\nX,Y\n"), ""},
+		{"wrong JSON array", writeFile(t, "lei.json", `{"relations":[]}`), ""},
+		{"bad relationships", writeFile(t, "lei.csv", testLEICSV), writeFile(t, "rr.json", `[]`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Load(tt.leiPath, tt.rrPath, NordicJurisdictions...); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+type testResult struct {
+	id  string
+	lei *Entity
+}
+
+func (r *testResult) LEIKey() (string, string) { return "NO", r.id }
+func (r *testResult) SetLEI(e *Entity)         { r.lei = e }
+
+func TestIndex_Enrich(t *testing.T) {
+	idx, err := Load(writeFile(t, "lei.csv", testLEICSV), "", NordicJurisdictions...)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	found := &testResult{id: "923609016"}
+	idx.Enrich(found)
+	if found.lei == nil || found.lei.LEI != "OW6OFBNCKXC4US5C7523" {
+		t.Errorf("Enrich did not attach Equinor's LEI: %+v", found.lei)
+	}
+
+	for _, id := range []string{"974760673", ""} {
+		r := &testResult{id: id}
+		idx.Enrich(r)
+		if r.lei != nil {
+			t.Errorf("Enrich(%q) attached %+v", id, r.lei)
+		}
+	}
+
+	var nilIdx *Index
+	if _, ok := nilIdx.Lookup("NO", "923609016"); ok || nilIdx.Len() != 0 {
+		t.Error("nil index must be empty")
+	}
+}
+
+func TestKey(t *testing.T) {
+	if got, want := key("fi", "0112038-9"), "FI:01120389"; got != want {
+		t.Errorf("key = %q, want %q", got, want)
+	}
+	if !strings.HasPrefix(key("se", "556074-3089"), "SE:") {
+		t.Error("jurisdiction not upper-cased")
+	}
+}
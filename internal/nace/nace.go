@@ -0,0 +1,252 @@
+// Package nace maps the Nordic national industry classifications onto the
+// common EU classification NACE, so companies from different registries can
+// be segmented by the same codes.
+//
+// SN2007 (Norway), DB07 (Denmark), TOL 2008 (Finland) and SNI 2007 (Sweden)
+// are national extensions of NACE Rev.2: their first four digits are the NACE
+// class and the extra digits a national subclass. SN2025, DB25, TOL 2025 and
+// SNI 2025 extend NACE Rev.2.1 the same way. The embedded tables carry
+// English labels for every section, division, group and class of both
+// revisions: Rev.2 as laid down in Regulation (EC) No 1893/2006, Annex I
+// (272 groups, 615 classes), and Rev.2.1 as laid down in Commission
+// Delegated Regulation (EU) 2023/137, Annex (287 groups, 651 classes).
+package nace
+
+import (
+	"embed"
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+)
+
+// NACE revisions reported in Code.Version.
+const (
+	Rev2  = "2"
+	Rev21 = "2.1"
+)
+
+// Levels reported in Code.Level.
+const (
+	LevelSection  = "section"
+	LevelDivision = "division"
+	LevelGroup    = "group"
+	LevelClass    = "class"
+)
+
+//go:embed data/*.csv
+var dataFS embed.FS
+
+// Code is a company's industry code expressed in NACE, with every level of
+// the hierarchy down to the deepest one the input reaches.
+type Code struct {
+	Code           string `json:"code" jsonschema:"Canonical NACE code at the deepest level matched, e.g. 62.01"`
+	Version        string `json:"version" jsonschema:"NACE revision: 2 or 2.1"`
+	Level          string `json:"level" jsonschema:"Level of the code: section, division, group or class"`
+	Label          string `json:"label,omitempty" jsonschema:"English label of the code"`
+	Section        string `json:"section" jsonschema:"NACE section letter, e.g. J"`
+	SectionLabel   string `json:"section_label" jsonschema:"English label of the section"`
+	Division       string `json:"division,omitempty" jsonschema:"Two-digit NACE division, e.g. 62"`
+	DivisionLabel  string `json:"division_label,omitempty" jsonschema:"English label of the division"`
+	Group          string `json:"group,omitempty" jsonschema:"NACE group, e.g. 62.0"`
+	Class          string `json:"class,omitempty" jsonschema:"NACE class, e.g. 62.01"`
+	NationalScheme string `json:"national_scheme,omitempty" jsonschema:"National scheme of the registry code, e.g. sn2007 or sn2025; omitted when the code is valid in both revisions and the registry does not say which"`
+	NationalCode   string `json:"national_code,omitempty" jsonschema:"Industry code as given by the registry"`
+	NationalLabel  string `json:"national_label,omitempty" jsonschema:"Registry's label of the code, in its own language"`
+	Rev21Section   string `json:"rev21_section,omitempty" jsonschema:"For Rev.2 codes, the section of the same division in Rev.2.1"`
+	Rev21Division  string `json:"rev21_division,omitempty" jsonschema:"For Rev.2 codes, the Rev.2.1 division when the division carried over whole"`
+}
+
+// Entry is one row of a classification table.
+type Entry struct {
+	Code  string `json:"code" jsonschema:"NACE code"`
+	Label string `json:"label" jsonschema:"English label"`
+}
+
+// table is one embedded NACE revision.
+type table struct {
+	labels   map[string]string   // code -> English label
+	parent   map[string]string   // code -> parent code ("" for sections)
+	children map[string][]string // code -> child codes in table order; "" lists the sections
+}
+
+var tables = map[string]*table{
+	Rev2:  mustLoad("data/nace_rev2.csv"),
+	Rev21: mustLoad("data/nace_rev21.csv"),
+}
+
+// rev2SplitDivisions are NACE Rev.2 divisions whose activities were spread
+// over several Rev.2.1 divisions, so they have no single Rev.2.1 equivalent.
+// Taken from the Eurostat NACE Rev.2 - Rev.2.1 correspondence table, which
+// moves 41.10 (development of building projects) to 68.12, divides 45
+// (motor vehicle trade and repair) between 46.7, 47.8 and 95.3, moves 63.91
+// (news agencies) and part of 63.12 (web portals) to 60.3, and moves 70.21
+// (public relations) to 73.30. Every other division keeps its activities,
+// though classes within it may be merged or split.
+var rev2SplitDivisions = map[string]bool{"41": true, "45": true, "63": true, "70": true}
+
+// mustLoad parses an embedded table. The files ship with the binary, so a
+// parse failure is a build defect and panics at init.
+func mustLoad(name string) *table {
+	f, err := dataFS.Open(name)
+	if err != nil {
+		panic(fmt.Sprintf("nace: %v", err))
+	}
+	defer func() { _ = f.Close() }()
+
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("nace: parsing %s: %v", name, err))
+	}
+
+	t := &table{
+		labels:   make(map[string]string, len(rows)),
+		parent:   make(map[string]string, len(rows)),
+		children: make(map[string][]string),
+	}
+	for _, row := range rows[1:] {
+		code, parent, label := row[0], row[1], row[2]
+		t.labels[code] = label
+		t.parent[code] = parent
+		t.children[parent] = append(t.children[parent], code)
+	}
+	return t
+}
+
+// Lookup resolves an industry code in the given scheme (see SchemeIDs) to NACE.
+// National codes may be written with or without dots ("62.010", "62010");
+// a section letter or a 2- to 4-digit NACE prefix is accepted in any scheme.
+// It returns a ValidationError for an unknown scheme, a malformed code, a
+// division, group or class that does not exist in the revision, or a
+// national "unspecified" code such as SN2007 00.000.
+func Lookup(scheme, code string) (*Code, error) {
+	s, ok := schemes[scheme]
+	if !ok {
+		return nil, apierrors.NewValidationError("scheme", scheme, "must be one of "+strings.Join(SchemeIDs(), ", "))
+	}
+	t := tables[s.Version]
+
+	raw := strings.TrimSpace(code)
+	upper := strings.ToUpper(raw)
+	if len(upper) == 1 && upper >= "A" && upper <= "Z" {
+		label, ok := t.labels[upper]
+		if !ok {
+			return nil, apierrors.NewValidationError("code", raw, fmt.Sprintf("no section %s in NACE Rev.%s", upper, s.Version))
+		}
+		return &Code{Code: upper, Version: s.Version, Level: LevelSection, Label: label, Section: upper, SectionLabel: label}, nil
+	}
+
+	digits := strings.NewReplacer(".", "", " ", "", "-", "").Replace(raw)
+	if len(digits) < 2 || len(digits) > s.Digits || strings.Trim(digits, "0123456789") != "" {
+		return nil, apierrors.NewValidationError("code", raw, fmt.Sprintf("expected a section letter or 2 to %d digits for %s", s.Digits, s.Name))
+	}
+	if strings.Trim(digits, "0") == "" || s.Unspecified[digits] {
+		return nil, apierrors.NewValidationError("code", raw, "industry not specified")
+	}
+
+	division := digits[:2]
+	section, ok := t.parent[division]
+	if !ok {
+		return nil, apierrors.NewValidationError("code", raw, fmt.Sprintf("no division %s in NACE Rev.%s", division, s.Version))
+	}
+
+	c := &Code{
+		Code:          division,
+		Version:       s.Version,
+		Level:         LevelDivision,
+		Section:       section,
+		SectionLabel:  t.labels[section],
+		Division:      division,
+		DivisionLabel: t.labels[division],
+	}
+	if len(digits) >= 3 {
+		c.Group = division + "." + digits[2:3]
+		c.Code, c.Level = c.Group, LevelGroup
+	}
+	if len(digits) >= 4 {
+		c.Class = division + "." + digits[2:4]
+		c.Code, c.Level = c.Class, LevelClass
+	}
+	label, ok := t.labels[c.Code]
+	if !ok {
+		return nil, apierrors.NewValidationError("code", raw, fmt.Sprintf("no %s %s in NACE Rev.%s", c.Level, c.Code, s.Version))
+	}
+	c.Label = label
+
+	if s.ID != SchemeNACE && s.ID != SchemeNACE21 {
+		c.NationalScheme = s.ID
+		c.NationalCode = raw
+	}
+	if s.Version == Rev2 {
+		if rev21Section, ok := tables[Rev21].parent[division]; ok && !rev2SplitDivisions[division] {
+			c.Rev21Section = rev21Section
+			c.Rev21Division = division
+		}
+	}
+	return c, nil
+}
+
+// FromNational resolves a code reported by a registry, attaching the
+// registry's label. It returns nil when the code cannot be classified, so
+// callers can assign the result to an omitempty field directly.
+func FromNational(scheme, code, label string) *Code {
+	if strings.TrimSpace(code) == "" {
+		return nil
+	}
+	c, err := Lookup(scheme, code)
+	if err != nil {
+		return nil
+	}
+	c.NationalLabel = strings.TrimSpace(label)
+	return c
+}
+
+// FromRegistry resolves a code from a registry that does not say which
+// revision of its national scheme the code belongs to. older and newer are
+// the country's Rev.2 and Rev.2.1 schemes. A code that exists in only one of
+// them is resolved there. A code valid in both keeps the older mapping but
+// leaves NationalScheme empty, since the revision cannot be told from the
+// code alone.
+func FromRegistry(older, newer, code, label string) *Code {
+	oldCode, newCode := FromNational(older, code, label), FromNational(newer, code, label)
+	switch {
+	case oldCode == nil:
+		return newCode
+	case newCode != nil:
+		oldCode.NationalScheme = ""
+	}
+	return oldCode
+}
+
+// Children lists the codes directly below code in a revision: the divisions
+// of a section, the groups of a division, the classes of a group, or every
+// section when code is empty. A class has no children.
+func Children(version, code string) []Entry {
+	t, ok := tables[version]
+	if !ok {
+		return nil
+	}
+	codes := t.children[strings.ToUpper(code)]
+	out := make([]Entry, 0, len(codes))
+	for _, c := range codes {
+		out = append(out, Entry{Code: c, Label: t.labels[c]})
+	}
+	return out
+}
+
+// Section is a NACE section with its divisions.
+type Section struct {
+	Entry
+	Divisions []Entry `json:"divisions"`
+}
+
+// Sections returns the section and division levels of a revision as a tree.
+func Sections(version string) []Section {
+	sections := Children(version, "")
+	out := make([]Section, 0, len(sections))
+	for _, s := range sections {
+		out = append(out, Section{Entry: s, Divisions: Children(version, s.Code)})
+	}
+	return out
+}
@@ -0,0 +1,240 @@
+package nace
+
+import (
+	"testing"
+
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+)
+
+func TestTablesComplete(t *testing.T) {
+	tests := []struct {
+		version   string
+		sections  int
+		divisions int
+	}{
+		{Rev2, 21, 88},
+		{Rev21, 22, 87},
+	}
+	for _, tt := range tests {
+		sections := Children(tt.version, "")
+		if len(sections) != tt.sections {
+			t.Errorf("Rev.%s: %d sections, want %d", tt.version, len(sections), tt.sections)
+		}
+		divisions := 0
+		for _, s := range sections {
+			divisions += len(Children(tt.version, s.Code))
+		}
+		if divisions != tt.divisions {
+			t.Errorf("Rev.%s: %d divisions, want %d", tt.version, divisions, tt.divisions)
+		}
+	}
+}
+
+// tableSizes are the group and class counts of each revision as published.
+var tableSizes = map[string][2]int{Rev2: {272, 615}, Rev21: {287, 651}}
+
+func TestTablesGroupsAndClasses(t *testing.T) {
+	for _, version := range []string{Rev2, Rev21} {
+		groups, classes := 0, 0
+		for _, s := range Children(version, "") {
+			for _, d := range Children(version, s.Code) {
+				gs := Children(version, d.Code)
+				if len(gs) == 0 {
+					t.Errorf("Rev.%s: division %s has no groups", version, d.Code)
+				}
+				for _, g := range gs {
+					cs := Children(version, g.Code)
+					if len(cs) == 0 {
+						t.Errorf("Rev.%s: group %s has no classes", version, g.Code)
+					}
+					for _, c := range cs {
+						if len(c.Code) != 5 || c.Code[:4] != g.Code || c.Label == "" {
+							t.Errorf("Rev.%s: bad class %+v under %s", version, c, g.Code)
+						}
+					}
+					groups++
+					classes += len(cs)
+				}
+			}
+		}
+		if want := tableSizes[version]; groups != want[0] || classes != want[1] {
+			t.Errorf("Rev.%s: %d groups and %d classes, want %d and %d", version, groups, classes, want[0], want[1])
+		}
+	}
+}
+
+func TestLookup_NationalSchemes(t *testing.T) {
+	tests := []struct {
+		scheme, code string
+		want         Code
+	}{
+		{SchemeSN2007, "62.010", Code{Code: "62.01", Level: LevelClass, Section: "J", Division: "62", Group: "62.0", Class: "62.01", Rev21Section: "K", Rev21Division: "62"}},
+		{SchemeDB07, "212000", Code{Code: "21.20", Level: LevelClass, Section: "C", Division: "21", Group: "21.2", Class: "21.20", Rev21Section: "C", Rev21Division: "21"}},
+		{SchemeTOL2008, "26110", Code{Code: "26.11", Level: LevelClass, Section: "C", Division: "26", Group: "26.1", Class: "26.11", Rev21Section: "C", Rev21Division: "26"}},
+		{SchemeSNI2007, "64", Code{Code: "64", Level: LevelDivision, Section: "K", Division: "64", Rev21Section: "L", Rev21Division: "64"}},
+		{SchemeSNI2007, "451", Code{Code: "45.1", Level: LevelGroup, Section: "G", Division: "45", Group: "45.1"}}, // split in Rev.2.1
+		{SchemeSN2025, "62.100", Code{Code: "62.10", Level: LevelClass, Section: "K", Division: "62", Group: "62.1", Class: "62.10"}},
+		{SchemeNACE, "j", Code{Code: "J", Level: LevelSection, Section: "J"}},
+	}
+	for _, tt := range tests {
+		got, err := Lookup(tt.scheme, tt.code)
+		if err != nil {
+			t.Errorf("Lookup(%s, %s): %v", tt.scheme, tt.code, err)
+			continue
+		}
+		if got.Code != tt.want.Code || got.Level != tt.want.Level || got.Section != tt.want.Section ||
+			got.Division != tt.want.Division || got.Group != tt.want.Group || got.Class != tt.want.Class ||
+			got.Rev21Section != tt.want.Rev21Section || got.Rev21Division != tt.want.Rev21Division {
+			t.Errorf("Lookup(%s, %s) = %+v, want %+v", tt.scheme, tt.code, *got, tt.want)
+		}
+		if got.SectionLabel == "" {
+			t.Errorf("Lookup(%s, %s): missing section label", tt.scheme, tt.code)
+		}
+	}
+}
+
+func TestLookup_Labels(t *testing.T) {
+	got, err := Lookup(SchemeSN2007, "06.100")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.SectionLabel != "Mining and quarrying" || got.DivisionLabel != "Extraction of crude petroleum and natural gas" {
+		t.Errorf("labels = %q / %q", got.SectionLabel, got.DivisionLabel)
+	}
+	if got.NationalScheme != SchemeSN2007 || got.NationalCode != "06.100" {
+		t.Errorf("national = %s %s", got.NationalScheme, got.NationalCode)
+	}
+
+	nace, err := Lookup(SchemeNACE, "62")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nace.Label != nace.DivisionLabel || nace.NationalScheme != "" {
+		t.Errorf("NACE division lookup = %+v", *nace)
+	}
+}
+
+func TestLookup_ClassLabels(t *testing.T) {
+	tests := []struct {
+		scheme, code string
+		want         string
+	}{
+		{SchemeSN2007, "62.010", "Computer programming activities"},
+		{SchemeSNI2007, "47111", "Retail sale in non-specialised stores with food, beverages or tobacco predominating"},
+		{SchemeDB07, "452000", "Maintenance and repair of motor vehicles"},
+		{SchemeNACE, "62.0", "Computer programming, consultancy and related activities"},
+		{SchemeSN2025, "62.100", "Computer programming activities"},
+		{SchemeDB25, "681200", "Development of building projects"},
+		{SchemeSNI2025, "60310", "News agency activities"},
+		{SchemeNACE21, "95.31", "Repair and maintenance of motor vehicles"},
+		{SchemeNACE21, "63.9", "Web search portal activities and other information service activities"},
+	}
+	for _, tt := range tests {
+		got, err := Lookup(tt.scheme, tt.code)
+		if err != nil {
+			t.Errorf("Lookup(%s, %s): %v", tt.scheme, tt.code, err)
+			continue
+		}
+		if got.Label != tt.want {
+			t.Errorf("Lookup(%s, %s).Label = %q, want %q", tt.scheme, tt.code, got.Label, tt.want)
+		}
+	}
+}
+
+func TestLookup_SplitDivisions(t *testing.T) {
+	for _, code := range []string{"41.10", "45.20", "63.91", "70.21"} {
+		got, err := Lookup(SchemeNACE, code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Rev21Division != "" || got.Rev21Section != "" {
+			t.Errorf("Lookup(%s) maps to Rev.2.1 %s/%s, want no single equivalent", code, got.Rev21Section, got.Rev21Division)
+		}
+	}
+}
+
+func TestLookup_Errors(t *testing.T) {
+	tests := []struct {
+		scheme, code string
+	}{
+		{"isic", "62"},
+		{SchemeNACE, "6"},
+		{SchemeNACE, "62010"},    // too deep for plain NACE
+		{SchemeSN2007, "62.01a"}, // not digits
+		{SchemeSN2007, "00.000"}, // unspecified
+		{SchemeDB07, "999999"},   // unspecified
+		{SchemeNACE, "04"},       // no such division
+		{SchemeNACE21, "45"},     // removed in Rev.2.1
+		{SchemeNACE, "V"},        // Rev.2.1 section only
+		{SchemeNACE, "47.0"},     // no such group
+		{SchemeSN2007, "47.130"}, // no such class
+		{SchemeSN2025, "62.500"}, // no such group in Rev.2.1
+	}
+	for _, tt := range tests {
+		if _, err := Lookup(tt.scheme, tt.code); !apierrors.IsValidation(err) {
+			t.Errorf("Lookup(%s, %s): expected validation error, got %v", tt.scheme, tt.code, err)
+		}
+	}
+}
+
+func TestFromNational(t *testing.T) {
+	c := FromNational(SchemeSN2007, "06.100", " Utvinning av råolje ")
+	if c == nil || c.NationalLabel != "Utvinning av råolje" {
+		t.Errorf("FromNational = %+v", c)
+	}
+	for _, code := range []string{"", "00.000", "garbage"} {
+		if c := FromNational(SchemeSN2007, code, "x"); c != nil {
+			t.Errorf("FromNational(%q) = %+v, want nil", code, *c)
+		}
+	}
+}
+
+func TestFromRegistry(t *testing.T) {
+	tests := []struct {
+		code, scheme, version, nace string
+	}{
+		{"62.010", SchemeSN2007, Rev2, "62.01"},  // SN2007 only
+		{"62.100", SchemeSN2025, Rev21, "62.10"}, // SN2025 only
+		{"06.100", "", Rev2, "06.10"},            // valid in both
+	}
+	for _, tt := range tests {
+		c := FromRegistry(SchemeSN2007, SchemeSN2025, tt.code, "x")
+		if c == nil || c.NationalScheme != tt.scheme || c.Version != tt.version || c.Code != tt.nace {
+			t.Errorf("FromRegistry(%s) = %+v, want %s %s in Rev.%s", tt.code, c, tt.scheme, tt.nace, tt.version)
+		}
+	}
+	if c := FromRegistry(SchemeSN2007, SchemeSN2025, "62.500", "x"); c != nil {
+		t.Errorf("FromRegistry(62.500) = %+v, want nil", *c)
+	}
+}
+
+func TestSchemeByName(t *testing.T) {
+	for name, want := range map[string]string{"TOL2008": SchemeTOL2008, "TOL 2025": SchemeTOL2025, "sni2007": SchemeSNI2007} {
+		if s, ok := SchemeByName(name); !ok || s.ID != want {
+			t.Errorf("SchemeByName(%q) = %v, %v, want %s", name, s.ID, ok, want)
+		}
+	}
+	if _, ok := SchemeByName("TOL1995"); ok {
+		t.Error("SchemeByName(TOL1995) should not match")
+	}
+}
+
+func TestSections(t *testing.T) {
+	sections := Sections(Rev2)
+	if len(sections) != 21 {
+		t.Fatalf("len(Sections) = %d, want 21", len(sections))
+	}
+	divisions := 0
+	for _, s := range sections {
+		if len(s.Divisions) == 0 {
+			t.Errorf("section %s has no divisions", s.Code)
+		}
+		divisions += len(s.Divisions)
+	}
+	if divisions != 88 {
+		t.Errorf("divisions = %d, want 88", divisions)
+	}
+	if len(Sections("3")) != 0 {
+		t.Error("unknown revision should have no sections")
+	}
+}
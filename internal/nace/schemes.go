@@ -0,0 +1,66 @@
+package nace
+
+import (
+	"sort"
+	"strings"
+)
+
+// Scheme identifiers accepted by Lookup.
+const (
+	SchemeNACE    = "nace"    // NACE Rev.2
+	SchemeNACE21  = "nace2.1" // NACE Rev.2.1
+	SchemeSN2007  = "sn2007"  // Norway, Standard for næringsgruppering 2007
+	SchemeSN2025  = "sn2025"  // Norway, Standard for næringsgruppering 2025
+	SchemeDB07    = "db07"    // Denmark, Dansk Branchekode 2007
+	SchemeDB25    = "db25"    // Denmark, Dansk Branchekode 2025
+	SchemeTOL2008 = "tol2008" // Finland, Toimialaluokitus 2008
+	SchemeTOL2025 = "tol2025" // Finland, Toimialaluokitus 2025
+	SchemeSNI2007 = "sni2007" // Sweden, Standard för svensk näringsgrensindelning 2007
+	SchemeSNI2025 = "sni2025" // Sweden, Standard för svensk näringsgrensindelning 2025
+)
+
+// Scheme describes one industry classification.
+type Scheme struct {
+	ID          string          `json:"id" jsonschema:"Scheme identifier, e.g. sn2007"`
+	Name        string          `json:"name" jsonschema:"Scheme name"`
+	Country     string          `json:"country,omitempty" jsonschema:"Country using the scheme: norway, denmark, finland or sweden; empty for NACE"`
+	Version     string          `json:"nace_version" jsonschema:"NACE revision the scheme extends: 2 or 2.1"`
+	Digits      int             `json:"digits" jsonschema:"Digits in the most detailed code"`
+	Unspecified map[string]bool `json:"-"` // National codes meaning "industry not specified"
+}
+
+var schemes = map[string]Scheme{
+	SchemeNACE:    {ID: SchemeNACE, Name: "NACE Rev.2", Version: Rev2, Digits: 4},
+	SchemeNACE21:  {ID: SchemeNACE21, Name: "NACE Rev.2.1", Version: Rev21, Digits: 4},
+	SchemeSN2007:  {ID: SchemeSN2007, Name: "SN2007", Country: "norway", Version: Rev2, Digits: 5},
+	SchemeSN2025:  {ID: SchemeSN2025, Name: "SN2025", Country: "norway", Version: Rev21, Digits: 5},
+	SchemeDB07:    {ID: SchemeDB07, Name: "DB07", Country: "denmark", Version: Rev2, Digits: 6, Unspecified: map[string]bool{"999999": true}},
+	SchemeDB25:    {ID: SchemeDB25, Name: "DB25", Country: "denmark", Version: Rev21, Digits: 6, Unspecified: map[string]bool{"999999": true}},
+	SchemeTOL2008: {ID: SchemeTOL2008, Name: "TOL 2008", Country: "finland", Version: Rev2, Digits: 5},
+	SchemeTOL2025: {ID: SchemeTOL2025, Name: "TOL 2025", Country: "finland", Version: Rev21, Digits: 5},
+	SchemeSNI2007: {ID: SchemeSNI2007, Name: "SNI 2007", Country: "sweden", Version: Rev2, Digits: 5},
+	SchemeSNI2025: {ID: SchemeSNI2025, Name: "SNI 2025", Country: "sweden", Version: Rev21, Digits: 5},
+}
+
+// SchemeIDs returns the accepted scheme identifiers in sorted order.
+func SchemeIDs() []string {
+	ids := make([]string, 0, len(schemes))
+	for id := range schemes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// SchemeFor returns the scheme with the given identifier.
+func SchemeFor(id string) (Scheme, bool) {
+	s, ok := schemes[id]
+	return s, ok
+}
+
+// SchemeByName returns the scheme a registry names, e.g. "TOL2008" or
+// "TOL 2025", ignoring case and spaces.
+func SchemeByName(name string) (Scheme, bool) {
+	s, ok := schemes[strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), " ", ""))]
+	return s, ok
+}
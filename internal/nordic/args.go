@@ -0,0 +1,170 @@
+package nordic
+
+import (
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/legalform"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/nace"
+)
+
+// Tag convention: see internal/norway/args.go. The jsonschema tag value is the
+// property description; a field is required unless its json tag has omitempty.
+
+// MaxIdentifiers is the maximum number of identifiers accepted per call.
+const MaxIdentifiers = 5000
+
+// ValidateIdentifiersArgs contains parameters for bulk identifier validation
+type ValidateIdentifiersArgs struct {
+	Identifiers    []string `json:"identifiers" jsonschema:"Company identifiers to validate, max 5000, in any mix of countries: Norwegian org numbers (9 digits), Danish CVR (8 digits), Finnish business IDs (1234567-8), Swedish org/personal numbers (10 or 12 digits). Spaces, dots, dashes and VAT forms such as NO923609016MVA or SE556012579001 are accepted"`
+	DefaultCountry string   `json:"default_country,omitempty" jsonschema:"Country to assume for ambiguous input: norway, denmark, finland or sweden. Mainly decides bare 8-digit numbers, which can be a Danish CVR or a Finnish business ID without its hyphen; when omitted the checksum decides, preferring Denmark"`
+	CheckRegistry  bool     `json:"check_registry,omitempty" jsonschema:"Also look up every well-formed identifier in its registry and report whether it exists and is active (default false: offline format and checksum checks only). Swedish lookups require Bolagsverket credentials"`
+	IssuesOnly     bool     `json:"issues_only,omitempty" jsonschema:"Return only identifiers with a problem: malformed, not found, inactive or failed lookup (default false). The summary always counts every identifier"`
+}
+
+// ValidateIdentifiersResult is the result of bulk identifier validation
+type ValidateIdentifiersResult struct {
+	Results []IdentifierResult `json:"results" jsonschema:"One entry per input identifier, in input order; only problem entries when issues_only=true"`
+	Summary ValidationSummary  `json:"summary" jsonschema:"Counts across all input identifiers"`
+}
+
+// IdentifierResult reports the outcome for one input identifier
+type IdentifierResult struct {
+	Input         string          `json:"input" jsonschema:"Identifier as given"`
+	Country       string          `json:"country,omitempty" jsonschema:"Detected country: norway, denmark, finland or sweden"`
+	Normalized    string          `json:"normalized,omitempty" jsonschema:"Identifier in the form the country's get_company tool accepts"`
+	Type          string          `json:"type,omitempty" jsonschema:"Identifier type: org_number, cvr, business_id or personal_number"`
+	Valid         bool            `json:"valid" jsonschema:"Whether the identifier is well-formed with a correct check digit"`
+	ChecksumValid bool            `json:"checksum_valid" jsonschema:"Whether the check digit is correct"`
+	Problem       string          `json:"problem,omitempty" jsonschema:"What is wrong with an invalid identifier"`
+	Registry      *RegistryStatus `json:"registry,omitempty" jsonschema:"Registry lookup outcome, present when check_registry=true and the identifier is valid"`
+}
+
+// RegistryStatus is the registry lookup outcome for a valid identifier
+type RegistryStatus struct {
+	Exists bool   `json:"exists" jsonschema:"Whether the registry has a company with this identifier"`
+	Active bool   `json:"active" jsonschema:"Whether the company is active"`
+	Name   string `json:"name,omitempty" jsonschema:"Registered company name"`
+	Status string `json:"status,omitempty" jsonschema:"Registry status as reported by the country's get_company tool"`
+	Error  string `json:"error,omitempty" jsonschema:"Why the lookup failed; exists and active are then unknown"`
+}
+
+// ValidationSummary counts outcomes across all identifiers
+type ValidationSummary struct {
+	Total          int `json:"total" jsonschema:"Number of input identifiers"`
+	Valid          int `json:"valid" jsonschema:"Well-formed identifiers"`
+	Malformed      int `json:"malformed" jsonschema:"Identifiers failing the format or checksum checks"`
+	NotFound       int `json:"not_found" jsonschema:"Valid identifiers unknown to the registry"`
+	Inactive       int `json:"inactive" jsonschema:"Identifiers of companies that are not active"`
+	RegistryErrors int `json:"registry_errors" jsonschema:"Registry lookups that failed"`
+}
+
+// hasIssue reports whether r should be kept when issues_only is set.
+func (r IdentifierResult) hasIssue() bool {
+	if !r.Valid {
+		return true
+	}
+	if r.Registry == nil {
+		return false
+	}
+	return r.Registry.Error != "" || !r.Registry.Exists || !r.Registry.Active
+}
+
+// LogAttrs returns structured-log attributes for the validation request.
+func (a ValidateIdentifiersArgs) LogAttrs() []any {
+	return []any{"identifiers_count", len(a.Identifiers), "check_registry", a.CheckRegistry}
+}
+
+// LogAttrs returns structured-log attributes for the validation result.
+func (r ValidateIdentifiersResult) LogAttrs() []any {
+	return []any{
+		"total", r.Summary.Total,
+		"malformed", r.Summary.Malformed,
+		"not_found", r.Summary.NotFound,
+		"inactive", r.Summary.Inactive,
+	}
+}
+
+// CheckVATArgs contains parameters for a VAT-number check
+type CheckVATArgs struct {
+	VATNumber string `json:"vat_number" jsonschema:"VAT number to check, e.g. NO923609016MVA, DK10150817, FI01120389 or SE556012579001. Spaces, dots and dashes are ignored; a bare organization number, CVR or business ID is also accepted"`
+	Country   string `json:"country,omitempty" jsonschema:"Country to assume when the number has no country prefix: norway, denmark, finland or sweden. Mainly decides bare 8-digit numbers, which can be Danish or Finnish"`
+
+	infra.CacheControl
+}
+
+// CheckVATResult is the result of a VAT-number check
+type CheckVATResult struct {
+	VATNumber         string `json:"vat_number" jsonschema:"Canonical VAT number, e.g. DK10150817"`
+	Country           string `json:"country" jsonschema:"Country of the VAT number: norway, denmark, finland or sweden"`
+	Valid             bool   `json:"valid" jsonschema:"Whether the VAT registration is live"`
+	Source            string `json:"source" jsonschema:"Register consulted: vies, or brreg for Norway"`
+	RegisteredName    string `json:"registered_name,omitempty" jsonschema:"Name held by the VAT register, when disclosed"`
+	RegisteredAddress string `json:"registered_address,omitempty" jsonschema:"Address held by the VAT register, when disclosed"`
+	RequestDate       string `json:"request_date,omitempty" jsonschema:"VIES consultation timestamp"`
+	RegistryID        string `json:"registry_id" jsonschema:"Identifier accepted by the country's get_company tool"`
+	RegistryName      string `json:"registry_name,omitempty" jsonschema:"Name in the national company registry"`
+	RegistryStatus    string `json:"registry_status,omitempty" jsonschema:"Status as reported by the country's get_company tool"`
+	RegistryError     string `json:"registry_error,omitempty" jsonschema:"Why the registry lookup failed; name_match is then unknown"`
+	NameMatch         string `json:"name_match" jsonschema:"VAT name compared with the registry name: exact, similar, mismatch or unknown"`
+	Message           string `json:"message,omitempty" jsonschema:"The outcome that most needs attention, in words"`
+
+	infra.Freshness
+}
+
+// LogAttrs returns structured-log attributes for the VAT check request.
+func (a CheckVATArgs) LogAttrs() []any {
+	return []any{"vat_number", a.VATNumber}
+}
+
+// LogAttrs returns structured-log attributes for the VAT check result.
+func (r CheckVATResult) LogAttrs() []any {
+	return []any{"country", r.Country, "valid", r.Valid, "name_match", r.NameMatch}
+}
+
+// LookupIndustryCodeArgs contains parameters for an industry-code lookup
+type LookupIndustryCodeArgs struct {
+	Code   string `json:"code,omitempty" jsonschema:"Industry code to resolve: a NACE section letter (J), a NACE code (62.01) or a national code (SN2007 62.010, DB07 620100, TOL 2008 62010, SNI 2007 62010). Dots and spaces are ignored. Omit to list the NACE sections"`
+	Scheme string `json:"scheme,omitempty" jsonschema:"Classification the code belongs to: nace (default, Rev.2), nace2.1, sn2007, sn2025, db07, db25, tol2008, tol2025, sni2007 or sni2025"`
+}
+
+// LookupIndustryCodeResult is the result of an industry-code lookup
+type LookupIndustryCodeResult struct {
+	Scheme         nace.Scheme  `json:"scheme" jsonschema:"Classification the code was resolved in"`
+	Classification *nace.Code   `json:"classification,omitempty" jsonschema:"The code mapped to NACE; omitted when listing sections"`
+	Children       []nace.Entry `json:"children,omitempty" jsonschema:"Codes directly below the matched one (divisions of a section, groups of a division, classes of a group), or all sections when no code was given"`
+}
+
+// LogAttrs returns structured-log attributes for the industry-code lookup request.
+func (a LookupIndustryCodeArgs) LogAttrs() []any {
+	return []any{"code", a.Code, "scheme", a.Scheme}
+}
+
+// LogAttrs returns structured-log attributes for the industry-code lookup result.
+func (r LookupIndustryCodeResult) LogAttrs() []any {
+	attrs := []any{"scheme", r.Scheme.ID, "children", len(r.Children)}
+	if r.Classification != nil {
+		attrs = append(attrs, "nace", r.Classification.Code)
+	}
+	return attrs
+}
+
+// ListLegalFormsArgs contains parameters for listing legal forms
+type ListLegalFormsArgs struct {
+	Country  string `json:"country,omitempty" jsonschema:"Only forms of this country: norway, denmark, finland or sweden. Omit for all four"`
+	Category string `json:"category,omitempty" jsonschema:"Only forms in this category: limited, public_limited, partnership, sole_trader, cooperative, association, foundation or branch_of_foreign"`
+}
+
+// ListLegalFormsResult is the result of listing legal forms
+type ListLegalFormsResult struct {
+	Forms []legalform.Form `json:"forms" jsonschema:"Legal forms matching the filters"`
+	Count int              `json:"count" jsonschema:"Number of legal forms"`
+}
+
+// LogAttrs returns structured-log attributes for the legal-form list request.
+func (a ListLegalFormsArgs) LogAttrs() []any {
+	return []any{"country", a.Country, "category", a.Category}
+}
+
+// LogAttrs returns structured-log attributes for the legal-form list result.
+func (r ListLegalFormsResult) LogAttrs() []any {
+	return []any{"count", r.Count}
+}
@@ -0,0 +1,71 @@
+// Package nordic implements tools that span more than one Nordic registry,
+// such as bulk identifier validation across Norway, Denmark, Finland and
+// Sweden and VAT-number checks against EU VIES. It holds no HTTP state of its
+// own: every call goes through the per-country clients or the VIES client, so
+// caching, deduplication, circuit breaking and concurrency limits are shared
+// with the single-country tools.
+package nordic
+
+import (
+	"log/slog"
+
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/base"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/denmark"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/finland"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/norway"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/sweden"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/vies"
+)
+
+// Country names used in results and the default_country argument. They match
+// the ToolSpec.Country values of the single-country tools.
+const (
+	CountryNorway  = "norway"
+	CountryDenmark = "denmark"
+	CountryFinland = "finland"
+	CountrySweden  = "sweden"
+)
+
+// registryWorkers bounds concurrent single-company lookups per country. It
+// matches the base client's semaphore so a bulk run queues behind the shared
+// limit instead of piling up goroutines waiting on it.
+const registryWorkers = base.MaxConcurrentRequests
+
+// Client fans cross-registry requests out to the per-country clients.
+type Client struct {
+	norway  *norway.Client
+	denmark *denmark.Client
+	finland *finland.Client
+	sweden  *sweden.Client // May be nil if OAuth2 credentials not configured
+	vies    *vies.Client   // May be nil; VAT checks outside Norway then fail
+	logger  *slog.Logger
+}
+
+// Config bundles the per-country clients supplied to NewClient. Sweden may be
+// nil when Bolagsverket OAuth credentials are not configured; Swedish
+// identifiers are then still validated offline but not looked up. VIES
+// answers VAT checks for Denmark, Finland and Sweden.
+type Config struct {
+	Norway  *norway.Client
+	Denmark *denmark.Client
+	Finland *finland.Client
+	Sweden  *sweden.Client
+	VIES    *vies.Client
+	Logger  *slog.Logger
+}
+
+// NewClient creates a cross-registry client.
+func NewClient(cfg Config) *Client {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Client{
+		norway:  cfg.Norway,
+		denmark: cfg.Denmark,
+		finland: cfg.Finland,
+		sweden:  cfg.Sweden,
+		vies:    cfg.VIES,
+		logger:  logger,
+	}
+}
@@ -0,0 +1,204 @@
+package nordic
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/denmark"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/finland"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/norway"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/sweden"
+)
+
+// Identifier types reported in IdentifierResult.Type.
+const (
+	TypeOrgNumber      = "org_number"      // Norwegian or Swedish organization number
+	TypeCVR            = "cvr"             // Danish CVR number
+	TypeBusinessID     = "business_id"     // Finnish Y-tunnus
+	TypePersonalNumber = "personal_number" // Swedish personnummer of a sole proprietor
+)
+
+// countryPrefixes maps ISO country prefixes (as used in VAT numbers) to
+// country names.
+var countryPrefixes = map[string]string{
+	"NO": CountryNorway,
+	"DK": CountryDenmark,
+	"FI": CountryFinland,
+	"SE": CountrySweden,
+}
+
+var (
+	// Hyphenated layouts that identify the country on their own.
+	finnishLayout = regexp.MustCompile(`^\d{7}-\d$`)
+	swedishLayout = regexp.MustCompile(`^(\d{2})?\d{6}-\d{4}$`)
+
+	digitsOnly = regexp.MustCompile(`^\d+$`)
+)
+
+// ValidCountry reports whether country is one of the supported country names.
+func ValidCountry(country string) bool {
+	switch country {
+	case CountryNorway, CountryDenmark, CountryFinland, CountrySweden:
+		return true
+	}
+	return false
+}
+
+// DetectIdentifier classifies a raw identifier, normalizes it to the form the
+// country's registry expects and verifies its check digit. It never calls a
+// registry.
+//
+// Spaces, dots and dashes are ignored, and VAT-style forms (NO...MVA,
+// DK..., FI........, SE..........01) are accepted. A bare 8-digit number is
+// ambiguous between a Danish CVR number and a Finnish business ID without its
+// hyphen; defaultCountry decides when set, otherwise whichever checksum
+// passes wins, with Denmark preferred.
+func DetectIdentifier(input, defaultCountry string) IdentifierResult {
+	res := IdentifierResult{Input: input}
+
+	s := strings.ToUpper(strings.TrimSpace(input))
+	s = strings.NewReplacer(" ", "", ".", "").Replace(s)
+	if s == "" {
+		res.Problem = "empty identifier"
+		return res
+	}
+
+	country := ""
+	if len(s) > 2 {
+		if c, ok := countryPrefixes[s[:2]]; ok {
+			country = c
+			s = strings.TrimPrefix(s[2:], "-")
+		}
+	}
+	if country == CountryNorway {
+		s = strings.TrimSuffix(s, "MVA")
+	}
+	if country == CountrySweden && len(s) == 12 && strings.HasSuffix(s, "01") && digitsOnly.MatchString(s) {
+		// Swedish VAT number: SE + organization number + "01".
+		s = s[:10]
+	}
+
+	if country == "" {
+		country = detectCountry(s, defaultCountry)
+	}
+	digits := strings.ReplaceAll(s, "-", "")
+	if country == "" || !digitsOnly.MatchString(digits) {
+		res.Problem = "unrecognized identifier format"
+		return res
+	}
+
+	res.Country = country
+	switch country {
+	case CountryNorway:
+		checkNorway(&res, digits)
+	case CountryDenmark:
+		checkDenmark(&res, digits)
+	case CountryFinland:
+		checkFinland(&res, digits)
+	case CountrySweden:
+		checkSweden(&res, digits)
+	}
+	res.Valid = res.Problem == ""
+	return res
+}
+
+// detectCountry infers the country of an identifier that carried no country
+// prefix. It returns "" when the shape matches no supported registry.
+func detectCountry(s, defaultCountry string) string {
+	switch {
+	case finnishLayout.MatchString(s):
+		return CountryFinland
+	case swedishLayout.MatchString(s):
+		return CountrySweden
+	}
+
+	digits := strings.ReplaceAll(s, "-", "")
+	if !digitsOnly.MatchString(digits) {
+		return ""
+	}
+	switch len(digits) {
+	case 9:
+		return CountryNorway
+	case 10, 12:
+		return CountrySweden
+	case 8:
+		if defaultCountry == CountryDenmark || defaultCountry == CountryFinland {
+			return defaultCountry
+		}
+		if denmark.ValidCVRChecksum(digits) {
+			return CountryDenmark
+		}
+		if finland.ValidateBusinessID(digits[:7]+"-"+digits[7:]) == nil {
+			return CountryFinland
+		}
+		return CountryDenmark
+	}
+	return defaultCountry
+}
+
+func checkNorway(res *IdentifierResult, digits string) {
+	res.Type = TypeOrgNumber
+	res.Normalized = digits
+	if len(digits) != 9 {
+		res.Problem = "expected 9 digits for a Norwegian organization number"
+		return
+	}
+	res.ChecksumValid = norway.ValidOrgNumberChecksum(digits)
+	if !res.ChecksumValid {
+		res.Problem = "check digit mismatch"
+	}
+}
+
+func checkDenmark(res *IdentifierResult, digits string) {
+	res.Type = TypeCVR
+	res.Normalized = digits
+	if len(digits) != 8 {
+		res.Problem = "expected 8 digits for a Danish CVR number"
+		return
+	}
+	res.ChecksumValid = denmark.ValidCVRChecksum(digits)
+	if !res.ChecksumValid {
+		res.Problem = "check digit mismatch"
+	}
+}
+
+func checkFinland(res *IdentifierResult, digits string) {
+	res.Type = TypeBusinessID
+	res.Normalized = digits
+	if len(digits) != 8 {
+		res.Problem = "expected 8 digits for a Finnish business ID"
+		return
+	}
+	res.Normalized = digits[:7] + "-" + digits[7:]
+	res.ChecksumValid = finland.ValidateBusinessID(res.Normalized) == nil
+	if !res.ChecksumValid {
+		res.Problem = "check digit mismatch"
+	}
+}
+
+func checkSweden(res *IdentifierResult, digits string) {
+	res.Type = TypeOrgNumber
+	res.Normalized = digits
+	switch len(digits) {
+	case 10:
+		// Organization numbers have a third digit of 2 or more, which no
+		// birth month in a personal number can have.
+		if digits[2] < '2' {
+			res.Type = TypePersonalNumber
+		}
+	case 12:
+		res.Type = TypePersonalNumber
+		if strings.HasPrefix(digits, "16") {
+			// Organization number written with Bolagsverket's "16" century prefix.
+			res.Type = TypeOrgNumber
+			res.Normalized = digits[2:]
+		}
+	default:
+		res.Problem = "expected 10 or 12 digits for a Swedish organization or personal number"
+		return
+	}
+	res.ChecksumValid = sweden.ValidOrgNumberChecksum(res.Normalized)
+	if !res.ChecksumValid {
+		res.Problem = "check digit mismatch"
+	}
+}
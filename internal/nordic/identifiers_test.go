@@ -0,0 +1,71 @@
+package nordic
+
+import "testing"
+
+func TestDetectIdentifier(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          string
+		defaultCountry string
+		wantCountry    string
+		wantNormalized string
+		wantType       string
+		wantValid      bool
+	}{
+		{"norway org number", "923609016", "", CountryNorway, "923609016", TypeOrgNumber, true},
+		{"norway VAT form", "NO 923 609 016 MVA", "", CountryNorway, "923609016", TypeOrgNumber, true},
+		{"norway bad check digit", "923609017", "", CountryNorway, "923609017", TypeOrgNumber, false},
+		{"denmark with prefix", "DK-10150817", "", CountryDenmark, "10150817", TypeCVR, true},
+		{"denmark bare", "10150817", "", CountryDenmark, "10150817", TypeCVR, true},
+		{"finland hyphenated", "0112038-9", "", CountryFinland, "0112038-9", TypeBusinessID, true},
+		{"finland VAT form", "FI01120389", "", CountryFinland, "0112038-9", TypeBusinessID, true},
+		{"finland bare by checksum", "01120389", "", CountryFinland, "0112038-9", TypeBusinessID, true},
+		{"8 digits forced to denmark", "01120389", CountryDenmark, CountryDenmark, "01120389", TypeCVR, false},
+		{"sweden org number", "556012-5790", "", CountrySweden, "5560125790", TypeOrgNumber, true},
+		{"sweden VAT form", "SE556012579001", "", CountrySweden, "5560125790", TypeOrgNumber, true},
+		{"sweden century-prefixed org number", "165560125790", "", CountrySweden, "5560125790", TypeOrgNumber, true},
+		{"sweden personal number", "8112189876", "", CountrySweden, "8112189876", TypePersonalNumber, true},
+		{"sweden 12-digit personal number", "19811218-9876", "", CountrySweden, "198112189876", TypePersonalNumber, true},
+		{"sweden prefix wrong length", "SE1234", "", CountrySweden, "1234", TypeOrgNumber, false},
+		{"unrecognized text", "hello", "", "", "", "", false},
+		{"unrecognized length", "12345", "", "", "", "", false},
+		{"empty", "  ", "", "", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DetectIdentifier(tt.input, tt.defaultCountry)
+			if got.Input != tt.input {
+				t.Errorf("Input = %q, want %q", got.Input, tt.input)
+			}
+			if got.Country != tt.wantCountry {
+				t.Errorf("Country = %q, want %q", got.Country, tt.wantCountry)
+			}
+			if got.Normalized != tt.wantNormalized {
+				t.Errorf("Normalized = %q, want %q", got.Normalized, tt.wantNormalized)
+			}
+			if got.Type != tt.wantType {
+				t.Errorf("Type = %q, want %q", got.Type, tt.wantType)
+			}
+			if got.Valid != tt.wantValid {
+				t.Errorf("Valid = %v, want %v (problem %q)", got.Valid, tt.wantValid, got.Problem)
+			}
+			if got.Valid == (got.Problem != "") {
+				t.Errorf("Valid = %v but Problem = %q", got.Valid, got.Problem)
+			}
+		})
+	}
+}
+
+func TestValidCountry(t *testing.T) {
+	for _, c := range []string{CountryNorway, CountryDenmark, CountryFinland, CountrySweden} {
+		if !ValidCountry(c) {
+			t.Errorf("ValidCountry(%q) = false, want true", c)
+		}
+	}
+	for _, c := range []string{"", "iceland", "NO"} {
+		if ValidCountry(c) {
+			t.Errorf("ValidCountry(%q) = true, want false", c)
+		}
+	}
+}
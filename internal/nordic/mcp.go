@@ -0,0 +1,148 @@
+package nordic
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/legalform"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/nace"
+)
+
+// ValidateIdentifiersMCP is the MCP wrapper for bulk identifier validation.
+// Results keep the caller's input order; duplicates are looked up once.
+func (c *Client) ValidateIdentifiersMCP(ctx context.Context, args ValidateIdentifiersArgs) (ValidateIdentifiersResult, error) {
+	if len(args.Identifiers) == 0 {
+		return ValidateIdentifiersResult{}, apierrors.NewValidationError("identifiers", "", "is required")
+	}
+	if len(args.Identifiers) > MaxIdentifiers {
+		return ValidateIdentifiersResult{}, apierrors.NewValidationError("identifiers", fmt.Sprintf("%d items", len(args.Identifiers)),
+			fmt.Sprintf("exceeds maximum of %d identifiers per call", MaxIdentifiers))
+	}
+	if args.DefaultCountry != "" && !ValidCountry(args.DefaultCountry) {
+		return ValidateIdentifiersResult{}, apierrors.NewValidationError("default_country", args.DefaultCountry,
+			"must be one of norway, denmark, finland, sweden")
+	}
+
+	results := make([]IdentifierResult, len(args.Identifiers))
+	for i, id := range args.Identifiers {
+		results[i] = DetectIdentifier(id, args.DefaultCountry)
+	}
+
+	if args.CheckRegistry {
+		statuses := c.checkRegistries(ctx, results)
+		for i := range results {
+			if !results[i].Valid {
+				continue
+			}
+			if st, ok := statuses[registryKey{results[i].Country, results[i].Normalized}]; ok {
+				results[i].Registry = &st
+			}
+		}
+	}
+
+	out := ValidateIdentifiersResult{Summary: summarize(results), Results: results}
+	if args.IssuesOnly {
+		out.Results = make([]IdentifierResult, 0, out.Summary.Malformed)
+		for _, r := range results {
+			if r.hasIssue() {
+				out.Results = append(out.Results, r)
+			}
+		}
+	}
+	return out, nil
+}
+
+// summarize counts outcomes over every result.
+func summarize(results []IdentifierResult) ValidationSummary {
+	s := ValidationSummary{Total: len(results)}
+	for _, r := range results {
+		if !r.Valid {
+			s.Malformed++
+			continue
+		}
+		s.Valid++
+		switch {
+		case r.Registry == nil:
+		case r.Registry.Error != "":
+			s.RegistryErrors++
+		case !r.Registry.Exists:
+			s.NotFound++
+		case !r.Registry.Active:
+			s.Inactive++
+		}
+	}
+	return s
+}
+
+// CheckVATMCP is the MCP wrapper for a VAT-number check. Danish, Finnish and
+// Swedish numbers are checked in VIES and the returned name is compared with
+// the national registry; Norwegian numbers are checked in Enhetsregisteret.
+func (c *Client) CheckVATMCP(ctx context.Context, args CheckVATArgs) (CheckVATResult, error) {
+	if strings.TrimSpace(args.VATNumber) == "" {
+		return CheckVATResult{}, apierrors.NewValidationError("vat_number", "", "is required")
+	}
+	if args.Country != "" && !ValidCountry(args.Country) {
+		return CheckVATResult{}, apierrors.NewValidationError("country", args.Country,
+			"must be one of norway, denmark, finland, sweden")
+	}
+
+	id := DetectIdentifier(args.VATNumber, args.Country)
+	if !id.Valid {
+		return CheckVATResult{}, apierrors.NewValidationError("vat_number", args.VATNumber, id.Problem)
+	}
+	if id.Country == CountryNorway {
+		return c.checkNorwegianVAT(ctx, id.Normalized)
+	}
+	return c.checkVIESVAT(ctx, id)
+}
+
+// LookupIndustryCodeMCP resolves an industry code from any Nordic national
+// classification, or NACE itself, to canonical NACE. It works offline from
+// the embedded tables; the client is only the receiver the handler registry
+// expects.
+func (c *Client) LookupIndustryCodeMCP(_ context.Context, args LookupIndustryCodeArgs) (LookupIndustryCodeResult, error) {
+	schemeID := strings.ToLower(strings.TrimSpace(args.Scheme))
+	if schemeID == "" {
+		schemeID = nace.SchemeNACE
+	}
+	scheme, ok := nace.SchemeFor(schemeID)
+	if !ok {
+		return LookupIndustryCodeResult{}, apierrors.NewValidationError("scheme", args.Scheme,
+			"must be one of "+strings.Join(nace.SchemeIDs(), ", "))
+	}
+
+	if strings.TrimSpace(args.Code) == "" {
+		return LookupIndustryCodeResult{Scheme: scheme, Children: nace.Children(scheme.Version, "")}, nil
+	}
+	code, err := nace.Lookup(scheme.ID, args.Code)
+	if err != nil {
+		return LookupIndustryCodeResult{}, err
+	}
+	return LookupIndustryCodeResult{
+		Scheme:         scheme,
+		Classification: code,
+		Children:       nace.Children(code.Version, code.Code),
+	}, nil
+}
+
+// ListLegalFormsMCP lists the legal forms of the four registries with their
+// common category, liability type and ELF code. Like the industry lookup it
+// answers from embedded data.
+func (c *Client) ListLegalFormsMCP(_ context.Context, args ListLegalFormsArgs) (ListLegalFormsResult, error) {
+	country := strings.ToLower(strings.TrimSpace(args.Country))
+	if country != "" && !ValidCountry(country) {
+		return ListLegalFormsResult{}, apierrors.NewValidationError("country", args.Country,
+			"must be one of norway, denmark, finland, sweden")
+	}
+	category := strings.ToLower(strings.TrimSpace(args.Category))
+	if category != "" && !slices.Contains(legalform.Categories(), category) {
+		return ListLegalFormsResult{}, apierrors.NewValidationError("category", args.Category,
+			"must be one of "+strings.Join(legalform.Categories(), ", "))
+	}
+
+	forms := legalform.List(country, category)
+	return ListLegalFormsResult{Forms: forms, Count: len(forms)}, nil
+}
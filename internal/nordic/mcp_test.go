@@ -0,0 +1,295 @@
+package nordic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/denmark"
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/finland"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/norway"
+)
+
+// newTestClient wires a nordic Client to mock Norwegian and Danish registries.
+// Finland points at an unroutable URL and Sweden is left unconfigured.
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+
+	noServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/enheter/923609016":
+			_, _ = w.Write([]byte(`{"organisasjonsnummer":"923609016","navn":"EQUINOR ASA","registrertIMvaregisteret":true,"forretningsadresse":{"adresse":["Forusbeen 50"],"postnummer":"4035","poststed":"STAVANGER"}}`))
+			return
+		case "/enheter/914778271":
+			_, _ = w.Write([]byte(`{"organisasjonsnummer":"914778271","navn":"TELENOR ASA","konkurs":true}`))
+			return
+		case "/enheter":
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var enheter []string
+		for _, on := range strings.Split(r.URL.Query().Get("organisasjonsnummer"), ",") {
+			switch on {
+			case "923609016":
+				enheter = append(enheter, `{"organisasjonsnummer":"923609016","navn":"EQUINOR ASA"}`)
+			case "914778271":
+				enheter = append(enheter, `{"organisasjonsnummer":"914778271","navn":"TELENOR ASA","konkurs":true}`)
+			}
+		}
+		_, _ = fmt.Fprintf(w, `{"_embedded":{"enheter":[%s]}}`, strings.Join(enheter, ","))
+	}))
+	t.Cleanup(noServer.Close)
+
+	dkServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("vat") != "10150817" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"vat":10150817,"name":"NOVO NORDISK A/S","enddate":"01/01 - 2020"}`))
+	}))
+	t.Cleanup(dkServer.Close)
+
+	no := norway.NewClient(norway.WithBaseURL(noServer.URL))
+	dk := denmark.NewClient(denmark.WithBaseURL(dkServer.URL))
+	fi := finland.NewClient().WithBaseURL("http://127.0.0.1:1")
+	t.Cleanup(func() {
+		no.Close()
+		dk.Close()
+		fi.Close()
+	})
+
+	return NewClient(Config{Norway: no, Denmark: dk, Finland: fi})
+}
+
+func TestValidateIdentifiersMCP_OfflineOnly(t *testing.T) {
+	c := newTestClient(t)
+
+	result, err := c.ValidateIdentifiersMCP(context.Background(), ValidateIdentifiersArgs{
+		Identifiers: []string{"923609016", "923609017", "garbage"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(result.Results))
+	}
+	for _, r := range result.Results {
+		if r.Registry != nil {
+			t.Errorf("%q: registry checked without check_registry", r.Input)
+		}
+	}
+	want := ValidationSummary{Total: 3, Valid: 1, Malformed: 2}
+	if result.Summary != want {
+		t.Errorf("Summary = %+v, want %+v", result.Summary, want)
+	}
+}
+
+func TestValidateIdentifiersMCP_CheckRegistry(t *testing.T) {
+	c := newTestClient(t)
+
+	result, err := c.ValidateIdentifiersMCP(context.Background(), ValidateIdentifiersArgs{
+		Identifiers: []string{
+			"923609016",      // active
+			"NO914778271MVA", // bankrupt
+			"974760673",      // valid but not in mock registry
+			"923 609 016",    // duplicate of the first
+			"DK10150817",     // dissolved
+			"0112038-9",      // finland unreachable
+			"5560125790",     // sweden not configured
+			"123",            // malformed
+		},
+		CheckRegistry: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	byInput := make(map[string]IdentifierResult, len(result.Results))
+	for _, r := range result.Results {
+		byInput[r.Input] = r
+	}
+
+	checks := []struct {
+		input  string
+		exists bool
+		active bool
+		hasErr bool
+	}{
+		{"923609016", true, true, false},
+		{"NO914778271MVA", true, false, false},
+		{"974760673", false, false, false},
+		{"923 609 016", true, true, false},
+		{"DK10150817", true, false, false},
+		{"0112038-9", false, false, true},
+		{"5560125790", false, false, true},
+	}
+	for _, tc := range checks {
+		reg := byInput[tc.input].Registry
+		if reg == nil {
+			t.Errorf("%q: missing registry status", tc.input)
+			continue
+		}
+		if reg.Exists != tc.exists || reg.Active != tc.active || (reg.Error != "") != tc.hasErr {
+			t.Errorf("%q: registry = %+v, want exists=%v active=%v error=%v", tc.input, *reg, tc.exists, tc.active, tc.hasErr)
+		}
+	}
+	if byInput["123"].Registry != nil {
+		t.Error("malformed identifier should not be looked up")
+	}
+	if name := byInput["DK10150817"].Registry.Name; name != "NOVO NORDISK A/S" {
+		t.Errorf("Denmark name = %q", name)
+	}
+
+	want := ValidationSummary{Total: 8, Valid: 7, Malformed: 1, NotFound: 1, Inactive: 2, RegistryErrors: 2}
+	if result.Summary != want {
+		t.Errorf("Summary = %+v, want %+v", result.Summary, want)
+	}
+}
+
+func TestValidateIdentifiersMCP_IssuesOnly(t *testing.T) {
+	c := newTestClient(t)
+
+	result, err := c.ValidateIdentifiersMCP(context.Background(), ValidateIdentifiersArgs{
+		Identifiers:   []string{"923609016", "914778271", "923609017"},
+		CheckRegistry: true,
+		IssuesOnly:    true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Results) != 2 {
+		t.Fatalf("expected 2 issues, got %d: %+v", len(result.Results), result.Results)
+	}
+	if result.Results[0].Input != "914778271" || result.Results[1].Input != "923609017" {
+		t.Errorf("unexpected issue order: %q, %q", result.Results[0].Input, result.Results[1].Input)
+	}
+	if result.Summary.Total != 3 {
+		t.Errorf("Summary.Total = %d, want 3", result.Summary.Total)
+	}
+}
+
+func TestValidateIdentifiersMCP_ArgValidation(t *testing.T) {
+	c := newTestClient(t)
+
+	tests := []struct {
+		name string
+		args ValidateIdentifiersArgs
+	}{
+		{"empty", ValidateIdentifiersArgs{}},
+		{"too many", ValidateIdentifiersArgs{Identifiers: make([]string, MaxIdentifiers+1)}},
+		{"bad default country", ValidateIdentifiersArgs{Identifiers: []string{"923609016"}, DefaultCountry: "iceland"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.ValidateIdentifiersMCP(context.Background(), tt.args)
+			if !apierrors.IsValidation(err) {
+				t.Errorf("expected validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLookupIndustryCodeMCP(t *testing.T) {
+	c := NewClient(Config{})
+
+	tests := []struct {
+		name         string
+		args         LookupIndustryCodeArgs
+		wantCode     string
+		wantSection  string
+		wantChildren int
+	}{
+		{"sections", LookupIndustryCodeArgs{}, "", "", 21},
+		{"rev2.1 sections", LookupIndustryCodeArgs{Scheme: "nace2.1"}, "", "", 22},
+		{"section", LookupIndustryCodeArgs{Code: "j"}, "J", "J", 6},
+		{"sn2007", LookupIndustryCodeArgs{Code: "62.010", Scheme: "SN2007"}, "62.01", "J", 0},
+		{"db07", LookupIndustryCodeArgs{Code: "620100", Scheme: "db07"}, "62.01", "J", 0},
+		{"sni2025", LookupIndustryCodeArgs{Code: "62100", Scheme: "sni2025"}, "62.10", "K", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := c.LookupIndustryCodeMCP(context.Background(), tt.args)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(result.Children) != tt.wantChildren {
+				t.Errorf("len(Children) = %d, want %d", len(result.Children), tt.wantChildren)
+			}
+			if tt.wantCode == "" {
+				if result.Classification != nil {
+					t.Errorf("Classification = %+v, want nil", result.Classification)
+				}
+				return
+			}
+			if result.Classification == nil {
+				t.Fatal("Classification is nil")
+			}
+			if result.Classification.Code != tt.wantCode || result.Classification.Section != tt.wantSection {
+				t.Errorf("Classification = %s in %s, want %s in %s",
+					result.Classification.Code, result.Classification.Section, tt.wantCode, tt.wantSection)
+			}
+		})
+	}
+}
+
+func TestLookupIndustryCodeMCP_Errors(t *testing.T) {
+	c := NewClient(Config{})
+
+	for _, args := range []LookupIndustryCodeArgs{
+		{Code: "62.01", Scheme: "isic"},
+		{Code: "99.999.9", Scheme: "sn2007"},
+		{Code: "00.000", Scheme: "sn2007"},
+	} {
+		if _, err := c.LookupIndustryCodeMCP(context.Background(), args); !apierrors.IsValidation(err) {
+			t.Errorf("%+v: expected validation error, got %v", args, err)
+		}
+	}
+}
+
+func TestListLegalFormsMCP(t *testing.T) {
+	c := NewClient(Config{})
+
+	all, err := c.ListLegalFormsMCP(context.Background(), ListLegalFormsArgs{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if all.Count == 0 || all.Count != len(all.Forms) {
+		t.Fatalf("Count = %d with %d forms", all.Count, len(all.Forms))
+	}
+
+	limited, err := c.ListLegalFormsMCP(context.Background(), ListLegalFormsArgs{Category: "Limited"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	countries := make(map[string]bool)
+	for _, f := range limited.Forms {
+		if f.Category != "limited" {
+			t.Errorf("%s %s has category %q", f.Country, f.Code, f.Category)
+		}
+		countries[f.Country] = true
+	}
+	if len(countries) != 4 {
+		t.Errorf("limited forms cover %d countries, want 4", len(countries))
+	}
+
+	dk, err := c.ListLegalFormsMCP(context.Background(), ListLegalFormsArgs{Country: "denmark", Category: "public_limited"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if dk.Count != 1 || dk.Forms[0].Code != "A/S" {
+		t.Errorf("denmark public_limited = %+v, want A/S only", dk.Forms)
+	}
+
+	for _, args := range []ListLegalFormsArgs{{Country: "iceland"}, {Category: "llc"}} {
+		if _, err := c.ListLegalFormsMCP(context.Background(), args); !apierrors.IsValidation(err) {
+			t.Errorf("%+v: expected validation error, got %v", args, err)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package nordic
+
+import (
+	"context"
+	"sync"
+
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/denmark"
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/finland"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/norway"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/sweden"
+)
+
+// norwayBatchSize is the largest batch the Brønnøysund /enheter endpoint
+// accepts in one request.
+const norwayBatchSize = 2000
+
+// registryKey identifies one normalized identifier in one registry.
+type registryKey struct {
+	country    string
+	normalized string
+}
+
+// lookupFunc fetches the registry status of one normalized identifier.
+type lookupFunc func(ctx context.Context, id string) (RegistryStatus, error)
+
+// checkRegistries looks up every distinct valid identifier in its registry.
+// Norway uses the batch endpoint; the other registries take one request per
+// identifier, bounded by registryWorkers. Countries run in parallel.
+func (c *Client) checkRegistries(ctx context.Context, results []IdentifierResult) map[registryKey]RegistryStatus {
+	byCountry := make(map[string][]string)
+	seen := make(map[registryKey]bool)
+	for _, r := range results {
+		k := registryKey{r.Country, r.Normalized}
+		if !r.Valid || seen[k] {
+			continue
+		}
+		seen[k] = true
+		byCountry[r.Country] = append(byCountry[r.Country], r.Normalized)
+	}
+
+	var (
+		mu       sync.Mutex
+		statuses = make(map[registryKey]RegistryStatus, len(seen))
+		wg       sync.WaitGroup
+	)
+	progress := infra.NewProgressCounter(ctx, len(seen), "registry lookups")
+	record := func(country, id string, st RegistryStatus) {
+		mu.Lock()
+		statuses[registryKey{country, id}] = st
+		mu.Unlock()
+		progress.Add(1)
+	}
+
+	for country, ids := range byCountry {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if country == CountryNorway {
+				c.checkNorway(ctx, ids, record)
+				return
+			}
+			lookup := c.lookupFor(country)
+			infra.RunBounded(ctx, len(ids), registryWorkers, func(ctx context.Context, i int) {
+				st, err := lookup(ctx, ids[i])
+				switch {
+				case apierrors.IsNotFound(err):
+					st = RegistryStatus{}
+				case err != nil:
+					st = RegistryStatus{Error: err.Error()}
+				}
+				record(country, ids[i], st)
+			})
+		}()
+	}
+	wg.Wait()
+	return statuses
+}
+
+// checkNorway looks Norwegian org numbers up in batches.
+func (c *Client) checkNorway(ctx context.Context, ids []string, record func(country, id string, st RegistryStatus)) {
+	for start := 0; start < len(ids); start += norwayBatchSize {
+		chunk := ids[start:min(start+norwayBatchSize, len(ids))]
+		resp, err := c.norway.BatchGetCompaniesMCP(ctx, norway.BatchGetCompaniesArgs{OrgNumbers: chunk})
+		if err != nil {
+			for _, id := range chunk {
+				record(CountryNorway, id, RegistryStatus{Error: err.Error()})
+			}
+			continue
+		}
+		found := make(map[string]bool, len(resp.Companies))
+		for _, co := range resp.Companies {
+			found[co.OrganizationNumber] = true
+			record(CountryNorway, co.OrganizationNumber, RegistryStatus{
+				Exists: true,
+				Active: co.Status == "ACTIVE",
+				Name:   co.Name,
+				Status: co.Status,
+			})
+		}
+		for _, id := range chunk {
+			if !found[id] {
+				record(CountryNorway, id, RegistryStatus{})
+			}
+		}
+	}
+}
+
+// lookupFor returns the single-company lookup for a non-Norwegian registry.
+func (c *Client) lookupFor(country string) lookupFunc {
+	switch country {
+	case CountryDenmark:
+		return c.lookupDenmark
+	case CountryFinland:
+		return c.lookupFinland
+	case CountrySweden:
+		if c.sweden == nil {
+			return func(context.Context, string) (RegistryStatus, error) {
+				return RegistryStatus{Error: "Sweden registry not configured (set BOLAGSVERKET_CLIENT_ID and BOLAGSVERKET_CLIENT_SECRET)"}, nil
+			}
+		}
+		return c.lookupSweden
+	}
+	return func(context.Context, string) (RegistryStatus, error) {
+		return RegistryStatus{Error: "no registry for country " + country}, nil
+	}
+}
+
+func (c *Client) lookupDenmark(ctx context.Context, cvr string) (RegistryStatus, error) {
+	res, err := c.denmark.GetCompanyMCP(ctx, denmark.GetCompanyArgs{CVR: cvr})
+	if err != nil || res.Summary == nil {
+		return RegistryStatus{}, err
+	}
+	return RegistryStatus{
+		Exists: true,
+		Active: res.Summary.Status == "ACTIVE",
+		Name:   res.Summary.Name,
+		Status: res.Summary.Status,
+	}, nil
+}
+
+func (c *Client) lookupFinland(ctx context.Context, businessID string) (RegistryStatus, error) {
+	res, err := c.finland.GetCompanyMCP(ctx, finland.GetCompanyArgs{BusinessID: businessID})
+	if err != nil || res.Summary == nil {
+		return RegistryStatus{}, err
+	}
+	return RegistryStatus{
+		Exists: true,
+		Active: finnishStatusActive(res.Summary.Status),
+		Name:   res.Summary.Name,
+		Status: res.Summary.Status,
+	}, nil
+}
+
+// finnishStatusActive reports whether a PRH status description (see
+// finland.statusToDesc) denotes a trading company.
+func finnishStatusActive(status string) bool {
+	switch status {
+	case "Dissolved", "Liquidation", "Bankruptcy":
+		return false
+	}
+	return true
+}
+
+func (c *Client) lookupSweden(ctx context.Context, orgNumber string) (RegistryStatus, error) {
+	res, err := c.sweden.GetCompanyMCP(ctx, sweden.GetCompanyArgs{OrgNumber: orgNumber})
+	if err != nil || res.Company == nil {
+		return RegistryStatus{}, err
+	}
+	return RegistryStatus{
+		Exists: true,
+		Active: swedishStatusActive(res.Company.Status),
+		Name:   res.Company.Name,
+		Status: res.Company.Status,
+	}, nil
+}
+
+// swedishStatusActive reports whether a Bolagsverket status (see
+// sweden.getStatus) denotes a trading company; one under reconstruction
+// still trades.
+func swedishStatusActive(status string) bool {
+	return status == "ACTIVE" || status == "RECONSTRUCTION"
+}
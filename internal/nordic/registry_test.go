@@ -0,0 +1,69 @@
+package nordic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/norway"
+)
+
+// validOrgNumbers returns n org numbers with valid check digits.
+func validOrgNumbers(n int) []string {
+	var ids []string
+	for i := 910000000; len(ids) < n; i++ {
+		if id := strconv.Itoa(i); norway.ValidOrgNumberChecksum(id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func TestCheckRegistries_NorwayBatchBeyondFirstPage(t *testing.T) {
+	const maxPageSize = 25 // Smaller than the batch, so the client must page
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		ids := strings.Split(q.Get("organisasjonsnummer"), ",")
+		size := 20 // brreg's default page size
+		if s, err := strconv.Atoi(q.Get("size")); err == nil {
+			size = min(s, maxPageSize)
+		}
+		page, _ := strconv.Atoi(q.Get("page"))
+
+		var enheter []string
+		for _, id := range ids[min(page*size, len(ids)):min((page+1)*size, len(ids))] {
+			enheter = append(enheter, fmt.Sprintf(`{"organisasjonsnummer":%q,"navn":"AS %s"}`, id, id))
+		}
+		totalPages := (len(ids) + size - 1) / size
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"_embedded":{"enheter":[%s]},"page":{"size":%d,"totalElements":%d,"totalPages":%d,"number":%d}}`,
+			strings.Join(enheter, ","), size, len(ids), totalPages, page)
+	}))
+	defer server.Close()
+
+	no := norway.NewClient(norway.WithBaseURL(server.URL))
+	defer no.Close()
+	c := NewClient(Config{Norway: no})
+
+	ids := validOrgNumbers(60)
+	result, err := c.ValidateIdentifiersMCP(context.Background(), ValidateIdentifiersArgs{
+		Identifiers:    ids,
+		DefaultCountry: CountryNorway,
+		CheckRegistry:  true,
+	})
+	if err != nil {
+		t.Fatalf("ValidateIdentifiersMCP: %v", err)
+	}
+	for i, r := range result.Results {
+		if r.Registry == nil || !r.Registry.Exists || !r.Registry.Active {
+			t.Errorf("identifier #%d %s: registry = %+v, want an active company", i+1, r.Input, r.Registry)
+		}
+	}
+	if result.Summary.NotFound != 0 {
+		t.Errorf("NotFound = %d, want 0", result.Summary.NotFound)
+	}
+}
@@ -0,0 +1,191 @@
+package nordic
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode"
+
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/norway"
+)
+
+// Sources reported in CheckVATResult.Source.
+const (
+	SourceVIES  = "vies"  // EU VAT Information Exchange System
+	SourceBrreg = "brreg" // Norwegian Merverdiavgiftsregisteret via Enhetsregisteret
+)
+
+// Outcomes reported in CheckVATResult.NameMatch.
+const (
+	NameMatchExact    = "exact"    // Same name once case and punctuation are ignored
+	NameMatchSimilar  = "similar"  // Same name once legal-form words are ignored, or one contains the other
+	NameMatchMismatch = "mismatch" // Different names
+	NameMatchUnknown  = "unknown"  // One of the names is unavailable
+)
+
+// viesCountryCodes maps country names to VIES member-state codes. Norway is
+// not in the EU and has no entry.
+var viesCountryCodes = map[string]string{
+	CountryDenmark: "DK",
+	CountryFinland: "FI",
+	CountrySweden:  "SE",
+}
+
+// legalFormWords are company-form abbreviations ignored when comparing names,
+// after normalizeName has removed their punctuation (A/S becomes AS).
+var legalFormWords = map[string]bool{
+	"AS": true, "ASA": true, "ANS": true, "DA": true, "ENK": true, "NUF": true, "SA": true, // Norway
+	"APS": true, "IS": true, "IVS": true, "KS": true, "PS": true, "AMBA": true, "FMBA": true, // Denmark
+	"OY": true, "OYJ": true, "AB": true, "ABP": true, "KY": true, "TMI": true, "OSK": true, // Finland
+	"PUBL": true, "HB": true, "KB": true, "EF": true, // Sweden
+}
+
+// checkNorwegianVAT answers a Norwegian VAT check from Enhetsregisteret, which
+// records membership of Merverdiavgiftsregisteret. Norway is outside VIES.
+func (c *Client) checkNorwegianVAT(ctx context.Context, orgNumber string) (CheckVATResult, error) {
+	out := CheckVATResult{
+		VATNumber:  "NO" + orgNumber + "MVA",
+		Country:    CountryNorway,
+		Source:     SourceBrreg,
+		RegistryID: orgNumber,
+		NameMatch:  NameMatchUnknown,
+	}
+
+	res, err := c.norway.GetCompanyMCP(ctx, norway.GetCompanyArgs{OrgNumber: orgNumber})
+	if err != nil {
+		return CheckVATResult{}, err
+	}
+	if !res.Found || res.Summary == nil {
+		out.Message = "No company with this organization number in Enhetsregisteret"
+		return out, nil
+	}
+
+	s := res.Summary
+	out.RegistryName = s.Name
+	out.RegistryStatus = s.Status
+	out.Valid = s.VATRegistered
+	if !out.Valid {
+		out.Message = "Company exists but is not registered in Merverdiavgiftsregisteret"
+		return out, nil
+	}
+	// The VAT register and the company register are one record at
+	// Brønnøysund, so the names agree by construction.
+	out.RegisteredName = s.Name
+	out.RegisteredAddress = s.BusinessAddress
+	out.NameMatch = NameMatchExact
+	if s.Status != "ACTIVE" {
+		out.Message = "VAT-registered, but registry status is " + s.Status
+	}
+	return out, nil
+}
+
+// checkVIESVAT asks VIES about a Danish, Finnish or Swedish VAT number and
+// cross-checks the returned name against the national registry.
+func (c *Client) checkVIESVAT(ctx context.Context, id IdentifierResult) (CheckVATResult, error) {
+	if c.vies == nil {
+		return CheckVATResult{}, errors.New("VIES client not configured")
+	}
+
+	code := viesCountryCodes[id.Country]
+	number := viesNumber(id)
+	resp, err := c.vies.CheckVAT(ctx, code, number)
+	if err != nil {
+		return CheckVATResult{}, err
+	}
+
+	out := CheckVATResult{
+		VATNumber:         code + number,
+		Country:           id.Country,
+		Valid:             resp.IsValid,
+		Source:            SourceVIES,
+		RegisteredName:    resp.RegisteredName(),
+		RegisteredAddress: resp.RegisteredAddress(),
+		RequestDate:       resp.RequestDate,
+		RegistryID:        id.Normalized,
+	}
+
+	st, err := c.lookupFor(id.Country)(ctx, id.Normalized)
+	switch {
+	case err != nil && !apierrors.IsNotFound(err):
+		out.RegistryError = err.Error()
+	case st.Error != "":
+		out.RegistryError = st.Error
+	default:
+		out.RegistryName = st.Name
+		out.RegistryStatus = st.Status
+	}
+	out.NameMatch = compareNames(out.RegisteredName, out.RegistryName)
+	out.Message = vatMessage(out, st)
+	return out, nil
+}
+
+// viesNumber returns the VAT number VIES expects after the country code.
+func viesNumber(id IdentifierResult) string {
+	switch id.Country {
+	case CountryFinland:
+		return strings.ReplaceAll(id.Normalized, "-", "")
+	case CountrySweden:
+		// Swedish VAT numbers are the 10-digit organization or personal
+		// number followed by 01.
+		return id.Normalized[len(id.Normalized)-10:] + "01"
+	}
+	return id.Normalized
+}
+
+// vatMessage explains the outcome that most needs the caller's attention.
+func vatMessage(r CheckVATResult, st RegistryStatus) string {
+	switch {
+	case !r.Valid:
+		return "VAT number is not registered in VIES"
+	case r.RegistryError != "":
+		return "VAT number is live; registry cross-check failed"
+	case !st.Exists:
+		return "VAT number is live but no company with this identifier was found in the national registry"
+	case r.NameMatch == NameMatchMismatch:
+		return "VAT-registered name differs from the registry name; verify the counterparty"
+	case !st.Active:
+		return "VAT number is live, but registry status is " + st.Status
+	case r.RegisteredName == "":
+		return "VAT number is live; the member state does not disclose the registered name"
+	}
+	return ""
+}
+
+// compareNames grades how well a VAT-registered name matches a registry name.
+func compareNames(vatName, registryName string) string {
+	a, b := normalizeName(vatName), normalizeName(registryName)
+	if a == "" || b == "" {
+		return NameMatchUnknown
+	}
+	if a == b {
+		return NameMatchExact
+	}
+	a, b = stripLegalForm(a), stripLegalForm(b)
+	if a != "" && b != "" && (a == b || strings.Contains(a, b) || strings.Contains(b, a)) {
+		return NameMatchSimilar
+	}
+	return NameMatchMismatch
+}
+
+// normalizeName upper-cases a company name and reduces punctuation, so
+// "Novo Nordisk A/S" and "NOVO NORDISK AS" compare equal.
+func normalizeName(name string) string {
+	name = strings.NewReplacer("/", "", ".", "", "&", " AND ").Replace(strings.ToUpper(name))
+	return strings.Join(strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// stripLegalForm removes legal-form words from both ends of a normalized
+// name; Finnish names often carry them first ("OY ...") as well as last.
+func stripLegalForm(name string) string {
+	words := strings.Fields(name)
+	for len(words) > 0 && legalFormWords[words[0]] {
+		words = words[1:]
+	}
+	for len(words) > 0 && legalFormWords[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,139 @@
+package nordic
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/vies"
+)
+
+// newVATTestClient extends newTestClient with a stub VIES service.
+func newVATTestClient(t *testing.T) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/ms/DK/vat/10150817":
+			_, _ = w.Write([]byte(`{"isValid":true,"requestDate":"2026-10-18T10:00:00Z","name":"Novo Nordisk A/S","address":"Novo Allé 1\n2880 Bagsværd"}`))
+		case "/ms/DK/vat/24256790":
+			_, _ = w.Write([]byte(`{"isValid":true,"name":"SOME OTHER COMPANY APS"}`))
+		case "/ms/FI/vat/01120389":
+			_, _ = w.Write([]byte(`{"isValid":false,"userError":"INVALID","name":"---","address":"---"}`))
+		case "/ms/SE/vat/556012579001":
+			_, _ = w.Write([]byte(`{"isValid":true,"name":"---","address":"---"}`))
+		default:
+			_, _ = w.Write([]byte(`{"isValid":false,"userError":"MS_UNAVAILABLE"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	c := newTestClient(t)
+	c.vies = vies.NewClient(vies.WithBaseURL(server.URL))
+	t.Cleanup(c.vies.Close)
+	return c
+}
+
+func TestCheckVATMCP(t *testing.T) {
+	c := newVATTestClient(t)
+
+	tests := []struct {
+		name         string
+		input        string
+		wantVAT      string
+		wantSource   string
+		wantValid    bool
+		wantMatch    string
+		wantRegistry string
+		wantRegErr   bool
+		wantMessage  bool
+		wantAddress  bool
+		wantCountry  string
+		country      string
+	}{
+		{"norway registered", "NO 923 609 016 MVA", "NO923609016MVA", SourceBrreg, true, NameMatchExact, "EQUINOR ASA", false, false, true, CountryNorway, ""},
+		{"norway not VAT-registered", "914778271", "NO914778271MVA", SourceBrreg, false, NameMatchUnknown, "TELENOR ASA", false, true, false, CountryNorway, ""},
+		{"norway unknown company", "974760673", "NO974760673MVA", SourceBrreg, false, NameMatchUnknown, "", false, true, false, CountryNorway, ""},
+		{"denmark live, name cross-checked", "DK10150817", "DK10150817", SourceVIES, true, NameMatchExact, "NOVO NORDISK A/S", false, true, true, CountryDenmark, ""},
+		{"denmark live, not in registry", "DK-24256790", "DK24256790", SourceVIES, true, NameMatchUnknown, "", false, true, false, CountryDenmark, ""},
+		{"finland not registered", "01120389", "FI01120389", SourceVIES, false, NameMatchUnknown, "", true, true, false, CountryFinland, CountryFinland},
+		{"sweden withheld name", "SE556012579001", "SE556012579001", SourceVIES, true, NameMatchUnknown, "", true, true, false, CountrySweden, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := c.CheckVATMCP(context.Background(), CheckVATArgs{VATNumber: tt.input, Country: tt.country})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.VATNumber != tt.wantVAT || got.Country != tt.wantCountry || got.Source != tt.wantSource {
+				t.Errorf("got %s/%s/%s, want %s/%s/%s", got.VATNumber, got.Country, got.Source, tt.wantVAT, tt.wantCountry, tt.wantSource)
+			}
+			if got.Valid != tt.wantValid {
+				t.Errorf("Valid = %v, want %v", got.Valid, tt.wantValid)
+			}
+			if got.NameMatch != tt.wantMatch {
+				t.Errorf("NameMatch = %q, want %q", got.NameMatch, tt.wantMatch)
+			}
+			if got.RegistryName != tt.wantRegistry {
+				t.Errorf("RegistryName = %q, want %q", got.RegistryName, tt.wantRegistry)
+			}
+			if (got.RegistryError != "") != tt.wantRegErr {
+				t.Errorf("RegistryError = %q, want error=%v", got.RegistryError, tt.wantRegErr)
+			}
+			if (got.Message != "") != tt.wantMessage {
+				t.Errorf("Message = %q, want message=%v", got.Message, tt.wantMessage)
+			}
+			if (got.RegisteredAddress != "") != tt.wantAddress {
+				t.Errorf("RegisteredAddress = %q, want address=%v", got.RegisteredAddress, tt.wantAddress)
+			}
+		})
+	}
+}
+
+func TestCheckVATMCP_Errors(t *testing.T) {
+	c := newVATTestClient(t)
+
+	for _, args := range []CheckVATArgs{
+		{},
+		{VATNumber: "DK10150818"}, // check digit mismatch
+		{VATNumber: "10150817", Country: "iceland"},
+	} {
+		if _, err := c.CheckVATMCP(context.Background(), args); !apierrors.IsValidation(err) {
+			t.Errorf("%+v: expected validation error, got %v", args, err)
+		}
+	}
+
+	// A member state outage is an error, not an invalid number.
+	if _, err := c.CheckVATMCP(context.Background(), CheckVATArgs{VATNumber: "FI12345671"}); err == nil || apierrors.IsValidation(err) {
+		t.Errorf("expected upstream error, got %v", err)
+	}
+
+	noVIES := newTestClient(t)
+	if _, err := noVIES.CheckVATMCP(context.Background(), CheckVATArgs{VATNumber: "DK10150817"}); err == nil {
+		t.Error("expected error without a VIES client")
+	}
+}
+
+func TestCompareNames(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want string
+	}{
+		{"NOVO NORDISK A/S", "Novo Nordisk A/S", NameMatchExact},
+		{"Nokia Oyj", "NOKIA OYJ", NameMatchExact},
+		{"Volvo Car Corporation AB", "Volvo Car Corporation", NameMatchSimilar},
+		{"Oy Karl Fazer Ab", "Karl Fazer", NameMatchSimilar},
+		{"Telefonaktiebolaget L M Ericsson (publ)", "TELEFONAKTIEBOLAGET L M ERICSSON", NameMatchSimilar},
+		{"Equinor ASA", "Telenor ASA", NameMatchMismatch},
+		{"AS", "ASA", NameMatchMismatch},
+		{"", "Equinor ASA", NameMatchUnknown},
+	}
+	for _, tt := range tests {
+		if got := compareNames(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareNames(%q, %q) = %q, want %q", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
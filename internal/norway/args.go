@@ -1,14 +1,22 @@
 package norway
 
-import "time"
+import (
+	"time"
+
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/legalform"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/lei"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/nace"
+)
 
 // Tag convention (verified against google/jsonschema-go, which the go-sdk uses
-// to derive input schemas): the `jsonschema:"..."` tag VALUE becomes the
-// property description, and a field is REQUIRED unless its `json` tag carries
-// `,omitempty`. There is no `required` keyword — `jsonschema:"required"` merely
-// sets the description to the literal word "required". Enum, minimum, maximum
-// and pattern constraints are not derivable from tags; they are enforced in the
-// handlers (see validation.go) and described in the tag text.
+// to derive input and output schemas): the `jsonschema:"..."` tag VALUE becomes
+// the property description, and a field is REQUIRED unless its `json` tag
+// carries `,omitempty` (for result types: always present). There is no
+// `required` keyword — `jsonschema:"required"` merely sets the description to
+// the literal word "required". Enum, minimum, maximum and pattern constraints
+// are not derivable from tags; they are enforced in the handlers
+// (see validation.go) and described in the tag text.
 
 // SearchCompaniesArgs contains parameters for company search
 type SearchCompaniesArgs struct {
@@ -24,52 +32,75 @@ type SearchCompaniesArgs struct {
 
 // SearchCompaniesResult is the result of a company search
 type SearchCompaniesResult struct {
-	Companies    []CompanySummary `json:"companies"`
-	TotalResults int              `json:"total_results"`
-	Page         int              `json:"page"`
-	TotalPages   int              `json:"total_pages"`
+	Companies    []CompanySummary `json:"companies" jsonschema:"Companies on this page"`
+	TotalResults int              `json:"total_results" jsonschema:"Total number of matches"`
+	Page         int              `json:"page" jsonschema:"Page number, 0-indexed"`
+	TotalPages   int              `json:"total_pages" jsonschema:"Total number of pages"`
 }
 
 // CompanySummary is a simplified company representation for search results
 type CompanySummary struct {
-	OrganizationNumber string `json:"organization_number"`
-	Name               string `json:"name"`
-	OrganizationForm   string `json:"organization_form,omitempty"`
-	PostalAddress      string `json:"postal_address,omitempty"`
-	BusinessAddress    string `json:"business_address,omitempty"`
-	Status             string `json:"status,omitempty"` // ACTIVE, BANKRUPT, LIQUIDATING
+	OrganizationNumber string          `json:"organization_number" jsonschema:"9-digit Norwegian organization number"`
+	Name               string          `json:"name" jsonschema:"Registered company name"`
+	OrganizationForm   string          `json:"organization_form,omitempty" jsonschema:"Organization form code, e.g. AS, ASA or ENK"`
+	PostalAddress      string          `json:"postal_address,omitempty" jsonschema:"Postal address"`
+	BusinessAddress    string          `json:"business_address,omitempty" jsonschema:"Business address"`
+	Status             string          `json:"status,omitempty" jsonschema:"Derived status: ACTIVE, BANKRUPT or LIQUIDATING"`
+	NACE               *nace.Code      `json:"nace,omitempty" jsonschema:"Primary industry code mapped to NACE"`
+	LegalFormClass     *legalform.Form `json:"legal_form_class,omitempty" jsonschema:"Legal form mapped to category, owner liability and ELF code"`
 }
 
 // GetCompanyArgs contains parameters for getting a single company
 type GetCompanyArgs struct {
 	OrgNumber string `json:"org_number" jsonschema:"9-digit Norwegian organization number; spaces and dashes are stripped automatically, e.g. 923609016 or 923 609 016"`
 	Full      bool   `json:"full,omitempty" jsonschema:"Return the full company record (all addresses, industry codes, capital) instead of the compact summary (default false)"`
+
+	infra.CacheControl
 }
 
 // GetCompanyResult is the result of getting a company
 type GetCompanyResult struct {
-	Found   bool                  `json:"found"`             // Whether the company was found
-	Message string                `json:"message,omitempty"` // Message when not found
-	Company *Company              `json:"company,omitempty"` // Full company (when full=true)
-	Summary *CompanyDetailSummary `json:"summary,omitempty"` // Summary (default)
+	Found   bool                  `json:"found" jsonschema:"Whether the company was found"`
+	Message string                `json:"message,omitempty" jsonschema:"Explanation when the company was not found"`
+	Company *Company              `json:"company,omitempty" jsonschema:"Full company record from Brønnøysund, when full=true"`
+	Summary *CompanyDetailSummary `json:"summary,omitempty" jsonschema:"Compact company record, the default"`
+	LEI     *lei.Entity           `json:"lei,omitempty" jsonschema:"Legal Entity Identifier from the GLEIF golden copy, when configured and the company has one"`
+
+	infra.Freshness
 }
 
+// LEIKey returns the jurisdiction and organization number for LEI enrichment.
+func (r *GetCompanyResult) LEIKey() (string, string) {
+	switch {
+	case r.Summary != nil:
+		return "NO", r.Summary.OrganizationNumber
+	case r.Company != nil:
+		return "NO", r.Company.OrganizationNumber
+	}
+	return "", ""
+}
+
+// SetLEI attaches the company's LEI entity.
+func (r *GetCompanyResult) SetLEI(e *lei.Entity) { r.LEI = e }
+
 // CompanyDetailSummary is a compact company representation for get_company responses
 type CompanyDetailSummary struct {
-	OrganizationNumber        string   `json:"organization_number"`
-	Name                      string   `json:"name"`
-	OrganizationForm          string   `json:"organization_form,omitempty"`
-	BusinessAddress           string   `json:"business_address,omitempty"`
-	PostalAddress             string   `json:"postal_address,omitempty"`
-	RegistrationDate          string   `json:"registration_date,omitempty"`
-	EmployeeCount             int      `json:"employee_count,omitempty"`
-	Industry                  string   `json:"industry,omitempty"`
-	Website                   string   `json:"website,omitempty"`
-	VATRegistered             bool     `json:"vat_registered,omitempty"`
-	Status                    string   `json:"status,omitempty"` // ACTIVE, BANKRUPT, LIQUIDATING
-	RegisteredInVoluntary     bool     `json:"registered_in_voluntary,omitempty"`
-	VoluntaryRegistrationDate string   `json:"voluntary_registration_date,omitempty"` // When registered in Frivillighetsregisteret
-	Activity                  []string `json:"activity,omitempty"`                    // Activity description for voluntary orgs
+	OrganizationNumber        string          `json:"organization_number" jsonschema:"9-digit Norwegian organization number"`
+	Name                      string          `json:"name" jsonschema:"Registered company name"`
+	OrganizationForm          string          `json:"organization_form,omitempty" jsonschema:"Organization form code and description, e.g. AS - Aksjeselskap"`
+	BusinessAddress           string          `json:"business_address,omitempty" jsonschema:"Business address"`
+	PostalAddress             string          `json:"postal_address,omitempty" jsonschema:"Postal address"`
+	RegistrationDate          string          `json:"registration_date,omitempty" jsonschema:"Registration date in Enhetsregisteret, YYYY-MM-DD"`
+	EmployeeCount             int             `json:"employee_count,omitempty" jsonschema:"Number of employees"`
+	Industry                  string          `json:"industry,omitempty" jsonschema:"Primary industry code (SN2007) and description"`
+	Website                   string          `json:"website,omitempty" jsonschema:"Company website"`
+	VATRegistered             bool            `json:"vat_registered,omitempty" jsonschema:"Whether the company is in the VAT register"`
+	Status                    string          `json:"status,omitempty" jsonschema:"Derived status: ACTIVE, BANKRUPT or LIQUIDATING"`
+	RegisteredInVoluntary     bool            `json:"registered_in_voluntary,omitempty" jsonschema:"Whether the organization is in Frivillighetsregisteret"`
+	VoluntaryRegistrationDate string          `json:"voluntary_registration_date,omitempty" jsonschema:"Date of registration in Frivillighetsregisteret, YYYY-MM-DD"`
+	Activity                  []string        `json:"activity,omitempty" jsonschema:"Activity description, mainly for voluntary organizations"`
+	NACE                      *nace.Code      `json:"nace,omitempty" jsonschema:"Primary industry code mapped to NACE"`
+	LegalFormClass            *legalform.Form `json:"legal_form_class,omitempty" jsonschema:"Legal form mapped to category, owner liability and ELF code"`
 }
 
 // GetRolesArgs contains parameters for getting company roles
@@ -79,25 +110,25 @@ type GetRolesArgs struct {
 
 // GetRolesResult is the result of getting company roles
 type GetRolesResult struct {
-	RoleGroups []RoleGroupSummary `json:"role_groups"`
+	RoleGroups []RoleGroupSummary `json:"role_groups" jsonschema:"Roles grouped by type, e.g. board, CEO and auditor"`
 }
 
 // RoleGroupSummary is a simplified role group for MCP responses
 type RoleGroupSummary struct {
-	Type         string        `json:"type"`
-	Description  string        `json:"description"`
-	LastModified string        `json:"last_modified,omitempty"`
-	Roles        []RoleSummary `json:"roles"`
+	Type         string        `json:"type" jsonschema:"Role group code, e.g. STYR (board), DAGL (CEO) or REVI (auditor)"`
+	Description  string        `json:"description" jsonschema:"Role group description in Norwegian"`
+	LastModified string        `json:"last_modified,omitempty" jsonschema:"When the role group was last changed, YYYY-MM-DD"`
+	Roles        []RoleSummary `json:"roles" jsonschema:"Roles in the group"`
 }
 
 // RoleSummary is a simplified role for MCP responses
 type RoleSummary struct {
-	Type        string `json:"type"`
-	Description string `json:"description"`
-	Name        string `json:"name,omitempty"`          // Person or entity name
-	BirthDate   string `json:"birth_date,omitempty"`    // For persons
-	Resigned    bool   `json:"resigned,omitempty"`      // Whether resigned
-	EntityOrgNr string `json:"entity_org_nr,omitempty"` // For corporate roles
+	Type        string `json:"type" jsonschema:"Role code, e.g. LEDE (chair), MEDL (member) or VARA (deputy)"`
+	Description string `json:"description" jsonschema:"Role description in Norwegian"`
+	Name        string `json:"name,omitempty" jsonschema:"Name of the person or entity holding the role"`
+	BirthDate   string `json:"birth_date,omitempty" jsonschema:"Birth date of a person holding the role, YYYY-MM-DD"`
+	Resigned    bool   `json:"resigned,omitempty" jsonschema:"Whether the holder has resigned"`
+	EntityOrgNr string `json:"entity_org_nr,omitempty" jsonschema:"Organization number of an entity holding the role"`
 }
 
 // GetSubUnitsArgs contains parameters for getting sub-units
@@ -107,17 +138,17 @@ type GetSubUnitsArgs struct {
 
 // GetSubUnitsResult is the result of getting sub-units
 type GetSubUnitsResult struct {
-	SubUnits     []SubUnitSummary `json:"sub_units"`
-	TotalResults int              `json:"total_results"`
+	SubUnits     []SubUnitSummary `json:"sub_units" jsonschema:"Sub-units (branch offices) of the company"`
+	TotalResults int              `json:"total_results" jsonschema:"Number of sub-units"`
 }
 
 // SubUnitSummary is a simplified sub-unit for MCP responses
 type SubUnitSummary struct {
-	OrganizationNumber string `json:"organization_number"`
-	Name               string `json:"name"`
-	ParentOrgNumber    string `json:"parent_org_number"`
-	BusinessAddress    string `json:"business_address,omitempty"`
-	EmployeeCount      int    `json:"employee_count,omitempty"`
+	OrganizationNumber string `json:"organization_number" jsonschema:"9-digit organization number of the sub-unit"`
+	Name               string `json:"name" jsonschema:"Sub-unit name"`
+	ParentOrgNumber    string `json:"parent_org_number" jsonschema:"Organization number of the parent company"`
+	BusinessAddress    string `json:"business_address,omitempty" jsonschema:"Business address"`
+	EmployeeCount      int    `json:"employee_count,omitempty" jsonschema:"Number of employees"`
 }
 
 // GetSubUnitArgs contains parameters for getting a single sub-unit
@@ -127,7 +158,7 @@ type GetSubUnitArgs struct {
 
 // GetSubUnitResult is the result of getting a sub-unit
 type GetSubUnitResult struct {
-	SubUnit *SubUnit `json:"sub_unit"`
+	SubUnit *SubUnit `json:"sub_unit" jsonschema:"Sub-unit record from Brønnøysund"`
 }
 
 // GetUpdatesArgs contains parameters for getting registry updates
@@ -138,15 +169,15 @@ type GetUpdatesArgs struct {
 
 // GetUpdatesResult is the result of getting updates
 type GetUpdatesResult struct {
-	Updates []UpdateSummary `json:"updates"`
+	Updates []UpdateSummary `json:"updates" jsonschema:"Registry changes after the requested instant"`
 }
 
 // UpdateSummary is a simplified update entry for MCP responses
 type UpdateSummary struct {
-	UpdateID           int       `json:"update_id"`
-	OrganizationNumber string    `json:"organization_number"`
-	UpdatedAt          time.Time `json:"updated_at"`
-	ChangeType         string    `json:"change_type,omitempty"`
+	UpdateID           int       `json:"update_id" jsonschema:"Update identifier, increasing through the feed"`
+	OrganizationNumber string    `json:"organization_number" jsonschema:"Organization number of the changed company"`
+	UpdatedAt          time.Time `json:"updated_at" jsonschema:"When the change was recorded"`
+	ChangeType         string    `json:"change_type,omitempty" jsonschema:"Change type from the feed, e.g. Ny, Endring or Sletting"`
 }
 
 // SearchSubUnitsArgs contains parameters for sub-unit search
@@ -159,10 +190,10 @@ type SearchSubUnitsArgs struct {
 
 // SearchSubUnitsResult is the result of a sub-unit search
 type SearchSubUnitsResult struct {
-	SubUnits     []SubUnitSummary `json:"sub_units"`
-	TotalResults int              `json:"total_results"`
-	Page         int              `json:"page"`
-	TotalPages   int              `json:"total_pages"`
+	SubUnits     []SubUnitSummary `json:"sub_units" jsonschema:"Sub-units on this page"`
+	TotalResults int              `json:"total_results" jsonschema:"Total number of matches"`
+	Page         int              `json:"page" jsonschema:"Page number, 0-indexed"`
+	TotalPages   int              `json:"total_pages" jsonschema:"Total number of pages"`
 }
 
 // ListMunicipalitiesArgs contains parameters for listing municipalities
@@ -172,14 +203,14 @@ type ListMunicipalitiesArgs struct {
 
 // ListMunicipalitiesResult is the result of listing municipalities
 type ListMunicipalitiesResult struct {
-	Municipalities []MunicipalitySummary `json:"municipalities"`
-	Count          int                   `json:"count"`
+	Municipalities []MunicipalitySummary `json:"municipalities" jsonschema:"Norwegian municipalities"`
+	Count          int                   `json:"count" jsonschema:"Number of municipalities"`
 }
 
 // MunicipalitySummary is a simplified municipality for MCP responses
 type MunicipalitySummary struct {
-	Number string `json:"number"`
-	Name   string `json:"name"`
+	Number string `json:"number" jsonschema:"4-digit municipality code, e.g. 0301"`
+	Name   string `json:"name" jsonschema:"Municipality name, e.g. OSLO"`
 }
 
 // ListOrgFormsArgs contains parameters for listing organization forms
@@ -189,14 +220,14 @@ type ListOrgFormsArgs struct {
 
 // ListOrgFormsResult is the result of listing organization forms
 type ListOrgFormsResult struct {
-	OrgForms []OrgFormSummary `json:"org_forms"`
-	Count    int              `json:"count"`
+	OrgForms []OrgFormSummary `json:"org_forms" jsonschema:"Organization forms"`
+	Count    int              `json:"count" jsonschema:"Number of organization forms"`
 }
 
 // OrgFormSummary is a simplified organization form for MCP responses
 type OrgFormSummary struct {
-	Code        string `json:"code"`
-	Description string `json:"description"`
+	Code        string `json:"code" jsonschema:"Organization form code, e.g. AS"`
+	Description string `json:"description" jsonschema:"Organization form description in Norwegian, e.g. Aksjeselskap"`
 }
 
 // GetSubUnitUpdatesArgs contains parameters for getting sub-unit updates
@@ -207,15 +238,15 @@ type GetSubUnitUpdatesArgs struct {
 
 // GetSubUnitUpdatesResult is the result of getting sub-unit updates
 type GetSubUnitUpdatesResult struct {
-	Updates []SubUnitUpdateSummary `json:"updates"`
+	Updates []SubUnitUpdateSummary `json:"updates" jsonschema:"Sub-unit registry changes after the requested instant"`
 }
 
 // SubUnitUpdateSummary is a simplified sub-unit update entry for MCP responses
 type SubUnitUpdateSummary struct {
-	UpdateID           int       `json:"update_id"`
-	OrganizationNumber string    `json:"organization_number"`
-	UpdatedAt          time.Time `json:"updated_at"`
-	ChangeType         string    `json:"change_type,omitempty"`
+	UpdateID           int       `json:"update_id" jsonschema:"Update identifier, increasing through the feed"`
+	OrganizationNumber string    `json:"organization_number" jsonschema:"Organization number of the changed sub-unit"`
+	UpdatedAt          time.Time `json:"updated_at" jsonschema:"When the change was recorded"`
+	ChangeType         string    `json:"change_type,omitempty" jsonschema:"Change type from the feed, e.g. Ny, Endring or Sletting"`
 }
 
 // GetSignatureRightsArgs contains parameters for getting signature rights
@@ -225,33 +256,33 @@ type GetSignatureRightsArgs struct {
 
 // GetSignatureRightsResult is the result of getting signature rights
 type GetSignatureRightsResult struct {
-	OrganizationNumber string           `json:"organization_number"`
-	CompanyName        string           `json:"company_name,omitempty"`
-	SignatureRights    []SignatureRight `json:"signature_rights"`
-	Prokura            []SignatureRight `json:"prokura"`
-	Summary            string           `json:"summary"`
+	OrganizationNumber string           `json:"organization_number" jsonschema:"9-digit Norwegian organization number"`
+	CompanyName        string           `json:"company_name,omitempty" jsonschema:"Registered company name"`
+	SignatureRights    []SignatureRight `json:"signature_rights" jsonschema:"Holders of signature rights (signatur)"`
+	Prokura            []SignatureRight `json:"prokura" jsonschema:"Holders of prokura"`
+	Summary            string           `json:"summary" jsonschema:"Signing rules in words"`
 }
 
 // SignatureRight represents a person or entity with signing authority
 type SignatureRight struct {
-	Type        string `json:"type"`                    // SIGN, PROK, etc.
-	Description string `json:"description"`             // Norwegian description
-	Name        string `json:"name"`                    // Person or entity name
-	BirthDate   string `json:"birth_date,omitempty"`    // For persons
-	EntityOrgNr string `json:"entity_org_nr,omitempty"` // For corporate signatories
-	Resigned    bool   `json:"resigned,omitempty"`
+	Type        string `json:"type" jsonschema:"Role code: SIGN (signature) or PROK (prokura)"`
+	Description string `json:"description" jsonschema:"Role description in Norwegian"`
+	Name        string `json:"name" jsonschema:"Name of the person or entity"`
+	BirthDate   string `json:"birth_date,omitempty" jsonschema:"Birth date of a person, YYYY-MM-DD"`
+	EntityOrgNr string `json:"entity_org_nr,omitempty" jsonschema:"Organization number of an entity signatory"`
+	Resigned    bool   `json:"resigned,omitempty" jsonschema:"Whether the holder has resigned"`
 }
 
 // BatchGetCompaniesArgs contains parameters for batch company lookup
 type BatchGetCompaniesArgs struct {
-	OrgNumbers []string `json:"org_numbers" jsonschema:"9-digit Norwegian organization numbers to look up in one request, max 2000. Entries that are not 9 digits are skipped and reported under not_found rather than failing the call"`
+	OrgNumbers []string `json:"org_numbers" jsonschema:"9-digit Norwegian organization numbers to look up in one call, max 2000. Entries that are not 9 digits are skipped and reported under not_found rather than failing the call"`
 }
 
 // BatchGetCompaniesResult is the result of batch company lookup
 type BatchGetCompaniesResult struct {
-	Companies    []CompanySummary `json:"companies"`
-	TotalResults int              `json:"total_results"`
-	NotFound     []string         `json:"not_found,omitempty"` // Org numbers that were not found
+	Companies    []CompanySummary `json:"companies" jsonschema:"Companies found"`
+	TotalResults int              `json:"total_results" jsonschema:"Number of companies found"`
+	NotFound     []string         `json:"not_found,omitempty" jsonschema:"Organization numbers with no company, including malformed entries"`
 }
 
 // LogAttrs implementations expose each tool's structured-log attributes so
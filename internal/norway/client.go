@@ -20,11 +20,9 @@ const (
 	// BaseURL is the Brønnøysundregistrene API endpoint
 	BaseURL = "https://data.brreg.no/enhetsregisteret/api"
 
-	// SearchCacheTTL for search results (shorter to ensure fresher results)
-	SearchCacheTTL = 2 * time.Minute
-
-	// DefaultCacheTTL for company details and other cached responses
-	DefaultCacheTTL = 5 * time.Minute
+	// batchChunkSize is the number of org numbers BatchGetCompanies puts in
+	// one request, keeping the query string around 1.2 KB
+	batchChunkSize = 100
 )
 
 // Client provides access to the Norwegian Brønnøysundregistrene API
@@ -50,13 +48,45 @@ func WithLogger(l *slog.Logger) ClientOption {
 	}
 }
 
-// WithCache sets a custom cache
+// WithCache sets a custom cache, closing the one it replaces
 func WithCache(c *infra.Cache) ClientOption {
 	return func(client *Client) {
+		if client.Cache != nil && client.Cache != c {
+			client.Cache.Close()
+		}
 		client.Cache = c
 	}
 }
 
+// WithCachePolicy sets the cache TTLs of the client's operations
+func WithCachePolicy(p *infra.CachePolicy) ClientOption {
+	return func(client *Client) {
+		client.CachePolicy = p
+	}
+}
+
+// WithBreakerPolicy sets the client's circuit breaker from the policy
+func WithBreakerPolicy(p *infra.BreakerPolicy) ClientOption {
+	return func(client *Client) {
+		client.CircuitBreaker = p.NewCircuitBreaker("norway")
+	}
+}
+
+// WithHedging turns on hedged requests with cfg; nil turns them off
+func WithHedging(cfg *base.HedgeConfig) ClientOption {
+	return func(client *Client) {
+		base.WithHedging(cfg)(client.Client)
+	}
+}
+
+// WithRateLimitPolicy sets the request budget of the client's host from the
+// policy
+func WithRateLimitPolicy(p *infra.RateLimitPolicy) ClientOption {
+	return func(client *Client) {
+		client.RateLimiter = p.NewRateLimiter("norway")
+	}
+}
+
 // WithBaseURL sets a custom base URL (for testing)
 func WithBaseURL(url string) ClientOption {
 	return func(client *Client) {
@@ -67,7 +97,11 @@ func WithBaseURL(url string) ClientOption {
 // NewClient creates a new Brønnøysundregistrene client
 func NewClient(opts ...ClientOption) *Client {
 	c := &Client{
-		Client:  base.NewClient(),
+		Client: base.NewClient(
+			base.WithRegistry("norway"),
+			base.WithCircuitBreaker(infra.DefaultBreakerPolicy().NewCircuitBreaker("norway")),
+			base.WithRateLimiter(infra.DefaultRateLimitPolicy().NewRateLimiter("norway")),
+		),
 		baseURL: BaseURL,
 	}
 	for _, opt := range opts {
@@ -107,19 +141,22 @@ type cachedFetch struct {
 }
 
 // getCached fetches a resource through the cache: return the cached value
-// when present, otherwise perform the request and cache the result.
+// when present, otherwise perform the request, shared with concurrent
+// callers asking for the same key, and cache the result.
 func getCached[T any](ctx context.Context, c *Client, req cachedFetch) (*T, error) {
-	if cached, ok := c.Cache.Get(req.key); ok {
-		return cached.(*T), nil
-	}
-
-	var result T
-	if err := c.doRequest(ctx, req.path, req.params, &result); err != nil {
-		return nil, err
-	}
-
-	c.Cache.Set(req.key, &result, req.ttl)
-	return &result, nil
+	return infra.Fetch(ctx, c.Cache, req.key, req.ttl, func(ctx context.Context) (*T, error) {
+		result, _, err := c.Dedup.Do(ctx, req.key, func() (interface{}, error) {
+			var result T
+			if err := c.doRequest(ctx, req.path, req.params, &result); err != nil {
+				return nil, err
+			}
+			return &result, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result.(*T), nil
+	})
 }
 
 // updateParams builds the query parameters shared by the registry's
@@ -149,7 +186,7 @@ func (c *Client) SearchCompanies(ctx context.Context, query string, opts *Search
 	params.Set("navn", query)
 	opts.apply(params)
 
-	return getCached[SearchResponse](ctx, c, cachedFetch{key: "search:" + params.Encode(), path: "/enheter", params: params, ttl: SearchCacheTTL})
+	return getCached[SearchResponse](ctx, c, cachedFetch{key: "search:" + params.Encode(), path: "/enheter", params: params, ttl: c.CachePolicy.TTL("norway", "search")})
 }
 
 // SearchOptions configures company search
@@ -185,26 +222,30 @@ func (c *Client) GetCompany(ctx context.Context, orgNumber string) (*Company, er
 	}
 
 	cacheKey := "company:" + orgNumber
-	if cached, ok := c.Cache.Get(cacheKey); ok {
-		return cached.(*Company), nil
-	}
-
-	// Use deduplication to avoid duplicate requests for the same org number
-	result, _, err := c.Dedup.Do(ctx, cacheKey, func() (interface{}, error) {
-		var company Company
-		if err := c.doRequest(ctx, "/enheter/"+orgNumber, nil, &company); err != nil {
+	return infra.Fetch(ctx, c.Cache, cacheKey, c.CachePolicy.TTL("norway", "company"), func(ctx context.Context) (*Company, error) {
+		// Use deduplication to avoid duplicate requests for the same org number
+		result, _, err := c.Dedup.Do(ctx, cacheKey, func() (interface{}, error) {
+			var company Company
+			if err := c.doRequest(ctx, "/enheter/"+orgNumber, nil, &company); err != nil {
+				return nil, err
+			}
+			return &company, nil
+		})
+		if err != nil {
 			return nil, err
 		}
-		return &company, nil
+		return result.(*Company), nil
 	})
+}
 
-	if err != nil {
-		return nil, err
+// InvalidateCompany drops the cached record, roles and sub-units of a
+// company so the next read goes to the registry, used when the update feed
+// reports a change and by the admin cache purge.
+func (c *Client) InvalidateCompany(orgNumber string) {
+	orgNumber = NormalizeOrgNumber(orgNumber)
+	for _, prefix := range []string{"company:", "roles:", "subunits:", "subunit:"} {
+		c.Cache.Delete(prefix + orgNumber)
 	}
-
-	company := result.(*Company)
-	c.Cache.Set(cacheKey, company, DefaultCacheTTL)
-	return company, nil
 }
 
 // GetRoles retrieves board members and other roles for a company
@@ -214,7 +255,7 @@ func (c *Client) GetRoles(ctx context.Context, orgNumber string) (*RolesResponse
 		return nil, err
 	}
 
-	return getCached[RolesResponse](ctx, c, cachedFetch{key: "roles:" + orgNumber, path: "/enheter/" + orgNumber + "/roller", ttl: DefaultCacheTTL})
+	return getCached[RolesResponse](ctx, c, cachedFetch{key: "roles:" + orgNumber, path: "/enheter/" + orgNumber + "/roller", ttl: c.CachePolicy.TTL("norway", "roles")})
 }
 
 // GetSubUnits retrieves sub-units (branches) for a parent company
@@ -227,7 +268,7 @@ func (c *Client) GetSubUnits(ctx context.Context, parentOrgNumber string) (*SubU
 	params := url.Values{}
 	params.Set("overordnetEnhet", parentOrgNumber)
 
-	return getCached[SubUnitSearchResponse](ctx, c, cachedFetch{key: "subunits:" + parentOrgNumber, path: "/underenheter", params: params, ttl: DefaultCacheTTL})
+	return getCached[SubUnitSearchResponse](ctx, c, cachedFetch{key: "subunits:" + parentOrgNumber, path: "/underenheter", params: params, ttl: c.CachePolicy.TTL("norway", "subunits")})
 }
 
 // GetSubUnit retrieves a specific sub-unit by organization number
@@ -237,7 +278,7 @@ func (c *Client) GetSubUnit(ctx context.Context, orgNumber string) (*SubUnit, er
 		return nil, err
 	}
 
-	return getCached[SubUnit](ctx, c, cachedFetch{key: "subunit:" + orgNumber, path: "/underenheter/" + orgNumber, ttl: DefaultCacheTTL})
+	return getCached[SubUnit](ctx, c, cachedFetch{key: "subunit:" + orgNumber, path: "/underenheter/" + orgNumber, ttl: c.CachePolicy.TTL("norway", "subunit")})
 }
 
 // GetUpdates retrieves recent updates from the registry
@@ -267,26 +308,26 @@ func (c *Client) SearchSubUnits(ctx context.Context, query string, opts *SearchS
 		setNonEmpty(params, "kommunenummer", opts.Municipality)
 	}
 
-	return getCached[SubUnitSearchResponse](ctx, c, cachedFetch{key: "search_subunits:" + params.Encode(), path: "/underenheter", params: params, ttl: SearchCacheTTL})
+	return getCached[SubUnitSearchResponse](ctx, c, cachedFetch{key: "search_subunits:" + params.Encode(), path: "/underenheter", params: params, ttl: c.CachePolicy.TTL("norway", "search_subunits")})
 }
 
 // GetMunicipalities retrieves the list of Norwegian municipalities.
 // Uses size=500 to fetch all municipalities in one request (Norway has ~365).
-// Cached for 24h since this data rarely changes.
+// Cached for a week by default since this data rarely changes.
 func (c *Client) GetMunicipalities(ctx context.Context) (*MunicipalitiesResponse, error) {
 	// Request all municipalities at once (default page size is 20)
 	params := url.Values{"size": {"500"}}
-	return getCached[MunicipalitiesResponse](ctx, c, cachedFetch{key: "municipalities", path: "/kommuner", params: params, ttl: 24 * time.Hour})
+	return getCached[MunicipalitiesResponse](ctx, c, cachedFetch{key: "municipalities", path: "/kommuner", params: params, ttl: c.CachePolicy.TTL("norway", "municipalities")})
 }
 
 // GetOrgForms retrieves the list of organization forms (AS, ENK, etc.).
-// Cached for 24h since this data rarely changes.
+// Cached for a week by default since this data rarely changes.
 func (c *Client) GetOrgForms(ctx context.Context) (*OrgFormsResponse, error) {
-	return getCached[OrgFormsResponse](ctx, c, cachedFetch{key: "orgforms", path: "/organisasjonsformer", ttl: 24 * time.Hour})
+	return getCached[OrgFormsResponse](ctx, c, cachedFetch{key: "orgforms", path: "/organisasjonsformer", ttl: c.CachePolicy.TTL("norway", "org_forms")})
 }
 
-// BatchGetCompanies retrieves multiple companies by organization numbers in one request.
-// The API supports up to 2000 org numbers per request.
+// BatchGetCompanies retrieves up to 2000 companies by organization number,
+// batchChunkSize per request.
 func (c *Client) BatchGetCompanies(ctx context.Context, orgNumbers []string) (*SearchResponse, error) {
 	if len(orgNumbers) == 0 {
 		return &SearchResponse{}, nil
@@ -305,10 +346,32 @@ func (c *Client) BatchGetCompanies(ctx context.Context, orgNumbers []string) (*S
 		normalized = append(normalized, on)
 	}
 
-	params := url.Values{}
-	params.Set("organisasjonsnummer", strings.Join(normalized, ","))
-
-	return getCached[SearchResponse](ctx, c, cachedFetch{key: "batch:" + strings.Join(normalized, ","), path: "/enheter", params: params, ttl: DefaultCacheTTL})
+	// Ask in chunks that keep the URL short, each for every match on one page
+	// (the default page size is 20), and follow further pages in case the
+	// registry caps the page size. Pages are cached and deduplicated like
+	// any other request.
+	var result SearchResponse
+	for start := 0; start < len(normalized); start += batchChunkSize {
+		chunk := normalized[start:min(start+batchChunkSize, len(normalized))]
+		params := url.Values{}
+		params.Set("organisasjonsnummer", strings.Join(chunk, ","))
+		params.Set("size", strconv.Itoa(len(chunk)))
+		for page := 0; ; page++ {
+			if page > 0 {
+				params.Set("page", strconv.Itoa(page))
+			}
+			resp, err := getCached[SearchResponse](ctx, c, cachedFetch{key: "batch:" + params.Encode(), path: "/enheter", params: params, ttl: c.CachePolicy.TTL("norway", "batch")})
+			if err != nil {
+				return nil, err
+			}
+			result.Embedded.Companies = append(result.Embedded.Companies, resp.Embedded.Companies...)
+			if page+1 >= resp.Page.TotalPages {
+				break
+			}
+		}
+	}
+	result.Page = PageInfo{Size: len(result.Embedded.Companies), TotalElements: len(result.Embedded.Companies), TotalPages: 1}
+	return &result, nil
 }
 
 // GetSubUnitUpdates retrieves recent updates to sub-units from the registry
@@ -324,10 +387,11 @@ func (c *Client) doRequest(ctx context.Context, path string, params url.Values,
 		reqURL += "?" + params.Encode()
 	}
 
-	body, statusCode, err := c.Client.DoRequest(ctx, base.RequestConfig{URL: reqURL})
+	resp, err := c.Client.DoRequest(ctx, base.RequestConfig{URL: reqURL})
 	if err != nil {
 		return err
 	}
+	body, statusCode := resp.Body, resp.StatusCode
 
 	// Handle HTTP errors
 	if statusCode == http.StatusNotFound {
@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -1567,6 +1568,31 @@ func TestClient_Caching(t *testing.T) {
 	}
 }
 
+func TestClient_InvalidateCompany(t *testing.T) {
+	callCount := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		callCount++
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Company{OrganizationNumber: "923609016", Name: "EQUINOR ASA"})
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+
+	if _, err := client.GetCompany(context.Background(), "923609016"); err != nil {
+		t.Fatalf("GetCompany failed: %v", err)
+	}
+	client.InvalidateCompany("923 609 016")
+	if _, err := client.GetCompany(context.Background(), "923609016"); err != nil {
+		t.Fatalf("GetCompany after invalidation failed: %v", err)
+	}
+
+	if callCount != 2 {
+		t.Errorf("Expected 2 API calls (cache invalidated), got %d", callCount)
+	}
+}
+
 func TestWithBaseURL(t *testing.T) {
 	client := NewClient(WithBaseURL("http://test.example.com"))
 	defer client.Close()
@@ -1715,6 +1741,45 @@ func TestBatchGetCompanies_WithMockServer(t *testing.T) {
 	}
 }
 
+func TestBatchGetCompanies_Chunked(t *testing.T) {
+	requests := 0 // Chunks are requested one after another
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids := strings.Split(r.URL.Query().Get("organisasjonsnummer"), ",")
+		requests++
+		if len(ids) > batchChunkSize || len(r.URL.RawQuery) > 2048 {
+			t.Errorf("request for %d org numbers, query of %d bytes", len(ids), len(r.URL.RawQuery))
+		}
+		var resp SearchResponse
+		for _, id := range ids {
+			resp.Embedded.Companies = append(resp.Embedded.Companies, Company{OrganizationNumber: id})
+		}
+		resp.Page = PageInfo{Size: len(ids), TotalElements: len(ids), TotalPages: 1}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+
+	var ids []string
+	for i := 910000000; len(ids) < 250; i++ {
+		if id := strconv.Itoa(i); ValidOrgNumberChecksum(id) {
+			ids = append(ids, id)
+		}
+	}
+	result, err := client.BatchGetCompanies(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("BatchGetCompanies failed: %v", err)
+	}
+	if len(result.Embedded.Companies) != len(ids) {
+		t.Errorf("got %d companies, want %d", len(result.Embedded.Companies), len(ids))
+	}
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3", requests)
+	}
+}
+
 func TestBatchGetCompanies_EmptyInput(t *testing.T) {
 	client := NewClient()
 	defer client.Close()
@@ -1971,6 +2036,12 @@ func TestGetCompanyMCP_Success(t *testing.T) {
 	if result.Summary.Status != "ACTIVE" {
 		t.Errorf("Status = %q, want ACTIVE", result.Summary.Status)
 	}
+	if n := result.Summary.NACE; n == nil || n.Code != "06.10" || n.Section != "B" || n.NationalCode != "06.100" {
+		t.Errorf("NACE = %+v, want 06.10 in section B from SN2007 06.100", n)
+	}
+	if f := result.Summary.LegalFormClass; f == nil || f.Code != "ASA" || f.Category != "public_limited" {
+		t.Errorf("LegalFormClass = %+v, want ASA in public_limited", f)
+	}
 }
 
 func TestGetCompanyMCP_FullDetails(t *testing.T) {
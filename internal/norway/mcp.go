@@ -6,8 +6,20 @@ import (
 	"strings"
 
 	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/legalform"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/nace"
 )
 
+// Enhetsregisteret reports naeringskode values without saying whether they
+// are SN2007 or SN2025 codes; nace.FromRegistry tells them apart by the code.
+const (
+	industryScheme     = nace.SchemeSN2007
+	industryScheme2025 = nace.SchemeSN2025
+)
+
+// legalformCountry keys the organisasjonsform codes in the legal-form table.
+const legalformCountry = "norway"
+
 // MCP Tool wrapper methods
 // These methods wrap the client methods with Args/Result types for MCP integration.
 
@@ -63,6 +75,7 @@ func buildCompanySummary(co Company) CompanySummary {
 	}
 	if co.OrganizationForm != nil {
 		summary.OrganizationForm = co.OrganizationForm.Code
+		summary.LegalFormClass = legalform.Lookup(legalformCountry, co.OrganizationForm.Code)
 	}
 	if co.PostalAddress != nil {
 		summary.PostalAddress = formatAddress(co.PostalAddress)
@@ -70,9 +83,19 @@ func buildCompanySummary(co Company) CompanySummary {
 	if co.BusinessAddress != nil {
 		summary.BusinessAddress = formatAddress(co.BusinessAddress)
 	}
+	summary.NACE = primaryNACE(co.IndustryCode1)
 	return summary
 }
 
+// primaryNACE maps the primary naeringskode to NACE; nil when absent or
+// unclassifiable.
+func primaryNACE(code *IndustryCode) *nace.Code {
+	if code == nil {
+		return nil
+	}
+	return nace.FromRegistry(industryScheme, industryScheme2025, code.Code, code.Description)
+}
+
 // buildSubUnitSummary flattens a Brønnøysund SubUnit into the SubUnitSummary
 // shape returned by the MCP sub-unit endpoints.
 func buildSubUnitSummary(su SubUnit) SubUnitSummary {
@@ -129,6 +152,7 @@ func buildCompanyDetailSummary(company *Company) *CompanyDetailSummary {
 	}
 	if company.OrganizationForm != nil {
 		summary.OrganizationForm = company.OrganizationForm.Code + " - " + company.OrganizationForm.Description
+		summary.LegalFormClass = legalform.Lookup(legalformCountry, company.OrganizationForm.Code)
 	}
 	if company.BusinessAddress != nil {
 		summary.BusinessAddress = formatAddress(company.BusinessAddress)
@@ -139,6 +163,7 @@ func buildCompanyDetailSummary(company *Company) *CompanyDetailSummary {
 	if company.IndustryCode1 != nil {
 		summary.Industry = company.IndustryCode1.Code + " - " + company.IndustryCode1.Description
 	}
+	summary.NACE = primaryNACE(company.IndustryCode1)
 	return summary
 }
 
@@ -61,3 +61,26 @@ func ValidateSize(size int) error {
 	}
 	return nil
 }
+
+// orgNumberWeights are the modulus-11 weights Brønnøysundregistrene applies to
+// the first eight digits of an organization number.
+var orgNumberWeights = []int{3, 2, 7, 6, 5, 4, 3, 2}
+
+// ValidOrgNumberChecksum reports whether a 9-digit organization number carries
+// a correct modulus-11 control digit. The input must already be normalized;
+// anything that is not exactly 9 digits is reported as invalid.
+func ValidOrgNumberChecksum(orgNumber string) bool {
+	if !orgNumberRegex.MatchString(orgNumber) {
+		return false
+	}
+	sum := 0
+	for i, w := range orgNumberWeights {
+		sum += int(orgNumber[i]-'0') * w
+	}
+	check := 11 - sum%11
+	if check == 11 {
+		check = 0
+	}
+	// A remainder of 1 would need control digit 10; such numbers are never issued.
+	return check != 10 && check == int(orgNumber[8]-'0')
+}
@@ -75,3 +75,27 @@ func TestValidateSize(t *testing.T) {
 		})
 	}
 }
+
+func TestValidOrgNumberChecksum(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{"valid", "923609016", true},
+		{"valid second", "914778271", true},
+		{"wrong check digit", "923609017", false},
+		{"remainder needs digit 10", "100000130", false},
+		{"too short", "92360901", false},
+		{"not normalized", "923 609 016", false},
+		{"letters", "92360901A", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidOrgNumberChecksum(tt.input); got != tt.want {
+				t.Errorf("ValidOrgNumberChecksum(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
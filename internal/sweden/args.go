@@ -1,39 +1,67 @@
 package sweden
 
+import (
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/base"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/legalform"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/lei"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/nace"
+)
+
 // Tag convention (verified against google/jsonschema-go, which the go-sdk uses
-// to derive input schemas): the `jsonschema:"..."` tag VALUE becomes the
-// property description, and a field is REQUIRED unless its `json` tag carries
-// `,omitempty`. There is no `required` keyword — `jsonschema:"required"` merely
-// sets the description to the literal word "required". Enum, minimum, maximum
-// and pattern constraints are not derivable from tags; they are enforced in the
-// handlers (see ValidateOrgNumber in mcp.go) and described in the tag text.
+// to derive input and output schemas): the `jsonschema:"..."` tag VALUE becomes
+// the property description, and a field is REQUIRED unless its `json` tag
+// carries `,omitempty` (for result types: always present). There is no
+// `required` keyword — `jsonschema:"required"` merely sets the description to
+// the literal word "required". Enum, minimum, maximum and pattern constraints
+// are not derivable from tags; they are enforced in the handlers
+// (see ValidateOrgNumber in mcp.go) and described in the tag text.
 
 // GetCompanyArgs contains parameters for getting a Swedish company.
 type GetCompanyArgs struct {
 	OrgNumber string `json:"org_number" jsonschema:"Swedish organization number (10 digits, e.g. 5560125790 or 556012-5790) or personal number for a sole proprietor (12 digits); separators are stripped automatically. Bolagsverket offers no name search, so ask the user for the number if you do not have it"`
+
+	infra.CacheControl
 }
 
 // GetCompanyResult is the MCP response for getting a company.
 type GetCompanyResult struct {
-	Company *CompanySummary `json:"company,omitempty"`
+	Company *CompanySummary `json:"company,omitempty" jsonschema:"Company record, when found"`
+	LEI     *lei.Entity     `json:"lei,omitempty" jsonschema:"Legal Entity Identifier from the GLEIF golden copy, when configured and the company has one"`
+
+	infra.Freshness
+}
+
+// LEIKey returns the jurisdiction and organization number for LEI enrichment.
+func (r *GetCompanyResult) LEIKey() (string, string) {
+	if r.Company == nil {
+		return "", ""
+	}
+	return "SE", r.Company.OrganizationNumber
 }
 
+// SetLEI attaches the company's LEI entity.
+func (r *GetCompanyResult) SetLEI(e *lei.Entity) { r.LEI = e }
+
 // CompanySummary is a simplified company representation for MCP responses.
 type CompanySummary struct {
-	OrganizationNumber  string   `json:"organization_number"`
-	Name                string   `json:"name"`
-	OrganizationForm    string   `json:"organization_form,omitempty"`    // e.g., "AB - Aktiebolag"
-	LegalForm           string   `json:"legal_form,omitempty"`           // e.g., "49 - Övriga aktiebolag"
-	BusinessDescription string   `json:"business_description,omitempty"` // Free text description
-	RegistrationDate    string   `json:"registration_date,omitempty"`
-	RegistrationCountry string   `json:"registration_country,omitempty"`
-	PostalAddress       string   `json:"postal_address,omitempty"`
-	IsActive            bool     `json:"is_active"`
-	DeregisteredDate    string   `json:"deregistered_date,omitempty"` // If company is deregistered
-	DeregisteredReason  string   `json:"deregistered_reason,omitempty"`
-	OngoingProceedings  []string `json:"ongoing_proceedings,omitempty"` // Bankruptcy, liquidation, etc.
-	IndustryCodes       []string `json:"industry_codes,omitempty"`      // SNI codes
-	AdBlockEnabled      bool     `json:"ad_block_enabled,omitempty"`    // "Reklamsparr" - opted out of marketing
+	OrganizationNumber  string          `json:"organization_number" jsonschema:"10-digit Swedish organization number"`
+	Name                string          `json:"name" jsonschema:"Registered company name"`
+	OrganizationForm    string          `json:"organization_form,omitempty" jsonschema:"Organization form code and name, e.g. AB - Aktiebolag"`
+	LegalForm           string          `json:"legal_form,omitempty" jsonschema:"SCB legal form code and name, e.g. 49 - Övriga aktiebolag"`
+	BusinessDescription string          `json:"business_description,omitempty" jsonschema:"Registered description of the business (verksamhetsbeskrivning)"`
+	RegistrationDate    string          `json:"registration_date,omitempty" jsonschema:"Registration date, YYYY-MM-DD"`
+	RegistrationCountry string          `json:"registration_country,omitempty" jsonschema:"Country of registration"`
+	PostalAddress       string          `json:"postal_address,omitempty" jsonschema:"Postal address"`
+	IsActive            bool            `json:"is_active" jsonschema:"Whether the company is registered and not deregistered"`
+	Status              string          `json:"status,omitempty" jsonschema:"Derived status: ACTIVE, BANKRUPT, LIQUIDATING, RECONSTRUCTION or DEREGISTERED"`
+	DeregisteredDate    string          `json:"deregistered_date,omitempty" jsonschema:"Deregistration date, when deregistered"`
+	DeregisteredReason  string          `json:"deregistered_reason,omitempty" jsonschema:"Reason for deregistration"`
+	OngoingProceedings  []string        `json:"ongoing_proceedings,omitempty" jsonschema:"Ongoing proceedings such as bankruptcy, liquidation or reconstruction"`
+	IndustryCodes       []string        `json:"industry_codes,omitempty" jsonschema:"SNI 2007 industry codes"`
+	NACE                *nace.Code      `json:"nace,omitempty" jsonschema:"Primary SNI code mapped to NACE"`
+	LegalFormClass      *legalform.Form `json:"legal_form_class,omitempty" jsonschema:"Legal form mapped to category, owner liability and ELF code"`
+	AdBlockEnabled      bool            `json:"ad_block_enabled,omitempty" jsonschema:"Whether the company has opted out of direct marketing (reklamspärr)"`
 }
 
 // GetDocumentListArgs contains parameters for getting annual reports list.
@@ -43,17 +71,17 @@ type GetDocumentListArgs struct {
 
 // GetDocumentListResult is the MCP response for getting document list.
 type GetDocumentListResult struct {
-	OrganizationNumber string            `json:"organization_number"`
-	Documents          []DocumentSummary `json:"documents"`
-	Count              int               `json:"count"`
+	OrganizationNumber string            `json:"organization_number" jsonschema:"Organization number the documents belong to"`
+	Documents          []DocumentSummary `json:"documents" jsonschema:"Filed annual reports"`
+	Count              int               `json:"count" jsonschema:"Number of documents"`
 }
 
 // DocumentSummary is a simplified annual report representation.
 type DocumentSummary struct {
-	DocumentID            string `json:"document_id"`
-	FileFormat            string `json:"file_format,omitempty"`
-	ReportingPeriodEnd    string `json:"reporting_period_end,omitempty"` // End of financial year
-	RegistrationTimestamp string `json:"registration_timestamp,omitempty"`
+	DocumentID            string `json:"document_id" jsonschema:"Document identifier for sweden_download_document"`
+	FileFormat            string `json:"file_format,omitempty" jsonschema:"File format of the document"`
+	ReportingPeriodEnd    string `json:"reporting_period_end,omitempty" jsonschema:"End of the financial year the report covers"`
+	RegistrationTimestamp string `json:"registration_timestamp,omitempty" jsonschema:"When Bolagsverket registered the report"`
 }
 
 // CheckStatusArgs contains parameters for checking API status.
@@ -63,9 +91,9 @@ type CheckStatusArgs struct {
 
 // CheckStatusResult is the MCP response for status check.
 type CheckStatusResult struct {
-	Available            bool   `json:"available"`
-	CircuitBreakerStatus string `json:"circuit_breaker_status"`
-	CacheEntries         int64  `json:"cache_entries"`
+	Available            bool   `json:"available" jsonschema:"Whether the Bolagsverket API answered its health check"`
+	CircuitBreakerStatus string `json:"circuit_breaker_status" jsonschema:"Circuit breaker state: closed, open or half-open"`
+	CacheEntries         int64  `json:"cache_entries" jsonschema:"Number of cached responses"`
 }
 
 // DownloadDocumentArgs contains parameters for downloading an annual report.
@@ -78,11 +106,24 @@ type DownloadDocumentArgs struct {
 // a 1-10 MB annual report base64-encodes to far more than is safe to carry in
 // the caller's context (HG-2 cost-lens).
 type DownloadDocumentResult struct {
-	DocumentID  string `json:"document_id"`
-	FileFormat  string `json:"file_format"`
-	SizeBytes   int    `json:"size_bytes"`
-	Path        string `json:"path"` // local filesystem path to the downloaded ZIP
-	Description string `json:"description"`
+	DocumentID  string `json:"document_id" jsonschema:"Downloaded document identifier"`
+	FileFormat  string `json:"file_format" jsonschema:"MIME type of the file, application/zip"`
+	SizeBytes   int    `json:"size_bytes" jsonschema:"File size in bytes"`
+	Path        string `json:"path" jsonschema:"Local filesystem path of the downloaded ZIP"`
+	Description string `json:"description" jsonschema:"What was downloaded and how to read it"`
+}
+
+// BatchGetCompaniesArgs contains parameters for batch company lookup.
+type BatchGetCompaniesArgs struct {
+	OrgNumbers []string `json:"org_numbers" jsonschema:"Swedish organization numbers (10 digits) or sole-proprietor personal numbers (12 digits) to look up, max 100; separators are stripped automatically. Malformed or unknown entries are reported under errors and missing rather than failing the call"`
+}
+
+// BatchGetCompaniesResult is the MCP response for batch company lookup.
+type BatchGetCompaniesResult struct {
+	Companies    []CompanySummary      `json:"companies" jsonschema:"Companies found, in input order"`
+	TotalResults int                   `json:"total_results" jsonschema:"Number of companies found"`
+	Missing      []string              `json:"missing,omitempty" jsonschema:"Input organization numbers with no company, as given by the caller"`
+	Errors       []base.BatchItemError `json:"errors,omitempty" jsonschema:"Why each missing organization number failed"`
 }
 
 // LogAttrs implementations expose each tool's structured-log attributes so
@@ -118,3 +159,13 @@ func (r CheckStatusResult) LogAttrs() []any { return []any{"available", r.Availa
 func (r DownloadDocumentResult) LogAttrs() []any {
 	return []any{"document_id", r.DocumentID, "size_bytes", r.SizeBytes}
 }
+
+// LogAttrs returns structured-log attributes for the batch lookup.
+func (a BatchGetCompaniesArgs) LogAttrs() []any {
+	return []any{"org_numbers_count", len(a.OrgNumbers)}
+}
+
+// LogAttrs returns structured-log attributes for the batch result.
+func (r BatchGetCompaniesResult) LogAttrs() []any {
+	return []any{"companies", len(r.Companies), "missing", len(r.Missing)}
+}
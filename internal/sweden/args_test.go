@@ -58,6 +58,16 @@ func TestLogAttrs(t *testing.T) {
 			DownloadDocumentResult{DocumentID: "abc-123", SizeBytes: 1048576}.LogAttrs(),
 			[]any{"document_id", "abc-123", "size_bytes", 1048576},
 		},
+		{
+			"BatchGetCompaniesArgs",
+			BatchGetCompaniesArgs{OrgNumbers: []string{"5560125790", "5565475489"}}.LogAttrs(),
+			[]any{"org_numbers_count", 2},
+		},
+		{
+			"BatchGetCompaniesResult",
+			BatchGetCompaniesResult{Missing: []string{"5565475489"}}.LogAttrs(),
+			[]any{"companies", 0, "missing", 1},
+		},
 	}
 
 	for _, tt := range tests {
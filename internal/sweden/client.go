@@ -1,13 +1,13 @@
 package sweden
 
 import (
-	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
@@ -15,6 +15,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/base"
 	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
 )
 
@@ -34,21 +35,16 @@ const (
 	// feedbackURL is the pre-filled issue link shown on setup/auth errors.
 	feedbackURL = "https://github.com/olgasafonova/nordic-registry-mcp-server/issues/new?template=bug_report.yml"
 
-	// Timeouts and retries
-	defaultTimeout     = 30 * time.Second
+	// Token lifetime
 	tokenRefreshMargin = 5 * time.Minute // Refresh token 5 minutes before expiry
 
-	// Cache TTLs
-	companyCacheTTL  = 15 * time.Minute
-	documentCacheTTL = 30 * time.Minute
-
 	// Size limits
 	maxDocumentSize = 100 * 1024 * 1024 // 100 MB - prevents memory exhaustion from malicious responses
 )
 
 // Client provides access to the Swedish business registry (Bolagsverket).
 type Client struct {
-	httpClient   *http.Client
+	*base.Client
 	baseURL      string
 	tokenURL     string
 	clientID     string
@@ -58,11 +54,6 @@ type Client struct {
 	tokenMu     sync.RWMutex
 	accessToken string
 	tokenExpiry time.Time
-
-	// Resilience infrastructure
-	cache          *infra.Cache
-	circuitBreaker *infra.CircuitBreaker
-	dedup          *infra.RequestDeduplicator
 }
 
 // ClientOption configures the client.
@@ -85,7 +76,51 @@ func WithTokenURL(url string) ClientOption {
 // WithHTTPClient sets a custom HTTP client.
 func WithHTTPClient(hc *http.Client) ClientOption {
 	return func(c *Client) {
-		c.httpClient = hc
+		c.HTTPClient = hc
+	}
+}
+
+// WithLogger sets a custom logger.
+func WithLogger(l *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.Logger = l
+	}
+}
+
+// WithCache sets a custom cache, closing the one it replaces.
+func WithCache(cache *infra.Cache) ClientOption {
+	return func(c *Client) {
+		base.WithCache(cache)(c.Client)
+	}
+}
+
+// WithCachePolicy sets the cache TTLs of the client's operations.
+func WithCachePolicy(p *infra.CachePolicy) ClientOption {
+	return func(c *Client) {
+		c.CachePolicy = p
+	}
+}
+
+// WithBreakerPolicy sets the client's circuit breaker from the policy.
+func WithBreakerPolicy(p *infra.BreakerPolicy) ClientOption {
+	return func(c *Client) {
+		c.CircuitBreaker = p.NewCircuitBreaker("sweden")
+	}
+}
+
+// WithRateLimitPolicy sets the request budget of the API host from the
+// policy.
+func WithRateLimitPolicy(p *infra.RateLimitPolicy) ClientOption {
+	return func(c *Client) {
+		c.RateLimiter = p.NewRateLimiter("sweden")
+	}
+}
+
+// WithHedging turns on hedged requests with cfg; nil turns them off. Only
+// GETs are hedged: status checks and document downloads.
+func WithHedging(cfg *base.HedgeConfig) ClientOption {
+	return func(c *Client) {
+		base.WithHedging(cfg)(c.Client)
 	}
 }
 
@@ -102,31 +137,16 @@ func WithCredentials(clientID, clientSecret string) ClientOption {
 // environment variables unless provided via WithCredentials option.
 func NewClient(opts ...ClientOption) (*Client, error) {
 	c := &Client{
-		httpClient: &http.Client{
-			Timeout: defaultTimeout,
-			// SECURITY: refuse all redirects on the Sweden client. The
-			// token endpoint POSTs OAuth client credentials; authenticated
-			// data calls carry a Bearer token in the Authorization header.
-			// Go's default redirect policy follows up to 10 redirects, and
-			// 307/308 preserves method+body across origins — so a wiki (or
-			// any proxy in front of it, or a MITM during DNS/TLS bootstrap)
-			// returning 307 Location: https://attacker/ would cause Go to
-			// re-POST the credentials to the attacker. Bolagsverket's
-			// configured endpoints are the only legitimate targets; the
-			// Bolagsverket API does not redirect under normal operation.
-			// Returning ErrUseLastResponse short-circuits the redirect and
-			// surfaces the 3xx response to the caller as an error.
-			CheckRedirect: func(req *http.Request, via []*http.Request) error {
-				return http.ErrUseLastResponse
-			},
-		},
-		baseURL:        defaultBaseURL,
-		tokenURL:       defaultTokenURL,
-		clientID:       os.Getenv(envClientID),
-		clientSecret:   os.Getenv(envClientSecret),
-		cache:          infra.NewCache(500),
-		circuitBreaker: infra.NewCircuitBreaker(),
-		dedup:          infra.NewRequestDeduplicator(),
+		Client: base.NewClient(
+			base.WithRegistry("sweden"),
+			base.WithCache(infra.NewCache(500)),
+			base.WithCircuitBreaker(infra.DefaultBreakerPolicy().NewCircuitBreaker("sweden")),
+			base.WithRateLimiter(infra.DefaultRateLimitPolicy().NewRateLimiter("sweden")),
+		),
+		baseURL:      defaultBaseURL,
+		tokenURL:     defaultTokenURL,
+		clientID:     os.Getenv(envClientID),
+		clientSecret: os.Getenv(envClientSecret),
 	}
 
 	for _, opt := range opts {
@@ -134,6 +154,7 @@ func NewClient(opts ...ClientOption) (*Client, error) {
 	}
 
 	if c.clientID == "" || c.clientSecret == "" {
+		c.Client.Close()
 		return nil, fmt.Errorf("sweden: missing OAuth2 credentials; set %s and %s environment variables. Register for free at https://bolagsverket.se/apierochoppnadata/vardefulladatamangder/kundanmalantillapiforvardefulladatamangder.5528.html — Still stuck? "+feedbackURL, envClientID, envClientSecret)
 	}
 
@@ -149,10 +170,7 @@ func (c *Client) Close() {
 	c.accessToken = ""
 	c.tokenExpiry = time.Time{}
 
-	// Close cache
-	if c.cache != nil {
-		c.cache.Close()
-	}
+	c.Client.Close()
 }
 
 // IsConfigured returns true if OAuth2 credentials are available.
@@ -188,7 +206,9 @@ func (c *Client) getToken(ctx context.Context) (string, error) {
 	return token, nil
 }
 
-// refreshToken requests a new OAuth2 access token.
+// refreshToken requests a new OAuth2 access token. It goes straight to the
+// token endpoint: a token failure fails the data call that needed it, which
+// the circuit breaker counts.
 func (c *Client) refreshToken(ctx context.Context) (string, error) {
 	data := url.Values{}
 	data.Set("grant_type", "client_credentials")
@@ -196,7 +216,7 @@ func (c *Client) refreshToken(ctx context.Context) (string, error) {
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.tokenURL, strings.NewReader(data.Encode()))
 	if err != nil {
-		return "", fmt.Errorf("sweden: creating token request: %w", err)
+		return "", fmt.Errorf("creating token request: %w", err)
 	}
 
 	// Basic auth with client credentials
@@ -204,9 +224,9 @@ func (c *Client) refreshToken(ctx context.Context) (string, error) {
 	req.Header.Set("Authorization", "Basic "+auth)
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	resp, err := c.httpClient.Do(req) // #nosec G704 -- URL constructed from hardcoded tokenURL const
+	resp, err := c.HTTPClient.Do(req) // #nosec G704 -- URL constructed from hardcoded tokenURL const
 	if err != nil {
-		return "", fmt.Errorf("sweden: token request failed: %w", err)
+		return "", fmt.Errorf("token request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -216,12 +236,12 @@ func (c *Client) refreshToken(ctx context.Context) (string, error) {
 		// while preserving the operator-helpful Bolagsverket developer-portal
 		// prose (this is the only diagnostic an operator gets for OAuth
 		// credential issues — see commit fcf5324b).
-		return "", fmt.Errorf("sweden: token request returned %d: %s. Check your credentials in the Developer Portal at https://portal.api.bolagsverket.se/devportal/ — Still stuck? "+feedbackURL, resp.StatusCode, truncateBody(body))
+		return "", fmt.Errorf("token request returned %d: %s. Check your credentials in the Developer Portal at https://portal.api.bolagsverket.se/devportal/ — Still stuck? "+feedbackURL, resp.StatusCode, truncateBody(body))
 	}
 
 	var tokenResp TokenResponse
 	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
-		return "", fmt.Errorf("sweden: decoding token response: %w", err)
+		return "", fmt.Errorf("decoding token response: %w", err)
 	}
 
 	c.accessToken = tokenResp.AccessToken
@@ -230,83 +250,50 @@ func (c *Client) refreshToken(ctx context.Context) (string, error) {
 	return c.accessToken, nil
 }
 
-// doRequest performs an HTTP request with authentication.
-func (c *Client) doRequest(ctx context.Context, method, endpoint string, body any) ([]byte, error) {
-	if !c.circuitBreaker.Allow() {
-		return nil, errors.New("sweden: circuit breaker open")
-	}
-
+// authorize is the base.AuthProvider of Bolagsverket calls: it sets the
+// OAuth2 Bearer token, fetching a new one when the current one is about to
+// expire.
+func (c *Client) authorize(ctx context.Context, req *http.Request) error {
 	token, err := c.getToken(ctx)
 	if err != nil {
-		c.circuitBreaker.RecordFailure()
-		return nil, err
-	}
-
-	req, err := c.buildAuthenticatedRequest(ctx, authenticatedRequest{
-		Method:   method,
-		Endpoint: endpoint,
-		Body:     body,
-		Token:    token,
-	})
-	if err != nil {
-		return nil, err
+		return err
 	}
-
-	resp, err := c.httpClient.Do(req) // #nosec G704 -- URL constructed from hardcoded base + validated input
-	if err != nil {
-		c.circuitBreaker.RecordFailure()
-		return nil, fmt.Errorf("sweden: request failed: %w", err)
-	}
-	defer resp.Body.Close()
-
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		c.circuitBreaker.RecordFailure()
-		return nil, fmt.Errorf("sweden: reading response: %w", err)
-	}
-
-	if resp.StatusCode >= 400 {
-		c.circuitBreaker.RecordFailure()
-		return nil, formatBolagsverketError(resp.StatusCode, respBody)
-	}
-
-	c.circuitBreaker.RecordSuccess()
-	return respBody, nil
-}
-
-// authenticatedRequest holds the parameters needed to build a Bolagsverket
-// authenticated HTTP request.
-type authenticatedRequest struct {
-	Method   string
-	Endpoint string
-	Body     any
-	Token    string
+	req.Header.Set("Authorization", "Bearer "+token)
+	return nil
 }
 
-// buildAuthenticatedRequest constructs an authenticated HTTP request with
-// JSON body (if any) and the Bolagsverket OAuth Bearer token.
-func (c *Client) buildAuthenticatedRequest(ctx context.Context, ar authenticatedRequest) (*http.Request, error) {
-	var reqBody io.Reader
-	if ar.Body != nil {
-		jsonBody, err := json.Marshal(ar.Body)
+// doRequest performs an authenticated request with body, if not nil, as its
+// JSON body and returns the response body.
+func (c *Client) doRequest(ctx context.Context, method, endpoint string, body any) ([]byte, error) {
+	cfg := base.RequestConfig{
+		Method: method,
+		URL:    c.baseURL + endpoint,
+		Auth:   c.authorize,
+	}
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
 		if err != nil {
 			return nil, fmt.Errorf("sweden: marshaling request body: %w", err)
 		}
-		reqBody = bytes.NewReader(jsonBody)
+		cfg.Body = jsonBody
+		cfg.Headers = http.Header{"Content-Type": {"application/json"}}
 	}
+	return c.send(ctx, cfg)
+}
 
-	reqURL := c.baseURL + ar.Endpoint
-	req, err := http.NewRequestWithContext(ctx, ar.Method, reqURL, reqBody)
+// send performs a request through the base client. The answer, error
+// statuses included, is a success for the circuit breaker: the base client
+// has already counted 429 and 5xx answers as failures after its retries.
+func (c *Client) send(ctx context.Context, cfg base.RequestConfig) ([]byte, error) {
+	resp, err := c.DoRequest(ctx, cfg)
 	if err != nil {
-		return nil, fmt.Errorf("sweden: creating request: %w", err)
+		return nil, fmt.Errorf("sweden: %w", err)
 	}
-
-	req.Header.Set("Authorization", "Bearer "+ar.Token)
-	if ar.Body != nil {
-		req.Header.Set("Content-Type", "application/json")
+	c.RecordSuccess()
+	if resp.StatusCode >= 400 {
+		return nil, formatBolagsverketError(resp.StatusCode, resp.Body)
 	}
-	req.Header.Set("Accept", "application/json")
-	return req, nil
+	return resp.Body, nil
 }
 
 // formatBolagsverketError parses a Bolagsverket Problem Details envelope when
@@ -337,40 +324,38 @@ func (c *Client) GetCompany(ctx context.Context, orgNumber string) (*Organisatio
 		return nil, errors.New("sweden: organization number is required")
 	}
 
-	// Check cache
 	cacheKey := "company:" + orgNumber
-	if cached, ok := c.cache.Get(cacheKey); ok {
-		if result, ok := cached.(*OrganisationerSvar); ok {
-			return result, nil
-		}
-	}
-
-	// Deduplicate concurrent requests
-	result, _, err := c.dedup.Do(ctx, cacheKey, func() (any, error) {
-		reqBody := OrganisationerBegaran{
-			Identitetsbeteckning: orgNumber,
-		}
-
-		respBody, err := c.doRequest(ctx, http.MethodPost, "/organisationer", reqBody)
+	return infra.Fetch(ctx, c.Cache, cacheKey, c.CachePolicy.TTL("sweden", "company"), func(ctx context.Context) (*OrganisationerSvar, error) {
+		// Deduplicate concurrent requests
+		result, _, err := c.Dedup.Do(ctx, cacheKey, func() (any, error) {
+			reqBody := OrganisationerBegaran{
+				Identitetsbeteckning: orgNumber,
+			}
+
+			respBody, err := c.doRequest(ctx, http.MethodPost, "/organisationer", reqBody)
+			if err != nil {
+				return nil, err
+			}
+
+			var result OrganisationerSvar
+			if err := json.Unmarshal(respBody, &result); err != nil {
+				return nil, fmt.Errorf("sweden: decoding company response: %w", err)
+			}
+			return &result, nil
+		})
 		if err != nil {
 			return nil, err
 		}
-
-		var result OrganisationerSvar
-		if err := json.Unmarshal(respBody, &result); err != nil {
-			return nil, fmt.Errorf("sweden: decoding company response: %w", err)
-		}
-
-		// Cache the result
-		c.cache.Set(cacheKey, &result, companyCacheTTL)
-
-		return &result, nil
+		return result.(*OrganisationerSvar), nil
 	})
+}
 
-	if err != nil {
-		return nil, err
-	}
-	return result.(*OrganisationerSvar), nil
+// InvalidateCompany drops the cached record and document list of a company
+// so the next read goes to the registry.
+func (c *Client) InvalidateCompany(orgNumber string) {
+	orgNumber = NormalizeOrgNumber(orgNumber)
+	c.Cache.Delete("company:" + orgNumber)
+	c.Cache.Delete("doclist:" + orgNumber)
 }
 
 // IsAlive checks if the API is available.
@@ -384,25 +369,18 @@ func (c *Client) IsAlive(ctx context.Context) (bool, error) {
 
 // CircuitBreakerStatus returns the current circuit breaker state.
 func (c *Client) CircuitBreakerStatus() string {
-	return c.circuitBreaker.State().String()
-}
-
-// CircuitBreakerStats returns detailed circuit breaker statistics.
-func (c *Client) CircuitBreakerStats() infra.CircuitBreakerStats {
-	return c.circuitBreaker.Stats()
+	return c.CircuitBreaker.State().String()
 }
 
 // CacheSize returns the current number of cached entries.
 func (c *Client) CacheSize() int64 {
-	return c.cache.Size()
+	return c.Cache.Size()
 }
 
 // maxBodyInError caps how many bytes of an unparsed upstream body land in
 // caller-facing error messages. See HG-2 in rules/review-patterns.md.
 //
-// Sweden has its own helper (rather than importing one from internal/base)
-// because the Sweden client is intentionally separated from the shared
-// base client (different OAuth lifecycle, Bearer auth, retry semantics).
+// Each registry package keeps its own helper, as its error prose differs.
 const maxBodyInError = 256
 
 // truncateBody bounds the blast radius of a non-Problem-Details upstream
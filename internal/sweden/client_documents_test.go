@@ -4,10 +4,11 @@ package sweden
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"net/http"
-	"strings"
+	"sync/atomic"
 	"testing"
+
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
 )
 
 // =============================================================================
@@ -85,52 +86,56 @@ func TestClient_DownloadDocument_EscapesDocumentID(t *testing.T) {
 }
 
 // =============================================================================
-// readBoundedDocument Tests
+// Download Transport Tests
 // =============================================================================
 
-// failingReader returns an error after yielding no data.
-type failingReader struct{}
-
-func (failingReader) Read([]byte) (int, error) {
-	return 0, errors.New("boom")
-}
-
-func TestClient_ReadBoundedDocument_Success(t *testing.T) {
-	client, err := NewClient(WithCredentials("test-id", "test-secret"))
-	if err != nil {
-		t.Fatalf("NewClient failed: %v", err)
-	}
+func TestClient_DownloadDocument_ReportsProgress(t *testing.T) {
+	client := createTestClient(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		_, _ = w.Write([]byte("small document"))
+	})
 
-	data, err := client.readBoundedDocument(strings.NewReader("small document"))
+	var done int64
+	ctx := infra.WithProgress(context.Background(), func(d, _ int64, _ string) {
+		done = d
+	})
+	data, err := client.DownloadDocument(ctx, "doc-123")
 	if err != nil {
-		t.Fatalf("readBoundedDocument failed: %v", err)
+		t.Fatalf("DownloadDocument failed: %v", err)
 	}
 	if string(data) != "small document" {
 		t.Errorf("data = %q, want %q", string(data), "small document")
 	}
-
-	stats := client.CircuitBreakerStats()
-	if stats.ConsecutiveFails != 0 {
-		t.Errorf("ConsecutiveFails = %d, want 0 after successful read", stats.ConsecutiveFails)
+	if done != int64(len(data)) {
+		t.Errorf("progress reported %d bytes, want %d", done, len(data))
 	}
 }
 
-func TestClient_ReadBoundedDocument_ReadError(t *testing.T) {
-	client, err := NewClient(WithCredentials("test-id", "test-secret"))
-	if err != nil {
-		t.Fatalf("NewClient failed: %v", err)
-	}
+func TestClient_DownloadDocument_RetriesServerError(t *testing.T) {
+	var tokens, calls atomic.Int32
+	client := createTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		tokens.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(TokenResponse{AccessToken: "test-token", ExpiresIn: 3600}) //nolint:gosec // G117: test fixture with fake token
+	}, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("Authorization = %q on attempt %d", r.Header.Get("Authorization"), calls.Load()+1)
+		}
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte("PK"))
+	})
 
-	_, err = client.readBoundedDocument(failingReader{})
-	if err == nil {
-		t.Fatal("Expected error from failing reader")
+	data, err := client.DownloadDocument(context.Background(), "doc-123")
+	if err != nil {
+		t.Fatalf("DownloadDocument failed: %v", err)
 	}
-	if !strings.Contains(err.Error(), "reading document") {
-		t.Errorf("Expected 'reading document' error, got: %v", err)
+	if string(data) != "PK" || calls.Load() != 2 {
+		t.Errorf("got %q after %d calls, want the retried answer after 2", data, calls.Load())
 	}
-
-	stats := client.CircuitBreakerStats()
-	if stats.ConsecutiveFails == 0 {
-		t.Error("Read error should record a circuit breaker failure")
+	if tokens.Load() != 1 {
+		t.Errorf("token requests = %d, want 1 for a retried call", tokens.Load())
 	}
 }
@@ -5,12 +5,16 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
 	"time"
+
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
 )
 
 // =============================================================================
@@ -60,7 +64,7 @@ func TestNewClient_WithOptions(t *testing.T) {
 		t.Fatalf("NewClient failed: %v", err)
 	}
 
-	if client.httpClient != customHTTPClient {
+	if client.HTTPClient != customHTTPClient {
 		t.Error("custom HTTP client was not set")
 	}
 	if client.baseURL != "http://custom-base.example.com" {
@@ -150,6 +154,29 @@ func TestValidateOrgNumber(t *testing.T) {
 	}
 }
 
+func TestValidOrgNumberChecksum(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{"valid org number", "5560125790", true},
+		{"valid personal number", "8112189876", true},
+		{"valid 12-digit personal number", "198112189876", true},
+		{"wrong check digit", "5560125791", false},
+		{"11 digits", "55601257900", false},
+		{"not normalized", "556012-5790", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidOrgNumberChecksum(tt.input); got != tt.want {
+				t.Errorf("ValidOrgNumberChecksum(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
 // =============================================================================
 // Type Helper Method Tests
 // =============================================================================
@@ -755,6 +782,37 @@ func TestClient_IsAlive_Success(t *testing.T) {
 	}
 }
 
+func TestClient_RateLimitQuota(t *testing.T) {
+	calls := 0
+	client := createTestClient(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte("OK"))
+	})
+	policy, err := infra.ParseRateLimitPolicy("sweden/daily_quota=1")
+	if err != nil {
+		t.Fatalf("ParseRateLimitPolicy: %v", err)
+	}
+	WithRateLimitPolicy(policy)(client)
+
+	if _, err := client.IsAlive(context.Background()); err != nil {
+		t.Fatalf("IsAlive within the quota: %v", err)
+	}
+	_, err = client.IsAlive(context.Background())
+	var exhausted *infra.ErrQuotaExhausted
+	if !errors.As(err, &exhausted) {
+		t.Fatalf("err = %v, want the daily quota exhausted", err)
+	}
+	if calls != 1 {
+		t.Errorf("API saw %d calls, want 1", calls)
+	}
+	if got := client.CircuitBreakerStats().ConsecutiveFails; got != 0 {
+		t.Errorf("quota refusal counted as %d failures, want none", got)
+	}
+	if hosts := client.RateLimitStats().Hosts; len(hosts) != 1 || hosts[0].QuotaRemaining != 0 {
+		t.Errorf("hosts = %+v, want one with no quota left", hosts)
+	}
+}
+
 func TestClient_IsAlive_NotOK(t *testing.T) {
 	client := createTestClient(t, nil, func(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write([]byte("NOT OK"))
@@ -893,6 +951,12 @@ func TestGetCompanyMCP_Success(t *testing.T) {
 	if len(result.Company.IndustryCodes) != 1 {
 		t.Errorf("Expected 1 industry code, got %d", len(result.Company.IndustryCodes))
 	}
+	if n := result.Company.NACE; n == nil || n.Code != "62.01" || n.NationalLabel != "Dataprogrammering" {
+		t.Errorf("NACE = %+v, want 62.01 labelled Dataprogrammering", n)
+	}
+	if f := result.Company.LegalFormClass; f == nil || f.Code != "AB" || f.Category != "limited" {
+		t.Errorf("LegalFormClass = %+v, want AB in limited", f)
+	}
 }
 
 func TestGetCompanyMCP_ValidationError(t *testing.T) {
@@ -946,6 +1010,9 @@ func TestGetCompanyMCP_Deregistered(t *testing.T) {
 	if result.Company.IsActive {
 		t.Error("Expected IsActive=false for deregistered company")
 	}
+	if result.Company.Status != "DEREGISTERED" {
+		t.Errorf("Status = %q, want DEREGISTERED", result.Company.Status)
+	}
 	if result.Company.DeregisteredDate != "2024-01-15" {
 		t.Errorf("DeregisteredDate = %q, want %q", result.Company.DeregisteredDate, "2024-01-15")
 	}
@@ -1452,7 +1519,7 @@ func TestClient_DownloadDocument_CircuitBreakerOpen(t *testing.T) {
 	if err == nil {
 		t.Error("Expected error when circuit breaker is open")
 	}
-	if !strings.Contains(err.Error(), "circuit breaker open") {
+	if !strings.Contains(err.Error(), "circuit breaker is open") {
 		t.Errorf("Expected circuit breaker error, got: %v", err)
 	}
 }
@@ -1573,6 +1640,36 @@ func TestGetCompanyMCP_OngoingProceedings(t *testing.T) {
 	if !strings.Contains(result.Company.OngoingProceedings[0], "2024-01-15") {
 		t.Errorf("First proceeding should contain date, got: %s", result.Company.OngoingProceedings[0])
 	}
+	if result.Company.Status != "BANKRUPT" {
+		t.Errorf("Status = %q, want BANKRUPT", result.Company.Status)
+	}
+}
+
+func TestGetStatus(t *testing.T) {
+	proceedings := func(p ...PagaendeAvvecklingsEllerOmstruktureringsforfarandeObjekt) *Organisation {
+		return &Organisation{PagaendeAvvecklingsEllerOmstruktureringsforfarande: &PagaendeAvvecklingsEllerOmstruktureringsforfarande{
+			PagaendeAvvecklingsEllerOmstruktureringsforfarandeLista: p,
+		}}
+	}
+	tests := []struct {
+		name string
+		org  *Organisation
+		want string
+	}{
+		{"no proceedings", &Organisation{}, "ACTIVE"},
+		{"deregistered", &Organisation{AvregistreradOrganisation: &AvregistreradOrganisation{Avregistreringsdatum: "2024-01-15"}}, "DEREGISTERED"},
+		{"liquidation by text", proceedings(PagaendeAvvecklingsEllerOmstruktureringsforfarandeObjekt{Kod: "LI", Klartext: "Likvidation"}), "LIQUIDATING"},
+		{"reconstruction", proceedings(PagaendeAvvecklingsEllerOmstruktureringsforfarandeObjekt{Klartext: "Företagsrekonstruktion"}), "RECONSTRUCTION"},
+		{"liquidation over reconstruction", proceedings(
+			PagaendeAvvecklingsEllerOmstruktureringsforfarandeObjekt{Klartext: "Företagsrekonstruktion"},
+			PagaendeAvvecklingsEllerOmstruktureringsforfarandeObjekt{Kod: "LIKVIDATION"},
+		), "LIQUIDATING"},
+	}
+	for _, tt := range tests {
+		if got := getStatus(tt.org); got != tt.want {
+			t.Errorf("%s: getStatus = %q, want %q", tt.name, got, tt.want)
+		}
+	}
 }
 
 func TestGetCompanyMCP_JuridiskFormWithoutKlartext(t *testing.T) {
@@ -1778,7 +1875,7 @@ func TestClient_doRequest_CircuitBreakerOpen(t *testing.T) {
 	if err == nil {
 		t.Error("Expected error when circuit breaker is open")
 	}
-	if !strings.Contains(err.Error(), "circuit breaker open") {
+	if !strings.Contains(err.Error(), "circuit breaker is open") {
 		t.Errorf("Expected circuit breaker error, got: %v", err)
 	}
 }
@@ -1958,3 +2055,48 @@ func TestClient_GetDocumentList_Caching(t *testing.T) {
 		t.Errorf("API called %d times, want 1 (cached)", apiCalls)
 	}
 }
+
+func TestClient_BatchGetCompaniesMCP(t *testing.T) {
+	client := createTestClient(t, nil, func(w http.ResponseWriter, r *http.Request) {
+		var req OrganisationerBegaran
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		resp := OrganisationerSvar{}
+		if req.Identitetsbeteckning == "5560125790" {
+			resp.Organisationer = []Organisation{{
+				Organisationsidentitet: &Identitetsbeteckning{Identitetsbeteckning: "5560125790"},
+				Organisationsnamn: &Organisationsnamn{
+					OrganisationsnamnLista: []OrganisationsnamnObjekt{{Namn: "VOLVO AB"}},
+				},
+				VerksamOrganisation: &VerksamOrganisation{Kod: JaNejJA},
+			}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+
+	result, err := client.BatchGetCompaniesMCP(context.Background(), BatchGetCompaniesArgs{
+		OrgNumbers: []string{"556012-5790", "5565475489", "12AB"},
+	})
+	if err != nil {
+		t.Fatalf("BatchGetCompaniesMCP failed: %v", err)
+	}
+
+	if len(result.Companies) != 1 || result.Companies[0].Name != "VOLVO AB" {
+		t.Fatalf("unexpected companies: %+v", result.Companies)
+	}
+	wantMissing := []string{"5565475489", "12AB"}
+	wantReasons := []string{"not_found", "invalid"}
+	if len(result.Errors) != len(wantMissing) {
+		t.Fatalf("Errors = %+v, want %d entries", result.Errors, len(wantMissing))
+	}
+	for i, e := range result.Errors {
+		if e.ID != wantMissing[i] || e.Reason != wantReasons[i] || result.Missing[i] != wantMissing[i] {
+			t.Errorf("Errors[%d] = %+v, want id %q reason %q", i, e, wantMissing[i], wantReasons[i])
+		}
+	}
+
+	if _, err := client.BatchGetCompaniesMCP(context.Background(), BatchGetCompaniesArgs{OrgNumbers: make([]string, MaxBatchSize+1)}); !apierrors.IsValidation(err) {
+		t.Errorf("above MaxBatchSize: err = %v, want a validation error", err)
+	}
+}
@@ -6,8 +6,27 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/base"
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/legalform"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/nace"
+)
+
+// Bolagsverket reports SNI codes without saying whether they are SNI 2007 or
+// SNI 2025 codes; nace.FromRegistry tells them apart by the code.
+const (
+	industryScheme     = nace.SchemeSNI2007
+	industryScheme2025 = nace.SchemeSNI2025
 )
 
+// legalformCountry keys the organisationsform and juridisk form codes in the
+// legal-form table.
+const legalformCountry = "sweden"
+
 // MCP Tool wrapper methods
 // These methods wrap the client methods with Args/Result types for MCP integration.
 
@@ -19,6 +38,10 @@ var (
 	orgNumberPattern = regexp.MustCompile(`^\d{10,12}$`)
 )
 
+// MaxBatchSize is the maximum number of organization numbers per batch
+// lookup. Each one costs an authenticated Bolagsverket request.
+const MaxBatchSize = 100
+
 // ValidateOrgNumber validates a Swedish organization number.
 func ValidateOrgNumber(orgNumber string) error {
 	normalized := NormalizeOrgNumber(orgNumber)
@@ -31,6 +54,35 @@ func ValidateOrgNumber(orgNumber string) error {
 	return nil
 }
 
+// ValidOrgNumberChecksum reports whether a normalized Swedish identity number
+// passes the Luhn check. Organisation numbers are checked over all 10 digits;
+// 12-digit personal and coordination numbers drop the century prefix first.
+// Anything that is not 10 or 12 digits is reported as invalid.
+func ValidOrgNumberChecksum(orgNumber string) bool {
+	if !orgNumberPattern.MatchString(orgNumber) {
+		return false
+	}
+	switch len(orgNumber) {
+	case 10:
+	case 12:
+		orgNumber = orgNumber[2:]
+	default:
+		return false
+	}
+	sum := 0
+	for i := 0; i < len(orgNumber); i++ {
+		d := int(orgNumber[i] - '0')
+		if i%2 == 0 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return sum%10 == 0
+}
+
 // GetCompanyMCP is the MCP wrapper for GetCompany.
 func (c *Client) GetCompanyMCP(ctx context.Context, args GetCompanyArgs) (GetCompanyResult, error) {
 	if err := ValidateOrgNumber(args.OrgNumber); err != nil {
@@ -43,16 +95,92 @@ func (c *Client) GetCompanyMCP(ctx context.Context, args GetCompanyArgs) (GetCom
 	}
 
 	if resp == nil || len(resp.Organisationer) == 0 {
-		return GetCompanyResult{}, fmt.Errorf("no company found for organization number %s", args.OrgNumber)
+		return GetCompanyResult{}, apierrors.NewNotFoundError("sweden", args.OrgNumber)
 	}
 
-	// Take the first organization (there can be multiple for sole proprietors)
+	// There can be several organisations for a sole proprietor's personal
+	// number; ask which one is meant, taking the first when nobody answers.
 	org := resp.Organisationer[0]
+	if len(resp.Organisationer) > 1 {
+		picked, err := askOrganisation(ctx, args.OrgNumber, resp.Organisationer)
+		if err != nil {
+			return GetCompanyResult{}, err
+		}
+		if picked != nil {
+			org = *picked
+		}
+	}
 
 	summary := buildCompanySummary(&org)
 	return GetCompanyResult{Company: summary}, nil
 }
 
+// askOrganisation asks the user, when the client supports it, which of the
+// organisations registered under one number they mean. It returns nil when
+// the user was not asked or did not pick one.
+func askOrganisation(ctx context.Context, orgNumber string, orgs []Organisation) (*Organisation, error) {
+	q := infra.Question{
+		Key:     "organisation",
+		Message: fmt.Sprintf("Bolagsverket has %d organisations registered under %s. Which one do you mean?", len(orgs), orgNumber),
+	}
+	for i := range orgs {
+		status := "active"
+		if !orgs[i].IsActive() {
+			status = "deregistered"
+		}
+		var form string
+		if orgs[i].Organisationsform != nil {
+			form = orgs[i].Organisationsform.Klartext
+		}
+		q.Choices = append(q.Choices, infra.Choice{
+			Value: strconv.Itoa(i),
+			Label: infra.Label(orgs[i].GetName(), form, orgs[i].GetRegistrationDate(), status),
+		})
+	}
+	answer, err := infra.Ask(ctx, q)
+	if err != nil || !answer.Answered {
+		return nil, err
+	}
+	i, err := strconv.Atoi(answer.Value)
+	if err != nil || i < 0 || i >= len(orgs) {
+		return nil, nil
+	}
+	return &orgs[i], nil
+}
+
+// BatchGetCompaniesMCP looks up several organization numbers by fanning out
+// over GetCompanyMCP. Malformed, unknown and failed numbers are reported per
+// item instead of failing the call.
+func (c *Client) BatchGetCompaniesMCP(ctx context.Context, args BatchGetCompaniesArgs) (BatchGetCompaniesResult, error) {
+	if len(args.OrgNumbers) == 0 {
+		return BatchGetCompaniesResult{}, nil
+	}
+	if len(args.OrgNumbers) > MaxBatchSize {
+		return BatchGetCompaniesResult{}, apierrors.NewValidationError("org_numbers", fmt.Sprintf("%d items", len(args.OrgNumbers)),
+			fmt.Sprintf("exceeds maximum of %d organization numbers per request", MaxBatchSize))
+	}
+
+	res := base.BatchLookup(ctx, args.OrgNumbers, base.BatchSpec[CompanySummary]{
+		Workers:   c.Concurrency(),
+		Normalize: NormalizeOrgNumber,
+		Validate:  ValidateOrgNumber,
+		Fetch: func(ctx context.Context, orgNumber string) (CompanySummary, error) {
+			result, err := c.GetCompanyMCP(ctx, GetCompanyArgs{OrgNumber: orgNumber})
+			if err != nil {
+				return CompanySummary{}, err
+			}
+			return *result.Company, nil
+		},
+	})
+
+	return BatchGetCompaniesResult{
+		Companies:    res.Found,
+		TotalResults: len(res.Found),
+		Missing:      res.Missing,
+		Errors:       res.Errors,
+	}, nil
+}
+
 // buildCompanySummary projects a Bolagsverket Organisation into the flat
 // CompanySummary the MCP caller consumes. The Bolagsverket schema is heavily
 // optional, so the helpers below isolate each optional field group.
@@ -73,6 +201,7 @@ func buildCompanySummary(org *Organisation) *CompanySummary {
 	if org.JuridiskForm != nil {
 		summary.LegalForm = formatCodeKlartext(org.JuridiskForm.Kod, org.JuridiskForm.Klartext)
 	}
+	summary.LegalFormClass = legalFormClass(org)
 
 	if org.Registreringsland != nil {
 		summary.RegistrationCountry = org.Registreringsland.Klartext
@@ -80,7 +209,9 @@ func buildCompanySummary(org *Organisation) *CompanySummary {
 
 	applyDeregistrationInfo(summary, org)
 	summary.OngoingProceedings = collectOngoingProceedings(org)
+	summary.Status = getStatus(org)
 	summary.IndustryCodes = collectSNICodes(org)
+	summary.NACE = primaryNACE(org)
 
 	if org.Reklamsparr != nil && org.Reklamsparr.Kod == JaNejJA {
 		summary.AdBlockEnabled = true
@@ -112,6 +243,34 @@ func applyDeregistrationInfo(summary *CompanySummary, org *Organisation) {
 	}
 }
 
+// getStatus derives company status from the deregistration and the ongoing
+// proceedings Bolagsverket reports. Proceedings are recognised by their code
+// or text, so bankruptcy wins over liquidation and liquidation over
+// reconstruction when several are ongoing.
+//
+//nolint:misspell // Swedish API uses "Organisation"
+func getStatus(org *Organisation) string {
+	if org.AvregistreradOrganisation != nil && org.AvregistreradOrganisation.Avregistreringsdatum != "" {
+		return "DEREGISTERED"
+	}
+	status := "ACTIVE"
+	if org.PagaendeAvvecklingsEllerOmstruktureringsforfarande == nil {
+		return status
+	}
+	for _, p := range org.PagaendeAvvecklingsEllerOmstruktureringsforfarande.PagaendeAvvecklingsEllerOmstruktureringsforfarandeLista {
+		s := strings.ToLower(p.Kod + " " + p.Klartext)
+		switch {
+		case strings.Contains(s, "konkurs"):
+			return "BANKRUPT"
+		case strings.Contains(s, "likvidation"):
+			status = "LIQUIDATING"
+		case strings.Contains(s, "rekonstruktion") && status == "ACTIVE":
+			status = "RECONSTRUCTION"
+		}
+	}
+	return status
+}
+
 // collectOngoingProceedings flattens the Bolagsverket bankruptcy / liquidation
 // list into human-readable strings.
 //
@@ -150,6 +309,35 @@ func collectSNICodes(org *Organisation) []string {
 	return out
 }
 
+// legalFormClass classifies the company's legal form. The organisationsform
+// is tried first because the juridisk form lumps some forms together (31
+// covers both HB and KB).
+//
+//nolint:misspell // Swedish API uses "Organisation"
+func legalFormClass(org *Organisation) *legalform.Form {
+	var candidates []string
+	if org.Organisationsform != nil {
+		candidates = append(candidates, org.Organisationsform.Kod)
+	}
+	if org.JuridiskForm != nil {
+		candidates = append(candidates, org.JuridiskForm.Kod)
+	}
+	return legalform.Lookup(legalformCountry, candidates...)
+}
+
+// primaryNACE maps the first classifiable SNI code to NACE. Bolagsverket
+// lists the primary code first.
+//
+//nolint:misspell // Swedish API uses "Organisation"
+func primaryNACE(org *Organisation) *nace.Code {
+	for _, sni := range org.GetSNICodes() {
+		if code := nace.FromRegistry(industryScheme, industryScheme2025, sni.Kod, sni.Klartext); code != nil {
+			return code
+		}
+	}
+	return nil
+}
+
 // GetDocumentListMCP is the MCP wrapper for GetDocumentList.
 func (c *Client) GetDocumentListMCP(ctx context.Context, args GetDocumentListArgs) (GetDocumentListResult, error) {
 	if err := ValidateOrgNumber(args.OrgNumber); err != nil {
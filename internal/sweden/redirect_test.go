@@ -54,13 +54,13 @@ func TestHTTPClient_Refuses307_NoCredentialLeak(t *testing.T) {
 		t.Fatalf("NewClient: %v", err)
 	}
 
-	// Send a raw request through the configured httpClient. Use the wiki
+	// Send a raw request through the configured HTTPClient. Use the wiki
 	// URL so the response is the 307; CheckRedirect must short-circuit.
 	req, err := http.NewRequest(http.MethodPost, wiki.URL, strings.NewReader("client_id=X&client_secret=SECRET"))
 	if err != nil {
 		t.Fatalf("NewRequest: %v", err)
 	}
-	resp, err := client.httpClient.Do(req)
+	resp, err := client.HTTPClient.Do(req)
 	if err != nil {
 		t.Fatalf("client.Do: %v", err)
 	}
@@ -81,11 +81,11 @@ func TestHTTPClient_ProductionConstructor_HasCheckRedirect(t *testing.T) {
 	if err != nil {
 		t.Fatalf("NewClient: %v", err)
 	}
-	if client.httpClient.CheckRedirect == nil {
+	if client.HTTPClient.CheckRedirect == nil {
 		t.Fatal("production constructor missing CheckRedirect (regression — credential leak vector)")
 	}
 	// The policy must refuse, not no-op.
-	got := client.httpClient.CheckRedirect(nil, nil)
+	got := client.HTTPClient.CheckRedirect(nil, nil)
 	if got != http.ErrUseLastResponse {
 		t.Errorf("CheckRedirect returned %v, want http.ErrUseLastResponse", got)
 	}
@@ -0,0 +1,225 @@
+package vies
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/base"
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
+)
+
+const (
+	// BaseURL is the VIES REST API endpoint
+	BaseURL = "https://ec.europa.eu/taxation_customs/vies/rest-api"
+
+	// DefaultUserAgent is the default user agent for VIES requests
+	DefaultUserAgent = "nordic-registry-mcp-server/1.0 (github.com/olgasafonova/nordic-registry-mcp-server)"
+)
+
+var (
+	countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+	vatNumberPattern   = regexp.MustCompile(`^[0-9A-Z+*]{2,12}$`)
+)
+
+// Client provides access to the EU VIES VAT-number check service
+type Client struct {
+	*base.Client
+	baseURL   string
+	userAgent string
+}
+
+// ClientOption configures the Client
+type ClientOption func(*Client)
+
+// WithHTTPClient sets a custom HTTP client
+func WithHTTPClient(c *http.Client) ClientOption {
+	return func(client *Client) {
+		client.HTTPClient = c
+	}
+}
+
+// WithLogger sets a custom logger
+func WithLogger(l *slog.Logger) ClientOption {
+	return func(client *Client) {
+		client.Logger = l
+	}
+}
+
+// WithCache sets a custom cache, closing the one it replaces
+func WithCache(c *infra.Cache) ClientOption {
+	return func(client *Client) {
+		if client.Cache != nil && client.Cache != c {
+			client.Cache.Close()
+		}
+		client.Cache = c
+	}
+}
+
+// WithCachePolicy sets the cache TTLs of the client's operations
+func WithCachePolicy(p *infra.CachePolicy) ClientOption {
+	return func(client *Client) {
+		client.CachePolicy = p
+	}
+}
+
+// WithBreakerPolicy sets the client's circuit breaker from the policy
+func WithBreakerPolicy(p *infra.BreakerPolicy) ClientOption {
+	return func(client *Client) {
+		client.CircuitBreaker = p.NewCircuitBreaker("vies")
+	}
+}
+
+// WithHedging turns on hedged requests with cfg; nil turns them off
+func WithHedging(cfg *base.HedgeConfig) ClientOption {
+	return func(client *Client) {
+		base.WithHedging(cfg)(client.Client)
+	}
+}
+
+// WithRateLimitPolicy sets the request budget of the client's host from the
+// policy
+func WithRateLimitPolicy(p *infra.RateLimitPolicy) ClientOption {
+	return func(client *Client) {
+		client.RateLimiter = p.NewRateLimiter("vies")
+	}
+}
+
+// WithBaseURL sets a custom base URL (for testing against a local stub)
+func WithBaseURL(url string) ClientOption {
+	return func(client *Client) {
+		client.baseURL = strings.TrimSuffix(url, "/")
+	}
+}
+
+// NewClient creates a new VIES client
+func NewClient(opts ...ClientOption) *Client {
+	c := &Client{
+		Client: base.NewClient(
+			base.WithRegistry("vies"),
+			base.WithCircuitBreaker(infra.DefaultBreakerPolicy().NewCircuitBreaker("vies")),
+			base.WithRateLimiter(infra.DefaultRateLimitPolicy().NewRateLimiter("vies")),
+		),
+		baseURL:   BaseURL,
+		userAgent: DefaultUserAgent,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// CheckVAT asks VIES whether a VAT number is registered. countryCode is the
+// two-letter VIES member-state code (DK, FI, SE, ...) and number the VAT
+// number without that prefix.
+//
+// An unregistered number is not an error: the response has IsValid false.
+// Malformed input returns a ValidationError. A member-state service that is
+// down or busy returns an error and is not cached, so the next call retries.
+func (c *Client) CheckVAT(ctx context.Context, countryCode, number string) (*CheckResponse, error) {
+	countryCode = strings.ToUpper(strings.TrimSpace(countryCode))
+	number = strings.ToUpper(strings.NewReplacer(" ", "", ".", "", "-", "").Replace(number))
+	number = strings.TrimPrefix(number, countryCode)
+
+	if !countryCodePattern.MatchString(countryCode) {
+		return nil, apierrors.NewValidationError("country_code", countryCode, "must be a two-letter VIES member-state code")
+	}
+	if !vatNumberPattern.MatchString(number) {
+		return nil, apierrors.NewValidationError("vat_number", number, "must be 2-12 letters or digits")
+	}
+
+	cacheKey := "vat:" + countryCode + number
+	return infra.Fetch(ctx, c.Cache, cacheKey, c.CachePolicy.TTL("vies", "vat"), func(ctx context.Context) (*CheckResponse, error) {
+		result, _, err := c.Dedup.Do(ctx, cacheKey, func() (interface{}, error) {
+			return c.doCheck(ctx, countryCode, number)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result.(*CheckResponse), nil
+	})
+}
+
+// InvalidateVAT drops the cached check of a VAT number, given with its
+// country prefix (DK10150817), so the next check goes to VIES.
+func (c *Client) InvalidateVAT(vatNumber string) {
+	vatNumber = strings.ToUpper(strings.NewReplacer(" ", "", ".", "", "-", "").Replace(vatNumber))
+	c.Cache.Delete("vat:" + vatNumber)
+}
+
+// doCheck performs the VIES request and classifies the outcome.
+func (c *Client) doCheck(ctx context.Context, countryCode, number string) (*CheckResponse, error) {
+	reqURL := fmt.Sprintf("%s/ms/%s/vat/%s", c.baseURL, url.PathEscape(countryCode), url.PathEscape(number))
+
+	res, err := c.Client.DoRequest(ctx, base.RequestConfig{
+		URL:       reqURL,
+		UserAgent: c.userAgent,
+	})
+	if err != nil {
+		return nil, err
+	}
+	body, statusCode := res.Body, res.StatusCode
+
+	if statusCode >= 400 {
+		c.RecordSuccess() // Client errors don't indicate service issues
+		var errResp ErrorResponse
+		if json.Unmarshal(body, &errResp) == nil && errResp.code() != "" {
+			return nil, classifyUserError(errResp.code(), countryCode, number)
+		}
+		return nil, fmt.Errorf("VIES error %d: %s", statusCode, truncateBody(body))
+	}
+
+	var resp CheckResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse VIES response: %w", err)
+	}
+	c.RecordSuccess()
+
+	switch resp.UserError {
+	case "", UserErrorValid, UserErrorInvalid:
+	default:
+		return nil, classifyUserError(resp.UserError, countryCode, number)
+	}
+
+	if resp.CountryCode == "" {
+		resp.CountryCode = countryCode
+	}
+	if resp.VATNumber == "" {
+		resp.VATNumber = number
+	}
+	return &resp, nil
+}
+
+// classifyUserError turns a VIES error code into an error. Only
+// INVALID_INPUT is the caller's fault; every other code means the answer is
+// unknown for now.
+func classifyUserError(code, countryCode, number string) error {
+	switch code {
+	case UserErrorInvalidInput:
+		return apierrors.NewValidationError("vat_number", countryCode+number, "rejected by VIES as malformed")
+	case UserErrorMSUnavailable, UserErrorTimeout:
+		return fmt.Errorf("VIES: the %s VAT service is unavailable (%s), try again later", countryCode, code)
+	case UserErrorServiceUnavailable, UserErrorMSMaxConcurrent, UserErrorGlobalMaxConcurrent:
+		return fmt.Errorf("VIES is busy or unavailable (%s), try again later", code)
+	}
+	return fmt.Errorf("VIES error: %s", code)
+}
+
+// maxBodyInError caps how many bytes of an unparsed upstream body land in
+// caller-facing error messages. See HG-2 in rules/review-patterns.md.
+const maxBodyInError = 256
+
+// truncateBody bounds a non-envelope upstream body (HTML error pages, proxy
+// interstitials) before it is echoed in an error message.
+func truncateBody(body []byte) string {
+	if len(body) <= maxBodyInError {
+		return string(body)
+	}
+	return string(body[:maxBodyInError]) + "..."
+}
@@ -0,0 +1,148 @@
+package vies
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+)
+
+// newStub serves canned VIES responses keyed by request path.
+func newStub(t *testing.T, calls *int32) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls != nil {
+			atomic.AddInt32(calls, 1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/ms/DK/vat/10150817":
+			_, _ = w.Write([]byte(`{"isValid":true,"requestDate":"2026-10-18T10:00:00.000Z","userError":"VALID","name":"NOVO NORDISK A/S","address":"Novo Allé 1\n2880 Bagsværd","vatNumber":"10150817"}`))
+		case "/ms/SE/vat/556012579001":
+			_, _ = w.Write([]byte(`{"isValid":true,"userError":"VALID","name":"---","address":"---","vatNumber":"556012579001"}`))
+		case "/ms/FI/vat/00000000":
+			_, _ = w.Write([]byte(`{"isValid":false,"userError":"INVALID","name":"---","address":"---"}`))
+		case "/ms/FI/vat/01120389":
+			_, _ = w.Write([]byte(`{"isValid":false,"userError":"MS_UNAVAILABLE"}`))
+		case "/ms/DK/vat/XX":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"actionSucceed":false,"errorWrappers":[{"error":"INVALID_INPUT"}]}`))
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("<html>" + strings.Repeat("x", 1000) + "</html>"))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	c := NewClient(WithBaseURL(server.URL + "/"))
+	t.Cleanup(c.Close)
+	return c
+}
+
+func TestCheckVAT_Valid(t *testing.T) {
+	c := newStub(t, nil)
+
+	resp, err := c.CheckVAT(context.Background(), "dk", "DK 10 15 08 17")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.IsValid {
+		t.Error("expected valid VAT number")
+	}
+	if resp.CountryCode != "DK" || resp.VATNumber != "10150817" {
+		t.Errorf("got %s %s, want DK 10150817", resp.CountryCode, resp.VATNumber)
+	}
+	if got := resp.RegisteredName(); got != "NOVO NORDISK A/S" {
+		t.Errorf("RegisteredName() = %q", got)
+	}
+	if got, want := resp.RegisteredAddress(), "Novo Allé 1, 2880 Bagsværd"; got != want {
+		t.Errorf("RegisteredAddress() = %q, want %q", got, want)
+	}
+}
+
+func TestCheckVAT_WithheldDetails(t *testing.T) {
+	c := newStub(t, nil)
+
+	resp, err := c.CheckVAT(context.Background(), "SE", "556012579001")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.IsValid || resp.RegisteredName() != "" || resp.RegisteredAddress() != "" {
+		t.Errorf("got valid=%v name=%q address=%q, want valid with withheld details",
+			resp.IsValid, resp.RegisteredName(), resp.RegisteredAddress())
+	}
+}
+
+func TestCheckVAT_InvalidIsNotAnError(t *testing.T) {
+	c := newStub(t, nil)
+
+	resp, err := c.CheckVAT(context.Background(), "FI", "00000000")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.IsValid {
+		t.Error("expected invalid VAT number")
+	}
+}
+
+func TestCheckVAT_Errors(t *testing.T) {
+	c := newStub(t, nil)
+
+	tests := []struct {
+		name       string
+		country    string
+		number     string
+		validation bool
+		contains   string
+	}{
+		{"bad country code", "D1", "10150817", true, ""},
+		{"bad number", "DK", "1", true, ""},
+		{"rejected by VIES", "DK", "XX", true, ""},
+		{"member state down", "FI", "01120389", false, "MS_UNAVAILABLE"},
+		{"unparsed body is truncated", "DK", "99999999", false, "403"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.CheckVAT(context.Background(), tt.country, tt.number)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if apierrors.IsValidation(err) != tt.validation {
+				t.Errorf("IsValidation = %v, want %v (err: %v)", !tt.validation, tt.validation, err)
+			}
+			if !strings.Contains(err.Error(), tt.contains) {
+				t.Errorf("error %q does not contain %q", err, tt.contains)
+			}
+			if len(err.Error()) > maxBodyInError+100 {
+				t.Errorf("error message not truncated: %d bytes", len(err.Error()))
+			}
+		})
+	}
+}
+
+func TestCheckVAT_CachesResultsButNotOutages(t *testing.T) {
+	var calls int32
+	c := newStub(t, &calls)
+
+	for range 2 {
+		if _, err := c.CheckVAT(context.Background(), "DK", "10150817"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("valid check made %d requests, want 1", calls)
+	}
+
+	calls = 0
+	for range 2 {
+		_, _ = c.CheckVAT(context.Background(), "FI", "01120389")
+	}
+	if calls != 2 {
+		t.Errorf("unavailable check made %d requests, want 2", calls)
+	}
+}
@@ -0,0 +1,69 @@
+// Package vies provides a client for the European Commission's VIES
+// (VAT Information Exchange System) service, which confirms whether an EU VAT
+// number is currently registered for intra-community trade.
+package vies
+
+import "strings"
+
+// User error codes returned by VIES in CheckResponse.UserError.
+const (
+	UserErrorValid               = "VALID"
+	UserErrorInvalid             = "INVALID"
+	UserErrorInvalidInput        = "INVALID_INPUT"
+	UserErrorMSUnavailable       = "MS_UNAVAILABLE"
+	UserErrorServiceUnavailable  = "SERVICE_UNAVAILABLE"
+	UserErrorTimeout             = "TIMEOUT"
+	UserErrorMSMaxConcurrent     = "MS_MAX_CONCURRENT_REQ"
+	UserErrorGlobalMaxConcurrent = "GLOBAL_MAX_CONCURRENT_REQ"
+)
+
+// CheckResponse is the VIES answer for one VAT number
+// (GET /ms/{countryCode}/vat/{vatNumber}).
+type CheckResponse struct {
+	CountryCode       string `json:"countryCode,omitempty"`
+	VATNumber         string `json:"vatNumber"`
+	IsValid           bool   `json:"isValid"`
+	RequestDate       string `json:"requestDate,omitempty"`
+	UserError         string `json:"userError,omitempty"`
+	Name              string `json:"name,omitempty"`
+	Address           string `json:"address,omitempty"`
+	RequestIdentifier string `json:"requestIdentifier,omitempty"`
+}
+
+// RegisteredName returns the trader name, or "" when the member state does
+// not disclose it (VIES then sends "---").
+func (r *CheckResponse) RegisteredName() string {
+	return disclosed(r.Name)
+}
+
+// RegisteredAddress returns the trader address on one line, or "" when the
+// member state does not disclose it.
+func (r *CheckResponse) RegisteredAddress() string {
+	return strings.Join(strings.Fields(strings.ReplaceAll(disclosed(r.Address), "\n", ", ")), " ")
+}
+
+// disclosed maps the VIES placeholder for withheld data to "".
+func disclosed(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "---" {
+		return ""
+	}
+	return s
+}
+
+// ErrorResponse is the envelope VIES returns with 4xx status codes.
+type ErrorResponse struct {
+	ActionSucceed bool `json:"actionSucceed"`
+	ErrorWrappers []struct {
+		Error   string `json:"error"`
+		Message string `json:"message,omitempty"`
+	} `json:"errorWrappers"`
+}
+
+// code returns the first error code in the envelope, or "".
+func (e ErrorResponse) code() string {
+	if len(e.ErrorWrappers) == 0 {
+		return ""
+	}
+	return e.ErrorWrappers[0].Error
+}
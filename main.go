@@ -12,16 +12,26 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"slices"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/olgasafonova/mcp-cache-go/mcpcache"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/admin"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/base"
 	"github.com/olgasafonova/nordic-registry-mcp-server/internal/denmark"
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
 	"github.com/olgasafonova/nordic-registry-mcp-server/internal/finland"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/lei"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/nordic"
 	"github.com/olgasafonova/nordic-registry-mcp-server/internal/norway"
 	"github.com/olgasafonova/nordic-registry-mcp-server/internal/sweden"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/vies"
+	"github.com/olgasafonova/nordic-registry-mcp-server/metrics"
 	"github.com/olgasafonova/nordic-registry-mcp-server/tools"
 	"github.com/olgasafonova/nordic-registry-mcp-server/tracing"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -39,6 +49,18 @@ type cliFlags struct {
 	allowedOrigins string
 	rateLimit      int
 	trustedProxies string
+	stateful       bool
+	countries      string
+	categories     string
+	allowTools     string
+	denyTools      string
+	cacheDir       string
+	cacheTTL       string
+	circuitBreaker string
+	registryRate   string
+	hedge          string
+	adminToken     string
+	adminTools     bool
 }
 
 // countryClients groups the per-country registry clients.
@@ -47,17 +69,41 @@ type countryClients struct {
 	denmark *denmark.Client
 	finland *finland.Client
 	sweden  *sweden.Client
+	vies    *vies.Client   // EU VAT-number checks
+	nordic  *nordic.Client // Cross-registry tools over the clients above
+	lei     *lei.Index     // GLEIF golden copy; nil when not configured
+}
+
+// clientPolicies groups the operator-tunable settings of the registry
+// clients, each nil for the built-in defaults.
+type clientPolicies struct {
+	cache      *infra.CachePolicy
+	breakers   *infra.BreakerPolicy
+	rateLimits *infra.RateLimitPolicy
+	hedged     map[string]bool // Registries whose requests are hedged
+}
+
+// hedging returns the hedging configuration of registry, nil when its
+// requests are not hedged.
+func (p clientPolicies) hedging(registry string) *base.HedgeConfig {
+	if !p.hedged[registry] {
+		return nil
+	}
+	cfg := base.DefaultHedgeConfig
+	return &cfg
 }
 
 // httpServerConfig groups everything runHTTPServer needs to stand up the
 // HTTP transport, replacing an 11-argument signature.
 type httpServerConfig struct {
-	server    *mcp.Server
-	logger    *slog.Logger
-	flags     cliFlags
-	authToken string
-	clients   *countryClients
-	registry  *tools.HandlerRegistry
+	server     *mcp.Server
+	logger     *slog.Logger
+	flags      cliFlags
+	authToken  string
+	adminToken string         // Enables the /admin endpoints when set
+	admin      *admin.Console // Behind the /admin endpoints
+	clients    *countryClients
+	registry   *tools.HandlerRegistry
 }
 
 func parseFlags() cliFlags {
@@ -66,6 +112,18 @@ func parseFlags() cliFlags {
 	allowedOrigins := flag.String("origins", "", "Comma-separated allowed origins for CORS.")
 	rateLimit := flag.Int("rate-limit", 60, "Maximum requests per minute per IP (0 = unlimited)")
 	trustedProxies := flag.String("trusted-proxies", "", "Comma-separated trusted proxy IPs/CIDRs.")
+	stateful := flag.Bool("stateful", false, "Keep HTTP sessions so clients can subscribe to resources (serves protocol revisions before 2026-07-28 only).")
+	countries := flag.String("countries", "", "Comma-separated countries whose tools to expose (norway, denmark, finland, sweden, nordic, admin). Can also use TOOL_COUNTRIES env var.")
+	categories := flag.String("categories", "", "Comma-separated tool categories to expose (e.g. search,read,roles). Can also use TOOL_CATEGORIES env var.")
+	allowTools := flag.String("tools", "", "Comma-separated tool names to expose; alone, only these are exposed. Can also use TOOL_ALLOW env var.")
+	denyTools := flag.String("exclude-tools", "", "Comma-separated tool names to hide. Can also use TOOL_DENY env var.")
+	cacheDir := flag.String("cache-dir", "", "Directory for the on-disk response cache shared by server processes (\"off\" disables it). Can also use CACHE_DIR env var. Defaults to the user cache directory.")
+	cacheTTL := flag.String("cache-ttl", "", "Comma-separated cache TTL overrides as country/operation=duration (e.g. norway/search=30s,vies/vat=5m). Can also use CACHE_TTL env var.")
+	circuitBreaker := flag.String("circuit-breaker", "", "Comma-separated circuit breaker overrides as country/setting=value (e.g. finland/failure_rate=0.4,norway/reset=1m). Can also use CIRCUIT_BREAKER env var.")
+	registryRate := flag.String("registry-rate-limit", "", "Comma-separated registry request budget overrides as country/setting=value (e.g. denmark/daily_quota=1000,norway/rate=5). Can also use REGISTRY_RATE_LIMIT env var.")
+	hedge := flag.String("hedge", "", "Comma-separated registries whose slow requests are hedged with a second request past the p95 latency (norway, denmark, finland, sweden, vies). Can also use HEDGE_REGISTRIES env var.")
+	adminToken := flag.String("admin-token", "", "Token for the /admin endpoints and, over HTTP, the admin tools; separate from -token. Can also use MCP_ADMIN_TOKEN env var.")
+	adminTools := flag.Bool("admin-tools", false, "Expose the admin_* tools for inspecting and purging caches and resetting circuit breakers.")
 	flag.Parse()
 
 	return cliFlags{
@@ -74,6 +132,18 @@ func parseFlags() cliFlags {
 		allowedOrigins: *allowedOrigins,
 		rateLimit:      *rateLimit,
 		trustedProxies: *trustedProxies,
+		stateful:       *stateful,
+		countries:      *countries,
+		categories:     *categories,
+		allowTools:     *allowTools,
+		denyTools:      *denyTools,
+		cacheDir:       *cacheDir,
+		cacheTTL:       *cacheTTL,
+		circuitBreaker: *circuitBreaker,
+		registryRate:   *registryRate,
+		hedge:          *hedge,
+		adminToken:     *adminToken,
+		adminTools:     *adminTools,
 	}
 }
 
@@ -97,27 +167,172 @@ func setupTracing(logger *slog.Logger) func() {
 }
 
 // buildClients creates the per-country registry clients. The Sweden client
-// is only created when OAuth2 credentials are configured.
-func buildClients(logger *slog.Logger) *countryClients {
+// is only created when OAuth2 credentials are configured. With a cacheDir,
+// each client's cache is backed by a disk store in its own subdirectory;
+// policy sets how long each operation's answers are cached.
+func buildClients(logger *slog.Logger, cacheDir string, policies clientPolicies) *countryClients {
 	clients := &countryClients{
-		norway:  norway.NewClient(norway.WithLogger(logger)),
-		denmark: denmark.NewClient(denmark.WithLogger(logger)),
-		finland: finland.NewClient(finland.WithLogger(logger)),
+		norway: norway.NewClient(norway.WithLogger(logger), norway.WithCache(clientCache(logger, cacheDir, "norway")),
+			norway.WithCachePolicy(policies.cache), norway.WithBreakerPolicy(policies.breakers), norway.WithRateLimitPolicy(policies.rateLimits),
+			norway.WithHedging(policies.hedging("norway"))),
+		denmark: denmark.NewClient(denmark.WithLogger(logger), denmark.WithCache(clientCache(logger, cacheDir, "denmark")),
+			denmark.WithCachePolicy(policies.cache), denmark.WithBreakerPolicy(policies.breakers), denmark.WithRateLimitPolicy(policies.rateLimits),
+			denmark.WithHedging(policies.hedging("denmark"))),
+		finland: finland.NewClient(finland.WithLogger(logger), finland.WithCache(clientCache(logger, cacheDir, "finland")),
+			finland.WithCachePolicy(policies.cache), finland.WithBreakerPolicy(policies.breakers), finland.WithRateLimitPolicy(policies.rateLimits),
+			finland.WithHedging(policies.hedging("finland"))),
+		vies: vies.NewClient(vies.WithLogger(logger), vies.WithCache(clientCache(logger, cacheDir, "vies")),
+			vies.WithCachePolicy(policies.cache), vies.WithBreakerPolicy(policies.breakers), vies.WithRateLimitPolicy(policies.rateLimits),
+			vies.WithHedging(policies.hedging("vies"))),
 	}
 
+	clients.sweden = buildSwedenClient(logger, cacheDir, policies)
+	clients.lei = buildLEIIndex(logger)
+	clients.nordic = nordic.NewClient(nordic.Config{
+		Norway:  clients.norway,
+		Denmark: clients.denmark,
+		Finland: clients.finland,
+		Sweden:  clients.sweden,
+		VIES:    clients.vies,
+		Logger:  logger,
+	})
+	return clients
+}
+
+// buildSwedenClient returns the Bolagsverket client, or nil when OAuth2
+// credentials are missing or the client cannot be created.
+func buildSwedenClient(logger *slog.Logger, cacheDir string, policies clientPolicies) *sweden.Client {
 	if !sweden.IsConfigured() {
 		logger.Info("Sweden client not configured (set BOLAGSVERKET_CLIENT_ID and BOLAGSVERKET_CLIENT_SECRET)")
-		return clients
+		return nil
 	}
 
-	swedenClient, err := sweden.NewClient()
+	swedenClient, err := sweden.NewClient(sweden.WithLogger(logger), sweden.WithCache(clientCache(logger, cacheDir, "sweden")),
+		sweden.WithCachePolicy(policies.cache), sweden.WithBreakerPolicy(policies.breakers),
+		sweden.WithRateLimitPolicy(policies.rateLimits), sweden.WithHedging(policies.hedging("sweden")))
 	if err != nil {
 		logger.Warn("Failed to create Sweden client", "error", err)
-		return clients
+		return nil
 	}
-	clients.sweden = swedenClient
 	logger.Info("Sweden client initialized (OAuth2 credentials configured)")
-	return clients
+	return swedenClient
+}
+
+// clientCache returns the response cache of one registry client: an
+// in-memory LRU, backed by a disk store in dir/name unless dir is empty or
+// the store cannot be opened. Its negative-cache counters are exported as
+// metrics labelled with name.
+func clientCache(logger *slog.Logger, dir, name string) *infra.Cache {
+	cache := newClientCache(logger, dir, name)
+	metrics.WatchNegativeCache(name, cache)
+	return cache
+}
+
+// newClientCache builds the cache returned by clientCache.
+func newClientCache(logger *slog.Logger, dir, name string) *infra.Cache {
+	if dir == "" {
+		return infra.NewCache(infra.DefaultMaxCacheEntries)
+	}
+	store, err := infra.NewDiskStore(filepath.Join(dir, name), infra.DefaultDiskCacheBytes)
+	if err != nil {
+		logger.Warn("Disk cache unavailable, caching in memory only", "registry", name, "error", err)
+		return infra.NewCache(infra.DefaultMaxCacheEntries)
+	}
+	return infra.NewTieredCache(infra.DefaultMaxCacheEntries, store)
+}
+
+// resolveCacheDir returns the disk cache directory from the flag, falling
+// back to the CACHE_DIR environment variable and then to a directory under
+// the user cache directory. "off" disables the disk cache (returns "").
+func resolveCacheDir(logger *slog.Logger, flagDir string) string {
+	dir := flagDir
+	if dir == "" {
+		dir = os.Getenv("CACHE_DIR")
+	}
+	switch dir {
+	case "off":
+		return ""
+	case "":
+		userDir, err := os.UserCacheDir()
+		if err != nil {
+			logger.Info("Disk cache disabled (no user cache directory; set CACHE_DIR)", "error", err)
+			return ""
+		}
+		dir = filepath.Join(userDir, ServerName)
+	}
+	logger.Info("Disk cache enabled", "dir", dir)
+	return dir
+}
+
+// resolveCachePolicy builds the cache TTL policy from the flag, falling back
+// to the CACHE_TTL environment variable, on top of the built-in TTLs.
+func resolveCachePolicy(flagSpec string) (*infra.CachePolicy, error) {
+	spec := flagSpec
+	if spec == "" {
+		spec = os.Getenv("CACHE_TTL")
+	}
+	return infra.ParseCachePolicy(spec)
+}
+
+// resolveBreakerPolicy builds the circuit breaker policy from the flag,
+// falling back to the CIRCUIT_BREAKER environment variable, on top of the
+// built-in per-registry settings.
+func resolveBreakerPolicy(flagSpec string) (*infra.BreakerPolicy, error) {
+	spec := flagSpec
+	if spec == "" {
+		spec = os.Getenv("CIRCUIT_BREAKER")
+	}
+	return infra.ParseBreakerPolicy(spec)
+}
+
+// hedgeableRegistries are the registries whose clients can hedge requests.
+var hedgeableRegistries = []string{"norway", "denmark", "finland", "sweden", "vies"}
+
+// resolveHedgedRegistries returns the registries named by the flag, falling
+// back to the HEDGE_REGISTRIES environment variable. An unknown name is an
+// error.
+func resolveHedgedRegistries(flagValue string) (map[string]bool, error) {
+	if flagValue == "" {
+		flagValue = os.Getenv("HEDGE_REGISTRIES")
+	}
+	hedged := make(map[string]bool)
+	for _, name := range parseCSVList(strings.ToLower(flagValue)) {
+		if !slices.Contains(hedgeableRegistries, name) {
+			return nil, fmt.Errorf("unknown registry %q (valid: %s)", name, strings.Join(hedgeableRegistries, ", "))
+		}
+		hedged[name] = true
+	}
+	return hedged, nil
+}
+
+// resolveRateLimitPolicy builds the registry request budgets from the flag,
+// falling back to the REGISTRY_RATE_LIMIT environment variable, on top of
+// the built-in per-registry budgets.
+func resolveRateLimitPolicy(flagSpec string) (*infra.RateLimitPolicy, error) {
+	spec := flagSpec
+	if spec == "" {
+		spec = os.Getenv("REGISTRY_RATE_LIMIT")
+	}
+	return infra.ParseRateLimitPolicy(spec)
+}
+
+// buildLEIIndex loads the GLEIF golden copy named by GLEIF_LEI_FILE (and
+// GLEIF_RR_FILE for parent LEIs). It returns nil when no file is configured
+// or loading fails; get_company results are then not LEI-enriched.
+func buildLEIIndex(logger *slog.Logger) *lei.Index {
+	if !lei.IsConfigured() {
+		logger.Info("LEI enrichment not configured (set GLEIF_LEI_FILE to a GLEIF golden-copy file)")
+		return nil
+	}
+
+	start := time.Now()
+	index, err := lei.LoadFromEnv()
+	if err != nil {
+		logger.Warn("Failed to load GLEIF golden copy, LEI enrichment disabled", "error", err)
+		return nil
+	}
+	logger.Info("LEI index loaded", "entities", index.Len(), "duration", time.Since(start).Round(time.Millisecond))
+	return index
 }
 
 // close releases all configured clients.
@@ -125,6 +340,7 @@ func (c *countryClients) close() {
 	c.norway.Close()
 	c.denmark.Close()
 	c.finland.Close()
+	c.vies.Close()
 	if c.sweden != nil {
 		c.sweden.Close()
 	}
@@ -139,8 +355,81 @@ func resolveAuthToken(flagToken string) string {
 	return os.Getenv("MCP_AUTH_TOKEN")
 }
 
-// buildServer creates the MCP server and registers all tools.
-func buildServer(logger *slog.Logger, clients *countryClients) (*mcp.Server, *tools.HandlerRegistry) {
+// resolveAdminToken returns the admin token from the flag, falling back to
+// the MCP_ADMIN_TOKEN environment variable.
+func resolveAdminToken(flagToken string) string {
+	if flagToken != "" {
+		return flagToken
+	}
+	return os.Getenv("MCP_ADMIN_TOKEN")
+}
+
+// buildAdminConsole returns the admin console over the registry clients.
+// Sweden is included when configured.
+func buildAdminConsole(clients *countryClients) *admin.Console {
+	targets := []admin.Target{
+		{Registry: "norway", Cache: clients.norway.Cache, Breaker: clients.norway.CircuitBreaker, Invalidate: clients.norway.InvalidateCompany},
+		{Registry: "denmark", Cache: clients.denmark.Cache, Breaker: clients.denmark.CircuitBreaker, Invalidate: clients.denmark.InvalidateCompany},
+		{Registry: "finland", Cache: clients.finland.Cache, Breaker: clients.finland.CircuitBreaker, Invalidate: clients.finland.InvalidateCompany},
+	}
+	if se := clients.sweden; se != nil {
+		targets = append(targets, admin.Target{Registry: "sweden", Cache: se.Cache, Breaker: se.CircuitBreaker, Invalidate: se.InvalidateCompany})
+	}
+	targets = append(targets, admin.Target{Registry: "vies", Cache: clients.vies.Cache, Breaker: clients.vies.CircuitBreaker, Invalidate: clients.vies.InvalidateVAT})
+	return admin.NewConsole(targets...)
+}
+
+// resolveToolFilter builds the tool filter from the flags, falling back to
+// the TOOL_COUNTRIES, TOOL_CATEGORIES, TOOL_ALLOW and TOOL_DENY environment
+// variables for each one left empty.
+func resolveToolFilter(flags cliFlags) (tools.ToolFilter, error) {
+	list := func(flagValue, env string) []string {
+		if flagValue == "" {
+			flagValue = os.Getenv(env)
+		}
+		return parseCSVList(strings.ToLower(flagValue))
+	}
+	filter := tools.ToolFilter{
+		Countries:  list(flags.countries, "TOOL_COUNTRIES"),
+		Categories: list(flags.categories, "TOOL_CATEGORIES"),
+		Allow:      list(flags.allowTools, "TOOL_ALLOW"),
+		Deny:       list(flags.denyTools, "TOOL_DENY"),
+	}
+	return filter, filter.Validate()
+}
+
+// resourcePollInterval returns how often subscribed resources are checked,
+// from RESOURCE_POLL_INTERVAL (a Go duration such as "2m") or the default.
+func resourcePollInterval(logger *slog.Logger) time.Duration {
+	v := os.Getenv("RESOURCE_POLL_INTERVAL")
+	if v == "" {
+		return tools.DefaultResourcePollInterval
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		logger.Warn("Invalid RESOURCE_POLL_INTERVAL, using default", "value", v, "default", tools.DefaultResourcePollInterval)
+		return tools.DefaultResourcePollInterval
+	}
+	return d
+}
+
+// buildServer creates the MCP server and registers the tools filter lets
+// through. A non-nil console adds the admin tools, which over HTTP need
+// adminToken.
+func buildServer(logger *slog.Logger, clients *countryClients, filter tools.ToolFilter, console *admin.Console, adminToken string) (*mcp.Server, *tools.HandlerRegistry) {
+	registry := tools.NewHandlerRegistry(tools.HandlerRegistryConfig{
+		NorwayClient:  clients.norway,
+		DenmarkClient: clients.denmark,
+		FinlandClient: clients.finland,
+		SwedenClient:  clients.sweden,
+		NordicClient:  clients.nordic,
+		LEIIndex:      clients.lei,
+		Admin:         console,
+		AdminToken:    adminToken,
+		Filter:        filter,
+		Logger:        logger,
+	})
+
 	server := mcp.NewServer(&mcp.Implementation{
 		Name:    ServerName,
 		Version: ServerVersion,
@@ -153,10 +442,18 @@ func buildServer(logger *slog.Logger, clients *countryClients) (*mcp.Server, *to
 		// causes intermittent connection failures in Claude Code CLI
 		// when many MCP servers start simultaneously. The client still
 		// discovers tools via the tools/list request during handshake.
+		// Resources and prompts are declared the same way, without
+		// listChanged; the SDK adds resources.subscribe and completions
+		// because their handlers are set.
 		Capabilities: &mcp.ServerCapabilities{
-			Tools: &mcp.ToolCapabilities{},
+			Tools:     &mcp.ToolCapabilities{},
+			Resources: &mcp.ResourceCapabilities{},
+			Prompts:   &mcp.PromptCapabilities{},
 		},
-		Instructions: serverInstructions,
+		SubscribeHandler:   registry.SubscribeResource,
+		UnsubscribeHandler: registry.UnsubscribeResource,
+		CompletionHandler:  registry.CompleteArgument,
+		Instructions:       registry.Instructions(),
 	})
 
 	// SEP-2549 requires ttlMs and cacheScope on every cacheable result, but the
@@ -179,13 +476,6 @@ func buildServer(logger *slog.Logger, clients *countryClients) (*mcp.Server, *to
 		},
 	}))
 
-	registry := tools.NewHandlerRegistry(tools.HandlerRegistryConfig{
-		NorwayClient:  clients.norway,
-		DenmarkClient: clients.denmark,
-		FinlandClient: clients.finland,
-		SwedenClient:  clients.sweden,
-		Logger:        logger,
-	})
 	registry.RegisterAll(server)
 	return server, registry
 }
@@ -228,20 +518,59 @@ func main() {
 		defer shutdownTracing()
 	}
 
-	clients := buildClients(logger)
+	var policies clientPolicies
+	var err error
+	if policies.cache, err = resolveCachePolicy(flags.cacheTTL); err != nil {
+		log.Fatalf("Invalid cache TTL: %v", err)
+	}
+	if policies.breakers, err = resolveBreakerPolicy(flags.circuitBreaker); err != nil {
+		log.Fatalf("Invalid circuit breaker: %v", err)
+	}
+	if policies.rateLimits, err = resolveRateLimitPolicy(flags.registryRate); err != nil {
+		log.Fatalf("Invalid registry rate limit: %v", err)
+	}
+	if policies.hedged, err = resolveHedgedRegistries(flags.hedge); err != nil {
+		log.Fatalf("Invalid hedge registries: %v", err)
+	}
+	clients := buildClients(logger, resolveCacheDir(logger, flags.cacheDir), policies)
 	defer clients.close()
 
+	filter, err := resolveToolFilter(flags)
+	if err != nil {
+		log.Fatalf("Invalid tool filter: %v", err)
+	}
+
 	authToken := resolveAuthToken(flags.bearerToken)
-	server, registry := buildServer(logger, clients)
+	adminToken := resolveAdminToken(flags.adminToken)
+	console := buildAdminConsole(clients)
+	var adminTools *admin.Console
+	if flags.adminTools {
+		// Over stdio the operator who started the server is the only
+		// caller; over HTTP admin tool calls must carry the admin token.
+		if flags.httpAddr != "" && adminToken == "" {
+			log.Fatalf("refusing to start: -admin-tools over HTTP needs -admin-token or MCP_ADMIN_TOKEN")
+		}
+		adminTools = console
+	}
+	server, registry := buildServer(logger, clients, filter, adminTools, adminToken)
+
+	// Subscribed resources are watched, and the brreg update feed drops
+	// changed companies from the cache, for both transports, like the server
+	// itself is shared between them.
+	watchCtx, stopWatching := context.WithCancel(context.Background())
+	defer stopWatching()
+	go registry.WatchResources(watchCtx, server, resourcePollInterval(logger))
 
 	if flags.httpAddr != "" {
 		runHTTPServer(httpServerConfig{
-			server:    server,
-			logger:    logger,
-			flags:     flags,
-			authToken: authToken,
-			clients:   clients,
-			registry:  registry,
+			server:     server,
+			logger:     logger,
+			flags:      flags,
+			authToken:  authToken,
+			adminToken: adminToken,
+			admin:      console,
+			clients:    clients,
+			registry:   registry,
 		})
 		return
 	}
@@ -249,148 +578,6 @@ func main() {
 	runStdioServer(server, logger)
 }
 
-const serverInstructions = `Nordic Registry MCP Server - Access Nordic Business Registries
-
-## Available Countries
-
-Currently supports:
-- **Norway** (Brønnøysundregistrene / data.brreg.no) - Norwegian business registry
-- **Denmark** (CVR / cvrapi.dk) - Danish business registry
-- **Finland** (PRH / avoindata.prh.fi) - Finnish business registry
-- **Sweden** (Bolagsverket / api.bolagsverket.se) - Swedish business registry (requires OAuth2 credentials)
-
-## Tool Selection Guide
-
-### Search for companies by name:
-"Find Norwegian companies named Equinor"
--> USE: norway_search_companies
-
-### Get company details by org number:
-"Get details for company 923609016"
--> USE: norway_get_company
-
-### Get board members and roles:
-"Who is on the board of 923609016?"
--> USE: norway_get_roles
-
-### Get branch offices:
-"What branches does company 923609016 have?"
--> USE: norway_get_subunits
-
-### Get a specific sub-unit:
-"Get details for sub-unit 912345678"
--> USE: norway_get_subunit
-
-### Monitor registry changes:
-"What companies changed since yesterday?"
--> USE: norway_get_updates
-
-## Danish Company Lookups
-
-### Search for Danish companies by name:
-"Find Danish company Novo Nordisk"
--> USE: denmark_search_companies
-
-### Get Danish company details by CVR:
-"Get details for CVR 10150817"
--> USE: denmark_get_company
-
-### Get production units (P-numbers):
-"What production units does CVR 10150817 have?"
--> USE: denmark_get_production_units
-
-### IMPORTANT: Danish Search Returns Only ONE Result
-
-The CVR API returns only one company per search. Large companies often have multiple legal entities with similar names. When searching for well-known or international companies, TRY MULTIPLE VARIATIONS:
-
-1. "[Company] Denmark" - Danish subsidiary (e.g., "Tietoevry Denmark")
-2. "[Company] A/S" or "[Company] ApS" - with legal form
-3. "[Company] DK" - common naming pattern
-4. "[Company] Holding" - holding company vs operating company
-5. Pre-merger/historical names - companies change names after M&A
-6. "[Company] filial" - branch of foreign company
-
-Example: Searching "Tietoevry" returns TIETOEVRY DK A/S (11 employees), but "Tietoevry Denmark" returns TIETOEVRY DENMARK A/S (56 employees) - a completely different legal entity.
-
-Always ask the user to clarify if the first result seems wrong (wrong size, wrong address, wrong industry).
-
-## Finnish Company Lookups
-
-### Search for Finnish companies by name:
-"Find Finnish company Nokia"
--> USE: finland_search_companies
-
-### Get Finnish company details by business ID:
-"Get details for business ID 0112038-9"
--> USE: finland_get_company
-
-### IMPORTANT: Finnish Search Can Return 900+ Results
-
-Common company names return too many results. To narrow down:
-
-1. Use exact legal name: "Nokia Oyj" instead of "Nokia"
-2. Filter by company_form: OY (private) or OYJ (public) for main operating companies
-3. Filter by location: city name to narrow geographically
-4. Combine filters: company_form=OY AND location=Helsinki
-
-Example: Searching "Nokia" returns 900+ results. Searching "Nokia Oyj" with company_form=OYJ returns just the main company.
-
-## Swedish Company Lookups
-
-Sweden has NO name search in this API - you must have the 10-digit organization number. Ask the user for the org number if not provided.
-
-### Get Swedish company details:
-"Get Swedish company 5560125790"
--> USE: sweden_get_company
-
-## Norwegian Organization Numbers
-
-Norwegian org numbers are 9 digits. Spaces and dashes are automatically removed.
-Examples: "923609016", "923 609 016", "923-609-016" all work.
-
-## Danish CVR Numbers
-
-Danish CVR numbers are 8 digits. Spaces, dashes, and "DK" prefix are automatically removed.
-Examples: "10150817", "DK-10150817", "DK10150817" all work.
-
-## Organization Forms (Norway)
-
-Common codes:
-- AS: Aksjeselskap (Limited company)
-- ASA: Allmennaksjeselskap (Public limited company)
-- ENK: Enkeltpersonforetak (Sole proprietorship)
-- NUF: Norsk avdeling av utenlandsk foretak (Norwegian branch of foreign company)
-- ANS: Ansvarlig selskap (General partnership)
-- DA: Delt ansvar (Limited partnership)
-- SA: Samvirkeforetak (Cooperative)
-- STI: Stiftelse (Foundation)
-
-## Company Types (Denmark)
-
-Common types:
-- A/S: Aktieselskab (Public limited company)
-- ApS: Anpartsselskab (Private limited company)
-- I/S: Interessentskab (General partnership)
-- K/S: Kommanditselskab (Limited partnership)
-- P/S: Partnerselskab (Partnership company)
-- IVS: Iværksætterselskab (Entrepreneurial company)
-- Enkeltmandsvirksomhed (Sole proprietorship)
-
-## Finnish Business IDs (Y-tunnus)
-
-Finnish business IDs are 7 digits + hyphen + check digit (e.g., 0112038-9).
-The FI prefix is automatically removed. Examples: "0112038-9", "FI0112038-9" both work.
-
-## Company Forms (Finland)
-
-Common codes:
-- OY: Osakeyhtiö (Private limited company)
-- OYJ: Julkinen osakeyhtiö (Public limited company)
-- Ky: Kommandiittiyhtiö (Limited partnership)
-- Ay: Avoin yhtiö (General partnership)
-- Tmi: Toiminimi (Sole proprietorship)
-- Osk: Osuuskunta (Cooperative)`
-
 // parseCSVList splits a comma-separated string into a slice, trimming
 // whitespace and dropping empty entries.
 func parseCSVList(s string) []string {
@@ -411,14 +598,29 @@ func parseCSVList(s string) []string {
 // and routes everything else through the shared secured handler. Only /health
 // and /ready stay unauthenticated so orchestrators can probe them; the
 // diagnostics endpoints and the MCP handler all sit behind the secured handler.
-func registerHTTPRoutes(cfg httpServerConfig, securedHandler http.Handler) *http.ServeMux {
+// A non-nil adminHandler serves /admin/ instead, behind its own token.
+func registerHTTPRoutes(cfg httpServerConfig, securedHandler, adminHandler http.Handler) *http.ServeMux {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/health", healthHandler)
 	mux.HandleFunc("/ready", readyHandler(cfg.clients))
+	if adminHandler != nil {
+		mux.Handle("/admin/", adminHandler)
+	}
 	mux.Handle("/", securedHandler)
 	return mux
 }
 
+// registerAdminRoutes wires the admin endpoints onto one mux. The caller
+// wraps it with a security middleware that checks the admin token rather
+// than the MCP bearer token, so a client of the MCP surface cannot purge
+// caches or open circuits.
+func registerAdminRoutes(logger *slog.Logger, console *admin.Console) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/admin/cache", adminCacheHandler(logger, console))
+	mux.HandleFunc("/admin/circuit", adminCircuitHandler(logger, console))
+	return mux
+}
+
 // registerSecuredRoutes wires the diagnostics endpoints (/metrics, /tools,
 // /status) and the MCP protocol handler onto one mux. The caller wraps this
 // mux with the security middleware so a single auth path guards all of them,
@@ -487,11 +689,35 @@ func toolsHandler(logger *slog.Logger, registry *tools.HandlerRegistry) http.Han
 			toolsByCountry[tool.Country] = append(toolsByCountry[tool.Country], toolInfo)
 		}
 
+		registeredResources := registry.RegisteredResources()
+		resources := make([]map[string]any, 0, len(registeredResources))
+		for _, res := range registeredResources {
+			resources = append(resources, map[string]any{
+				"uri":      res.URI,
+				"name":     res.Name,
+				"title":    res.Title,
+				"template": res.Template,
+				"country":  res.Country,
+			})
+		}
+
+		registeredPrompts := registry.RegisteredPrompts()
+		prompts := make([]map[string]any, 0, len(registeredPrompts))
+		for _, p := range registeredPrompts {
+			prompts = append(prompts, map[string]any{
+				"name":        p.Name,
+				"title":       p.Title,
+				"description": p.Description,
+			})
+		}
+
 		response := map[string]any{
 			"server":     ServerName,
 			"version":    ServerVersion,
 			"tool_count": len(registeredTools),
 			"countries":  toolsByCountry,
+			"resources":  resources,
+			"prompts":    prompts,
 		}
 
 		if err := json.NewEncoder(w).Encode(response); err != nil {
@@ -505,42 +731,16 @@ func statusHandler(logger *slog.Logger, clients *countryClients) http.HandlerFun
 		w.Header().Set("Content-Type", "application/json")
 		w.Header().Set("Cache-Control", "no-store")
 
-		noCBStats := clients.norway.CircuitBreakerStats()
-		noDedupStats := clients.norway.DedupStats()
-		dkCBStats := clients.denmark.CircuitBreakerStats()
-		dkDedupStats := clients.denmark.DedupStats()
-		fiCBStats := clients.finland.CircuitBreakerStats()
-
 		response := map[string]any{
 			"server":  ServerName,
 			"version": ServerVersion,
-			"norway": map[string]any{
-				"circuit_breaker": map[string]any{
-					"state":                noCBStats.State,
-					"consecutive_failures": noCBStats.ConsecutiveFails,
-					"last_failure":         noCBStats.LastFailure,
-				},
-				"dedup": map[string]any{
-					"inflight_requests": noDedupStats,
-				},
-			},
-			"denmark": map[string]any{
-				"circuit_breaker": map[string]any{
-					"state":                dkCBStats.State,
-					"consecutive_failures": dkCBStats.ConsecutiveFails,
-					"last_failure":         dkCBStats.LastFailure,
-				},
-				"dedup": map[string]any{
-					"inflight_requests": dkDedupStats,
-				},
-			},
-			"finland": map[string]any{
-				"circuit_breaker": map[string]any{
-					"state":                fiCBStats.State,
-					"consecutive_failures": fiCBStats.ConsecutiveFails,
-					"last_failure":         fiCBStats.LastFailure,
-				},
-			},
+			"norway":  registryStatus(clients.norway.Client),
+			"denmark": registryStatus(clients.denmark.Client),
+			"finland": registryStatus(clients.finland.Client),
+			"vies":    registryStatus(clients.vies.Client),
+		}
+		if clients.sweden != nil {
+			response["sweden"] = registryStatus(clients.sweden.Client)
 		}
 
 		if err := json.NewEncoder(w).Encode(response); err != nil {
@@ -549,9 +749,120 @@ func statusHandler(logger *slog.Logger, clients *countryClients) http.HandlerFun
 	}
 }
 
+// registryStatus renders a registry client's circuit breaker, in-flight
+// deduplicated requests and request budget for /status.
+func registryStatus(c *base.Client) map[string]any {
+	cbStats := c.CircuitBreakerStats()
+	return map[string]any{
+		"circuit_breaker": map[string]any{
+			"state":                cbStats.State,
+			"consecutive_failures": cbStats.ConsecutiveFails,
+			"last_failure":         cbStats.LastFailure,
+		},
+		"dedup": map[string]any{
+			"inflight_requests": c.DedupStats(),
+		},
+		"rate_limit": rateLimitStatus(c.RateLimitStats()),
+	}
+}
+
+// rateLimitStatus renders a registry client's request budget for /status:
+// its rate, the requests that waited for it and, with a daily quota, what
+// is left of it per host.
+func rateLimitStatus(stats infra.RateLimitStats) map[string]any {
+	status := map[string]any{
+		"rate_per_second": stats.Rate,
+		"burst":           stats.Burst,
+		"waits":           stats.Waits,
+	}
+	if stats.DailyQuota > 0 {
+		status["daily_quota"] = stats.DailyQuota
+		hosts := make([]map[string]any, 0, len(stats.Hosts))
+		for _, h := range stats.Hosts {
+			hosts = append(hosts, map[string]any{
+				"host":            h.Host,
+				"quota_used":      h.QuotaUsed,
+				"quota_remaining": h.QuotaRemaining,
+				"quota_reset_at":  h.QuotaResetAt,
+			})
+		}
+		status["hosts"] = hosts
+	}
+	return status
+}
+
+// adminCacheHandler serves /admin/cache: GET lists cache statistics, DELETE
+// or POST purges one registry's cache by identifier, prefix or all=true.
+func adminCacheHandler(logger *slog.Logger, console *admin.Console) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.Method {
+		case http.MethodGet:
+			result, err := console.CacheStatsMCP(r.Context(), admin.CacheStatsArgs{Registry: q.Get("registry")})
+			writeAdminResponse(w, logger, result, err)
+		case http.MethodDelete, http.MethodPost:
+			args := admin.PurgeCacheArgs{
+				Registry:   q.Get("registry"),
+				Identifier: q.Get("identifier"),
+				Prefix:     q.Get("prefix"),
+				All:        q.Get("all") == "true",
+			}
+			result, err := console.PurgeCacheMCP(r.Context(), args)
+			if err == nil {
+				logger.Info("Admin cache purge", "registry", result.Registry, "purged", result.Purged)
+			}
+			writeAdminResponse(w, logger, result, err)
+		default:
+			w.Header().Set("Allow", "GET, POST, DELETE")
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// adminCircuitHandler serves /admin/circuit: GET lists circuit breakers,
+// POST resets (action=reset) or forces open (action=open) one registry's.
+func adminCircuitHandler(logger *slog.Logger, console *admin.Console) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.Method {
+		case http.MethodGet:
+			result, err := console.CircuitStatusMCP(r.Context(), admin.CircuitStatusArgs{Registry: q.Get("registry")})
+			writeAdminResponse(w, logger, result, err)
+		case http.MethodPost:
+			result, err := console.SetCircuitMCP(r.Context(), admin.SetCircuitArgs{Registry: q.Get("registry"), Action: q.Get("action")})
+			if err == nil {
+				logger.Warn("Admin circuit change", "registry", result.Circuit.Registry, "state", result.Circuit.State, "forced", result.Circuit.Forced)
+			}
+			writeAdminResponse(w, logger, result, err)
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// writeAdminResponse writes the result of an admin operation as JSON, or its
+// error: 400 for invalid parameters, 500 otherwise.
+func writeAdminResponse(w http.ResponseWriter, logger *slog.Logger, result any, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err != nil {
+		status := http.StatusInternalServerError
+		if apierrors.IsValidation(err) {
+			status = http.StatusBadRequest
+		}
+		w.WriteHeader(status)
+		result = map[string]string{"error": err.Error()}
+	}
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		logger.Error("Failed to encode admin response", "error", err)
+	}
+}
+
 // serveHTTP starts the HTTP server and blocks until a shutdown signal or a
-// fatal server error, then performs a graceful shutdown.
-func serveHTTP(httpServer *http.Server, securedHandler *SecurityMiddleware, logger *slog.Logger) {
+// fatal server error, then performs a graceful shutdown. adminHandler may be
+// nil.
+func serveHTTP(httpServer *http.Server, securedHandler, adminHandler *SecurityMiddleware, logger *slog.Logger) {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
@@ -585,6 +896,9 @@ func serveHTTP(httpServer *http.Server, securedHandler *SecurityMiddleware, logg
 		securedHandler.rateLimiter.Close()
 		logger.Info("Rate limiter stopped")
 	}
+	if adminHandler != nil && adminHandler.rateLimiter != nil {
+		adminHandler.rateLimiter.Close()
+	}
 
 	logger.Info("Shutdown complete")
 }
@@ -597,12 +911,16 @@ func serveHTTP(httpServer *http.Server, securedHandler *SecurityMiddleware, logg
 // returned for every request, so there is no per-session state to lose. Session
 // IDs are ignored and DELETE returns 405, per the spec (SEP-2567).
 //
+// The -stateful flag trades that away for sessions: older clients subscribe
+// with resources/subscribe and receive notifications/resources/updated on the
+// session's GET stream, which a stateless server cannot hold open.
+//
 // Extracted from runHTTPServer so the protocol behavior is testable; that
 // function blocks on a listener and cannot be exercised from a test.
 func newMCPHandler(cfg httpServerConfig) http.Handler {
 	return mcp.NewStreamableHTTPHandler(func(_ *http.Request) *mcp.Server {
 		return cfg.server
-	}, &mcp.StreamableHTTPOptions{Stateless: true})
+	}, &mcp.StreamableHTTPOptions{Stateless: !cfg.flags.stateful})
 }
 
 func runHTTPServer(cfg httpServerConfig) {
@@ -621,7 +939,18 @@ func runHTTPServer(cfg httpServerConfig) {
 	securedMux := registerSecuredRoutes(cfg, mcpHandler)
 	securedHandler := NewSecurityMiddleware(securedMux, logger, securityConfig)
 
-	mux := registerHTTPRoutes(cfg, securedHandler)
+	// The admin endpoints exist only with an admin token, and check it in
+	// place of the MCP bearer token.
+	var adminHandler *SecurityMiddleware
+	var adminRoutes http.Handler
+	if cfg.adminToken != "" {
+		adminConfig := securityConfig
+		adminConfig.BearerToken = cfg.adminToken
+		adminHandler = NewSecurityMiddleware(registerAdminRoutes(logger, cfg.admin), logger, adminConfig)
+		adminRoutes = adminHandler
+	}
+
+	mux := registerHTTPRoutes(cfg, securedHandler, adminRoutes)
 
 	httpServer := &http.Server{
 		Addr:         addr,
@@ -644,6 +973,8 @@ func runHTTPServer(cfg httpServerConfig) {
 		"address", addr,
 		"auth_enabled", authToken != "",
 		"rate_limit", cfg.flags.rateLimit,
+		"stateful", cfg.flags.stateful,
+		"admin_endpoints", cfg.adminToken != "",
 	)
 
 	if authToken == "" {
@@ -653,5 +984,5 @@ func runHTTPServer(cfg httpServerConfig) {
 		logger.Warn("Server binding to external interface. Ensure you're behind HTTPS proxy in production.")
 	}
 
-	serveHTTP(httpServer, securedHandler, logger)
+	serveHTTP(httpServer, securedHandler, adminHandler, logger)
 }
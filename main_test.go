@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"log/slog"
@@ -11,7 +12,16 @@ import (
 	"testing"
 	"time"
 
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/admin"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/denmark"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/finland"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
 	"github.com/olgasafonova/nordic-registry-mcp-server/internal/norway"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/sweden"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/vies"
+	"github.com/olgasafonova/nordic-registry-mcp-server/tools"
 )
 
 func TestNewRateLimiter(t *testing.T) {
@@ -388,7 +398,7 @@ func TestRegisterHTTPRoutesSecuresDiagnostics(t *testing.T) {
 	securedHandler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		_, _ = w.Write([]byte(sentinel))
 	})
-	mux := registerHTTPRoutes(httpServerConfig{}, securedHandler)
+	mux := registerHTTPRoutes(httpServerConfig{}, securedHandler, nil)
 
 	for _, path := range []string{"/metrics", "/status", "/tools", "/"} {
 		req := httptest.NewRequest("GET", path, nil)
@@ -411,6 +421,55 @@ func TestRegisterHTTPRoutesSecuresDiagnostics(t *testing.T) {
 	}
 }
 
+// TestRegisterHTTPRoutesAdmin verifies that the admin endpoints take the
+// admin token and not the MCP bearer token, and act on the clients.
+func TestRegisterHTTPRoutesAdmin(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError}))
+	noClient := norway.NewClient(norway.WithLogger(logger))
+	defer noClient.Close()
+	noClient.Cache.Set("company:923609016", "cached", time.Minute)
+	console := admin.NewConsole(admin.Target{Registry: "norway", Cache: noClient.Cache, Breaker: noClient.CircuitBreaker, Invalidate: noClient.InvalidateCompany})
+
+	adminHandler := NewSecurityMiddleware(registerAdminRoutes(logger, console), logger, SecurityConfig{BearerToken: "admin-secret"})
+	securedHandler := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("SECURED"))
+	})
+	mux := registerHTTPRoutes(httpServerConfig{}, securedHandler, adminHandler)
+
+	do := func(method, target, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := do("GET", "/admin/cache", "mcp-token"); w.Code != http.StatusUnauthorized {
+		t.Errorf("GET /admin/cache with the MCP token: status = %d, want 401", w.Code)
+	}
+	if w := do("GET", "/admin/cache", "admin-secret"); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"entries":1`) {
+		t.Errorf("GET /admin/cache: status = %d, body = %s", w.Code, w.Body)
+	}
+	if w := do("DELETE", "/admin/cache?registry=norway&identifier=923609016", "admin-secret"); w.Code != http.StatusOK {
+		t.Errorf("DELETE /admin/cache: status = %d, body = %s", w.Code, w.Body)
+	}
+	if n := noClient.Cache.Size(); n != 0 {
+		t.Errorf("cache size after purge = %d, want 0", n)
+	}
+	if w := do("DELETE", "/admin/cache?registry=norway", "admin-secret"); w.Code != http.StatusBadRequest {
+		t.Errorf("DELETE /admin/cache without a selector: status = %d, want 400", w.Code)
+	}
+	if w := do("POST", "/admin/circuit?registry=norway&action=open", "admin-secret"); w.Code != http.StatusOK {
+		t.Errorf("POST /admin/circuit: status = %d, body = %s", w.Code, w.Body)
+	}
+	if noClient.CircuitBreaker.Allow() {
+		t.Error("circuit allows calls after being forced open")
+	}
+	if w := do("PUT", "/admin/circuit", "admin-secret"); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("PUT /admin/circuit: status = %d, want 405", w.Code)
+	}
+}
+
 // TestNewMCPHandler_ServesProtocol20260728 pins the reason newMCPHandler passes
 // Stateless: true. Without it the transport rejects every >= 2026-07-28 request
 // with HTTP 400 ("only supported on stateless HTTP servers"), which no build or
@@ -425,7 +484,7 @@ func TestNewMCPHandler_ServesProtocol20260728(t *testing.T) {
 	clients := &countryClients{norway: norway.NewClient(norway.WithLogger(logger))}
 	defer clients.norway.Close()
 
-	server, _ := buildServer(logger, clients)
+	server, _ := buildServer(logger, clients, tools.ToolFilter{}, nil, "")
 	handler := newMCPHandler(httpServerConfig{server: server, logger: logger})
 
 	t.Run("tools/list is answered at 2026-07-28", func(t *testing.T) {
@@ -478,7 +537,7 @@ func TestToolsListAdvertisesCacheTTL(t *testing.T) {
 	clients := &countryClients{norway: norway.NewClient(norway.WithLogger(logger))}
 	defer clients.norway.Close()
 
-	server, _ := buildServer(logger, clients)
+	server, _ := buildServer(logger, clients, tools.ToolFilter{}, nil, "")
 	handler := newMCPHandler(httpServerConfig{server: server, logger: logger})
 
 	body := `{"jsonrpc":"2.0","id":1,"method":"tools/list","params":{"_meta":{` +
@@ -549,7 +608,7 @@ func TestNewMCPHandler_ParamHeaderPassthrough(t *testing.T) {
 	clients := &countryClients{norway: norway.NewClient(norway.WithLogger(logger))}
 	defer clients.norway.Close()
 
-	server, _ := buildServer(logger, clients)
+	server, _ := buildServer(logger, clients, tools.ToolFilter{}, nil, "")
 	handler := newMCPHandler(httpServerConfig{server: server, logger: logger})
 
 	callCompany := func(t *testing.T, paramHeader string) *httptest.ResponseRecorder {
@@ -622,3 +681,256 @@ func TestNewMCPHandler_ParamHeaderPassthrough(t *testing.T) {
 		}
 	})
 }
+
+// TestNewMCPHandler_StatefulSubscriptions pins that -stateful keeps sessions
+// across requests, which resources/subscribe needs: the subscription is made
+// on one POST and the update arrives later on the session's GET stream.
+func TestNewMCPHandler_StatefulSubscriptions(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, &slog.HandlerOptions{Level: slog.LevelError}))
+	clients := &countryClients{norway: norway.NewClient(norway.WithLogger(logger))}
+	defer clients.norway.Close()
+
+	server, _ := buildServer(logger, clients, tools.ToolFilter{}, nil, "")
+	httpServer := httptest.NewServer(newMCPHandler(httpServerConfig{server: server, logger: logger, flags: cliFlags{stateful: true}}))
+	defer httpServer.Close()
+
+	updated := make(chan string, 1)
+	client := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "1"}, &mcp.ClientOptions{
+		ResourceUpdatedHandler: func(_ context.Context, req *mcp.ResourceUpdatedNotificationRequest) {
+			updated <- req.Params.URI
+		},
+	})
+	ctx := context.Background()
+	session, err := client.Connect(ctx, &mcp.StreamableClientTransport{Endpoint: httpServer.URL}, nil)
+	if err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	defer session.Close()
+
+	if caps := session.InitializeResult().Capabilities; caps.Resources == nil || !caps.Resources.Subscribe {
+		t.Fatal("server does not advertise resources.subscribe")
+	}
+	// The static NACE table needs no registry call to validate.
+	const uri = "nordic://reference/nace-rev2"
+	if err := session.Subscribe(ctx, &mcp.SubscribeParams{URI: uri}); err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	if err := server.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{URI: uri}); err != nil {
+		t.Fatalf("ResourceUpdated failed: %v", err)
+	}
+
+	select {
+	case got := <-updated:
+		if got != uri {
+			t.Errorf("update for %q, want %q", got, uri)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no notifications/resources/updated received")
+	}
+}
+
+func TestResolveToolFilter(t *testing.T) {
+	t.Setenv("TOOL_COUNTRIES", "denmark")
+	t.Setenv("TOOL_DENY", "norway_get_updates")
+
+	filter, err := resolveToolFilter(cliFlags{countries: "Norway, nordic", categories: "search,read"})
+	if err != nil {
+		t.Fatalf("resolveToolFilter: %v", err)
+	}
+	// The flag wins over TOOL_COUNTRIES; TOOL_DENY fills in the unset flag.
+	if strings.Join(filter.Countries, ",") != "norway,nordic" {
+		t.Errorf("Countries = %v, want [norway nordic]", filter.Countries)
+	}
+	if strings.Join(filter.Categories, ",") != "search,read" {
+		t.Errorf("Categories = %v, want [search read]", filter.Categories)
+	}
+	if strings.Join(filter.Deny, ",") != "norway_get_updates" {
+		t.Errorf("Deny = %v, want [norway_get_updates]", filter.Deny)
+	}
+
+	if _, err := resolveToolFilter(cliFlags{countries: "iceland"}); err == nil {
+		t.Error("resolveToolFilter accepted an unknown country")
+	}
+}
+
+func TestResolveCacheDir(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	t.Setenv("CACHE_DIR", "/var/cache/nordic")
+	if got := resolveCacheDir(logger, "/tmp/flag"); got != "/tmp/flag" {
+		t.Errorf("resolveCacheDir with flag = %q, want /tmp/flag", got)
+	}
+	if got := resolveCacheDir(logger, ""); got != "/var/cache/nordic" {
+		t.Errorf("resolveCacheDir from CACHE_DIR = %q, want /var/cache/nordic", got)
+	}
+	if got := resolveCacheDir(logger, "off"); got != "" {
+		t.Errorf("resolveCacheDir(off) = %q, want disabled", got)
+	}
+
+	t.Setenv("CACHE_DIR", "")
+	t.Setenv("XDG_CACHE_HOME", "/home/test/.cache")
+	if got := resolveCacheDir(logger, ""); !strings.HasSuffix(got, ServerName) {
+		t.Errorf("resolveCacheDir default = %q, want a %s directory", got, ServerName)
+	}
+}
+
+func TestResolveCachePolicy(t *testing.T) {
+	t.Setenv("CACHE_TTL", "vies/vat=1m")
+	policy, err := resolveCachePolicy("norway/search=30s")
+	if err != nil {
+		t.Fatalf("resolveCachePolicy: %v", err)
+	}
+	// The flag wins over CACHE_TTL.
+	if got := policy.TTL("norway", "search"); got != 30*time.Second {
+		t.Errorf("norway/search = %v, want 30s", got)
+	}
+	if got := policy.TTL("vies", "vat"); got != 15*time.Minute {
+		t.Errorf("vies/vat = %v, want the default 15m", got)
+	}
+
+	policy, err = resolveCachePolicy("")
+	if err != nil || policy.TTL("vies", "vat") != time.Minute {
+		t.Errorf("resolveCachePolicy from CACHE_TTL = %v, %v; want vies/vat 1m", policy, err)
+	}
+
+	if _, err := resolveCachePolicy("norway/everything=1m"); err == nil {
+		t.Error("resolveCachePolicy accepted an unknown operation")
+	}
+}
+
+func TestResolveRateLimitPolicy(t *testing.T) {
+	t.Setenv("REGISTRY_RATE_LIMIT", "vies/rate=1")
+	policy, err := resolveRateLimitPolicy("denmark/daily_quota=500")
+	if err != nil {
+		t.Fatalf("resolveRateLimitPolicy: %v", err)
+	}
+	// The flag wins over REGISTRY_RATE_LIMIT.
+	if got := policy.Config("denmark").DailyQuota; got != 500 {
+		t.Errorf("denmark daily quota = %d, want 500", got)
+	}
+	if got := policy.Config("vies").Rate; got != 2 {
+		t.Errorf("vies rate = %v, want the default 2", got)
+	}
+
+	policy, err = resolveRateLimitPolicy("")
+	if err != nil || policy.Config("vies").Rate != 1 {
+		t.Errorf("resolveRateLimitPolicy from REGISTRY_RATE_LIMIT = %v, %v; want vies rate 1", policy, err)
+	}
+
+	if _, err := resolveRateLimitPolicy("denmark/quota=1"); err == nil {
+		t.Error("resolveRateLimitPolicy accepted an unknown setting")
+	}
+}
+
+func TestResolveHedgedRegistries(t *testing.T) {
+	t.Setenv("HEDGE_REGISTRIES", "norway")
+	hedged, err := resolveHedgedRegistries("Finland, vies")
+	if err != nil {
+		t.Fatalf("resolveHedgedRegistries: %v", err)
+	}
+	// The flag wins over HEDGE_REGISTRIES.
+	if !hedged["finland"] || !hedged["vies"] || hedged["norway"] {
+		t.Errorf("hedged = %v, want finland and vies", hedged)
+	}
+	if cfg := (clientPolicies{hedged: hedged}).hedging("finland"); cfg == nil || cfg.Quantile != 0.95 {
+		t.Errorf("finland hedging = %+v, want the defaults", cfg)
+	}
+	if cfg := (clientPolicies{hedged: hedged}).hedging("denmark"); cfg != nil {
+		t.Errorf("denmark hedging = %+v, want none", cfg)
+	}
+
+	if hedged, err := resolveHedgedRegistries(""); err != nil || !hedged["norway"] {
+		t.Errorf("resolveHedgedRegistries from HEDGE_REGISTRIES = %v, %v; want norway", hedged, err)
+	}
+	if _, err := resolveHedgedRegistries("brreg"); err == nil {
+		t.Error("resolveHedgedRegistries accepted an unknown registry")
+	}
+}
+
+func TestStatusHandlerRateLimit(t *testing.T) {
+	se, err := sweden.NewClient(sweden.WithCredentials("test-id", "test-secret"))
+	if err != nil {
+		t.Fatalf("sweden.NewClient: %v", err)
+	}
+	quota, err := infra.ParseRateLimitPolicy("denmark/daily_quota=50")
+	if err != nil {
+		t.Fatalf("ParseRateLimitPolicy: %v", err)
+	}
+	clients := &countryClients{
+		norway:  norway.NewClient(),
+		denmark: denmark.NewClient(denmark.WithRateLimitPolicy(quota)),
+		finland: finland.NewClient(),
+		sweden:  se,
+		vies:    vies.NewClient(),
+	}
+	defer func() {
+		clients.norway.Close()
+		clients.denmark.Close()
+		clients.finland.Close()
+		clients.sweden.Close()
+		clients.vies.Close()
+	}()
+	_, _ = clients.denmark.RateLimiter.Wait(context.Background(), "cvrapi.dk")
+
+	rec := httptest.NewRecorder()
+	statusHandler(slog.New(slog.DiscardHandler), clients)(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+
+	var body struct {
+		Denmark struct {
+			RateLimit struct {
+				DailyQuota int `json:"daily_quota"`
+				Hosts      []struct {
+					Host           string `json:"host"`
+					QuotaRemaining int    `json:"quota_remaining"`
+				} `json:"hosts"`
+			} `json:"rate_limit"`
+		} `json:"denmark"`
+		Norway struct {
+			RateLimit map[string]any `json:"rate_limit"`
+		} `json:"norway"`
+		Sweden map[string]any `json:"sweden"`
+		VIES   map[string]any `json:"vies"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode /status: %v", err)
+	}
+	dk := body.Denmark.RateLimit
+	if dk.DailyQuota != 50 || len(dk.Hosts) != 1 || dk.Hosts[0].Host != "cvrapi.dk" || dk.Hosts[0].QuotaRemaining != 49 {
+		t.Errorf("denmark rate_limit = %+v, want 49 of 50 left on cvrapi.dk", dk)
+	}
+	if _, ok := body.Norway.RateLimit["daily_quota"]; ok || body.Norway.RateLimit["rate_per_second"] != 10.0 {
+		t.Errorf("norway rate_limit = %v, want a rate and no quota", body.Norway.RateLimit)
+	}
+	for _, key := range []string{"circuit_breaker", "dedup", "rate_limit"} {
+		if _, ok := body.Sweden[key]; !ok {
+			t.Errorf("sweden status = %v, want %s like the other registries", body.Sweden, key)
+		}
+		if _, ok := body.VIES[key]; !ok {
+			t.Errorf("vies status = %v, want %s like the other registries", body.VIES, key)
+		}
+	}
+}
+
+func TestResolveBreakerPolicy(t *testing.T) {
+	t.Setenv("CIRCUIT_BREAKER", "vies/reset=1m")
+	policy, err := resolveBreakerPolicy("finland/failure_rate=0.3")
+	if err != nil {
+		t.Fatalf("resolveBreakerPolicy: %v", err)
+	}
+	// The flag wins over CIRCUIT_BREAKER.
+	if got := policy.Config("finland").FailureRate; got != 0.3 {
+		t.Errorf("finland failure rate = %v, want 0.3", got)
+	}
+	if got := policy.Config("vies").ResetTimeout; got != 30*time.Second {
+		t.Errorf("vies reset = %v, want the default 30s", got)
+	}
+
+	policy, err = resolveBreakerPolicy("")
+	if err != nil || policy.Config("vies").ResetTimeout != time.Minute {
+		t.Errorf("resolveBreakerPolicy from CIRCUIT_BREAKER = %v, %v; want vies reset 1m", policy, err)
+	}
+
+	if _, err := resolveBreakerPolicy("norway/timeout=1m"); err == nil {
+		t.Error("resolveBreakerPolicy accepted an unknown setting")
+	}
+}
@@ -3,6 +3,9 @@
 package metrics
 
 import (
+	"sync"
+
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
@@ -92,6 +95,14 @@ var (
 		Help:      "Registry API retry count by country and action",
 	}, []string{"country", "action"})
 
+	// RegistryAPIHedges counts hedged registry API requests by which of the
+	// two answered first
+	RegistryAPIHedges = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "registry_api_hedged_requests_total",
+		Help:      "Registry API requests sent a second time after a slow first attempt, by country, action and winner",
+	}, []string{"country", "action", "winner"})
+
 	// RateLimitRejections counts requests rejected due to rate limiting
 	RateLimitRejections = promauto.NewCounter(prometheus.CounterOpts{
 		Namespace: Namespace,
@@ -99,11 +110,12 @@ var (
 		Help:      "Requests rejected due to rate limiting",
 	})
 
-	// RateLimitWaits counts requests that had to wait for rate limiter
+	// RateLimitWaits counts registry requests that had to wait for the
+	// upstream host's rate limiter
 	RateLimitWaits = promauto.NewCounter(prometheus.CounterOpts{
 		Namespace: Namespace,
 		Name:      "rate_limit_waits_total",
-		Help:      "Requests that waited for rate limiter semaphore",
+		Help:      "Registry requests that waited for the upstream rate limiter",
 	})
 
 	// AuthFailures counts authentication failures
@@ -210,3 +222,54 @@ func RecordCacheAccess(hit bool) {
 func SetCacheSize(size int64) {
 	CacheSize.Set(float64(size))
 }
+
+// negativeCacheCollector exports the negative-cache counters of the registry
+// clients' caches, read from the caches when metrics are scraped.
+type negativeCacheCollector struct {
+	mu      sync.Mutex
+	caches  map[string]*infra.Cache // registry -> cache
+	entries *prometheus.Desc
+	hits    *prometheus.Desc
+	stores  *prometheus.Desc
+}
+
+var negativeCaches = &negativeCacheCollector{
+	caches: make(map[string]*infra.Cache),
+	entries: prometheus.NewDesc(prometheus.BuildFQName(Namespace, "", "negative_cache_entries"),
+		"Current number of remembered not-found and validation outcomes", []string{"registry"}, nil),
+	hits: prometheus.NewDesc(prometheus.BuildFQName(Namespace, "", "negative_cache_hits_total"),
+		"Lookups answered by a remembered not-found or validation outcome", []string{"registry"}, nil),
+	stores: prometheus.NewDesc(prometheus.BuildFQName(Namespace, "", "negative_cache_stores_total"),
+		"Not-found and validation outcomes remembered", []string{"registry"}, nil),
+}
+
+func init() {
+	prometheus.MustRegister(negativeCaches)
+}
+
+// WatchNegativeCache exports the negative-cache counters of cache under the
+// registry label, replacing any cache watched under it before.
+func WatchNegativeCache(registry string, cache *infra.Cache) {
+	negativeCaches.mu.Lock()
+	defer negativeCaches.mu.Unlock()
+	negativeCaches.caches[registry] = cache
+}
+
+// Describe implements prometheus.Collector.
+func (c *negativeCacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.entries
+	ch <- c.hits
+	ch <- c.stores
+}
+
+// Collect implements prometheus.Collector.
+func (c *negativeCacheCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for registry, cache := range c.caches {
+		stats := cache.NegativeStats()
+		ch <- prometheus.MustNewConstMetric(c.entries, prometheus.GaugeValue, float64(stats.Entries), registry)
+		ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.Hits), registry)
+		ch <- prometheus.MustNewConstMetric(c.stores, prometheus.CounterValue, float64(stats.Stores), registry)
+	}
+}
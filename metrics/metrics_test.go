@@ -1,8 +1,12 @@
 package metrics
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
 	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
 )
@@ -184,6 +188,7 @@ func TestMetricsRegistered(t *testing.T) {
 		RegistryAPIRequestsTotal,
 		RegistryAPIErrors,
 		RegistryAPIRetries,
+		RegistryAPIHedges,
 		RateLimitRejections,
 		RateLimitWaits,
 		AuthFailures,
@@ -203,6 +208,48 @@ func TestMetricsRegistered(t *testing.T) {
 	}
 }
 
+func TestWatchNegativeCache(t *testing.T) {
+	cache := infra.NewCache(10)
+	defer cache.Close()
+	WatchNegativeCache("test-registry", cache)
+
+	notFound := func(context.Context) (*struct{}, error) {
+		return nil, apierrors.NewNotFoundError("norway", "1")
+	}
+	for range 2 {
+		_, _ = infra.Fetch(context.Background(), cache, "company:1", time.Minute, notFound)
+	}
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatalf("Gather: %v", err)
+	}
+	want := map[string]float64{
+		"nordic_registry_mcp_negative_cache_entries":      1,
+		"nordic_registry_mcp_negative_cache_hits_total":   1,
+		"nordic_registry_mcp_negative_cache_stores_total": 1,
+	}
+	for _, family := range families {
+		wantValue, ok := want[family.GetName()]
+		if !ok {
+			continue
+		}
+		for _, m := range family.GetMetric() {
+			if m.GetLabel()[0].GetValue() != "test-registry" {
+				continue
+			}
+			got := m.GetGauge().GetValue() + m.GetCounter().GetValue()
+			if got != wantValue {
+				t.Errorf("%s = %v, want %v", family.GetName(), got, wantValue)
+			}
+			delete(want, family.GetName())
+		}
+	}
+	for name := range want {
+		t.Errorf("%s not exported", name)
+	}
+}
+
 func TestNamespace(t *testing.T) {
 	if Namespace != "nordic_registry_mcp" {
 		t.Errorf("expected namespace 'nordic_registry_mcp', got '%s'", Namespace)
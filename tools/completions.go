@@ -0,0 +1,245 @@
+package tools
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/legalform"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/norway"
+)
+
+const (
+	// maxCompletionValues is the most values one completion/complete result
+	// may carry
+	maxCompletionValues = 100
+
+	// companyCompletionSize is how many brreg search hits a company-name
+	// completion asks for
+	companyCompletionSize = 20
+)
+
+// completeFunc returns the completions of a partially typed argument value.
+type completeFunc func(ctx context.Context, value string) ([]string, error)
+
+// argumentCompletions maps a completion reference and argument name to the
+// completer method. References are prompt names and resource template URIs,
+// as MCP defines them, and also tool names: completion/complete has no tool
+// reference type, so a client completing tool arguments sends ref/prompt
+// with the tool name.
+var argumentCompletions = map[string]map[string]string{
+	"norway_search_companies": {
+		"query":        "NorwayCompanyName",
+		"municipality": "NorwayMunicipality",
+		"org_form":     "NorwayOrgForm",
+	},
+	"norway_search_subunits":      {"municipality": "NorwayMunicipality"},
+	"norway_get_company":          {"org_number": "NorwayOrgNumber"},
+	"norway_get_roles":            {"org_number": "NorwayOrgNumber"},
+	"norway_get_signature_rights": {"org_number": "NorwayOrgNumber"},
+	"norway_get_subunits":         {"parent_org_number": "NorwayOrgNumber"},
+	"finland_search_companies":    {"company_form": "FinlandCompanyForm"},
+	"nordic_list_legal_forms": {
+		"country":  "Country",
+		"category": "LegalFormCategory",
+	},
+
+	"vendor_verification":   {"country": "Country"},
+	"board_check":           {"org_number": "NorwayOrgNumber"},
+	"signing_authority":     {"org_number": "NorwayOrgNumber"},
+	"cross_border_presence": {"countries": "CountryList"},
+
+	"nordic://no/company/{org_number}":       {"org_number": "NorwayOrgNumber"},
+	"nordic://no/company/{org_number}/roles": {"org_number": "NorwayOrgNumber"},
+}
+
+// initCompleters builds the completer map.
+func (h *HandlerRegistry) initCompleters() {
+	h.completers = map[string]completeFunc{
+		"NorwayCompanyName":  h.completeNorwayCompanyName,
+		"NorwayOrgNumber":    h.completeNorwayOrgNumber,
+		"NorwayMunicipality": h.completeNorwayMunicipality,
+		"NorwayOrgForm":      h.completeNorwayOrgForm,
+		"FinlandCompanyForm": completeFinlandCompanyForm,
+		"Country":            completeCountry,
+		"CountryList":        completeCountryList,
+		"LegalFormCategory":  completeLegalFormCategory,
+	}
+}
+
+// CompleteArgument is the completion/complete handler. Arguments without a
+// completer, and lookups that fail, complete to nothing rather than an
+// error, since clients ask on every keystroke.
+func (h *HandlerRegistry) CompleteArgument(ctx context.Context, req *mcp.CompleteRequest) (res *mcp.CompleteResult, err error) {
+	defer h.recoverPanic("completion/complete", &err)
+
+	ref := req.Params.Ref.Name
+	if req.Params.Ref.Type == "ref/resource" {
+		ref = req.Params.Ref.URI
+	}
+	arg := req.Params.Argument
+
+	res = &mcp.CompleteResult{Completion: mcp.CompletionResultDetails{Values: []string{}}}
+	method, ok := argumentCompletions[ref][arg.Name]
+	if !ok {
+		return res, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, ToolTimeout)
+	defer cancel()
+	values, err := h.completers[method](ctx, arg.Value)
+	if err != nil {
+		h.logger.Warn("Completion failed", "ref", ref, "argument", arg.Name, "error", err)
+		return res, nil
+	}
+
+	res.Completion.Total = len(values)
+	if len(values) > maxCompletionValues {
+		values = values[:maxCompletionValues]
+		res.Completion.HasMore = true
+	}
+	if len(values) > 0 {
+		res.Completion.Values = values
+	}
+	return res, nil
+}
+
+// hasFoldPrefix reports whether s starts with prefix, ignoring case.
+func hasFoldPrefix(s, prefix string) bool {
+	return strings.HasPrefix(strings.ToLower(s), strings.ToLower(prefix))
+}
+
+// completeNorwayMunicipality completes a municipality code from a number or
+// name prefix, so "osl" and "03" both give 0301.
+func (h *HandlerRegistry) completeNorwayMunicipality(ctx context.Context, value string) ([]string, error) {
+	resp, err := h.norwayClient.GetMunicipalities(ctx)
+	if err != nil {
+		return nil, err
+	}
+	value = strings.TrimSpace(value)
+	var values []string
+	for _, m := range resp.Embedded.Municipalities {
+		if strings.HasPrefix(m.Number, value) || hasFoldPrefix(m.Name, value) {
+			values = append(values, m.Number)
+		}
+	}
+	slices.Sort(values)
+	return values, nil
+}
+
+// completeNorwayOrgForm completes an organization form code from a code or
+// description prefix.
+func (h *HandlerRegistry) completeNorwayOrgForm(ctx context.Context, value string) ([]string, error) {
+	resp, err := h.norwayClient.GetOrgForms(ctx)
+	if err != nil {
+		return nil, err
+	}
+	value = strings.TrimSpace(value)
+	var values []string
+	for _, f := range resp.Embedded.OrgForms {
+		if hasFoldPrefix(f.Code, value) || hasFoldPrefix(f.Description, value) {
+			values = append(values, f.Code)
+		}
+	}
+	return values, nil
+}
+
+// searchNorwayCompanies runs the brreg name search behind the company
+// completions. Names shorter than the search minimum complete to nothing.
+func (h *HandlerRegistry) searchNorwayCompanies(ctx context.Context, name string) ([]norway.Company, error) {
+	name = strings.TrimSpace(name)
+	if norway.ValidateSearchQuery(name) != nil {
+		return nil, nil
+	}
+	resp, err := h.norwayClient.SearchCompanies(ctx, name, &norway.SearchOptions{Size: companyCompletionSize})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Embedded.Companies, nil
+}
+
+// completeNorwayCompanyName completes a company name.
+func (h *HandlerRegistry) completeNorwayCompanyName(ctx context.Context, value string) ([]string, error) {
+	companies, err := h.searchNorwayCompanies(ctx, value)
+	if err != nil {
+		return nil, err
+	}
+	var values []string
+	for _, c := range companies {
+		if !slices.Contains(values, c.Name) {
+			values = append(values, c.Name)
+		}
+	}
+	return values, nil
+}
+
+// completeNorwayOrgNumber completes an organization number from the company
+// name typed in its place. A value that is already numeric is left alone.
+func (h *HandlerRegistry) completeNorwayOrgNumber(ctx context.Context, value string) ([]string, error) {
+	if strings.Trim(value, "0123456789 -") == "" {
+		return nil, nil
+	}
+	companies, err := h.searchNorwayCompanies(ctx, value)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, 0, len(companies))
+	for _, c := range companies {
+		values = append(values, c.OrganizationNumber)
+	}
+	return values, nil
+}
+
+// completeFinlandCompanyForm completes a PRH company form code from the
+// legal-form table, by code or by Finnish or English name.
+func completeFinlandCompanyForm(_ context.Context, value string) ([]string, error) {
+	value = strings.TrimSpace(value)
+	var values []string
+	for _, f := range legalform.List("finland", "") {
+		if hasFoldPrefix(f.Code, value) || hasFoldPrefix(f.Name, value) || hasFoldPrefix(f.NameEN, value) {
+			values = append(values, f.Code)
+		}
+	}
+	return values, nil
+}
+
+// completeCountry completes a country name.
+func completeCountry(_ context.Context, value string) ([]string, error) {
+	var values []string
+	for _, c := range legalform.Countries {
+		if hasFoldPrefix(c, strings.TrimSpace(value)) {
+			values = append(values, c)
+		}
+	}
+	return values, nil
+}
+
+// completeCountryList completes the last entry of a comma-separated country
+// list, keeping the entries before it.
+func completeCountryList(ctx context.Context, value string) ([]string, error) {
+	head, last := "", value
+	if i := strings.LastIndex(value, ","); i >= 0 {
+		head, last = value[:i+1]+" ", value[i+1:]
+	}
+	countries, _ := completeCountry(ctx, last)
+	values := make([]string, 0, len(countries))
+	for _, c := range countries {
+		if !strings.Contains(head, c) {
+			values = append(values, head+c)
+		}
+	}
+	return values, nil
+}
+
+// completeLegalFormCategory completes a legal-form category.
+func completeLegalFormCategory(_ context.Context, value string) ([]string, error) {
+	var values []string
+	for _, c := range legalform.Categories() {
+		if hasFoldPrefix(c, strings.TrimSpace(value)) {
+			values = append(values, c)
+		}
+	}
+	return values, nil
+}
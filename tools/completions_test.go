@@ -0,0 +1,181 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/denmark"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/finland"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/norway"
+)
+
+// createMockCompletionServer serves the brreg reference lists and a name
+// search that matches on prefix.
+func createMockCompletionServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	companies := []map[string]any{
+		{"organisasjonsnummer": "923609016", "navn": "EQUINOR ASA"},
+		{"organisasjonsnummer": "990888213", "navn": "EQUINOR ENERGY AS"},
+		{"organisasjonsnummer": "976820479", "navn": "TELENOR ASA"},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/kommuner":
+			_ = json.NewEncoder(w).Encode(map[string]any{"_embedded": map[string]any{"kommuner": []map[string]string{
+				{"nummer": "4601", "navn": "BERGEN"},
+				{"nummer": "0301", "navn": "OSLO"},
+				{"nummer": "3103", "navn": "MOSS"},
+			}}})
+		case "/organisasjonsformer":
+			_ = json.NewEncoder(w).Encode(map[string]any{"_embedded": map[string]any{"organisasjonsformer": []map[string]string{
+				{"kode": "AS", "beskrivelse": "Aksjeselskap"},
+				{"kode": "ASA", "beskrivelse": "Allmennaksjeselskap"},
+				{"kode": "ENK", "beskrivelse": "Enkeltpersonforetak"},
+			}}})
+		case "/enheter":
+			query := strings.ToUpper(r.URL.Query().Get("navn"))
+			var hits []map[string]any
+			for _, c := range companies {
+				if strings.HasPrefix(c["navn"].(string), query) {
+					hits = append(hits, c)
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"_embedded": map[string]any{"enheter": hits}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newCompletionTestRegistry(t *testing.T) *HandlerRegistry {
+	t.Helper()
+	mockServer := createMockCompletionServer(t)
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	noClient := norway.NewClient(norway.WithLogger(logger), norway.WithBaseURL(mockServer.URL))
+	t.Cleanup(noClient.Close)
+	dkClient := denmark.NewClient(denmark.WithLogger(logger))
+	t.Cleanup(dkClient.Close)
+	fiClient := finland.NewClient(finland.WithLogger(logger))
+	t.Cleanup(fiClient.Close)
+	return NewHandlerRegistry(HandlerRegistryConfig{NorwayClient: noClient, DenmarkClient: dkClient, FinlandClient: fiClient, Logger: logger})
+}
+
+func TestArgumentCompletions(t *testing.T) {
+	registry := newCompletionTestRegistry(t)
+	templates := make(map[string]bool)
+	for _, spec := range AllResources {
+		templates[spec.URI] = spec.Template
+	}
+	prompts := make(map[string]bool)
+	for _, spec := range AllPrompts {
+		prompts[spec.Name] = true
+	}
+	tools := make(map[string]bool)
+	for _, spec := range AllTools {
+		tools[spec.Name] = true
+	}
+
+	for ref, args := range argumentCompletions {
+		if !tools[ref] && !prompts[ref] && !templates[ref] {
+			t.Errorf("completion reference %q is not a tool, prompt or resource template", ref)
+		}
+		for arg, method := range args {
+			if _, ok := registry.completers[method]; !ok {
+				t.Errorf("%s.%s: no completer %q", ref, arg, method)
+			}
+		}
+	}
+}
+
+func TestCompleteArgument(t *testing.T) {
+	registry := newCompletionTestRegistry(t)
+	tests := []struct {
+		name     string
+		ref      mcp.CompleteReference
+		argument string
+		value    string
+		want     []string
+	}{
+		{"municipality by name", mcp.CompleteReference{Type: "ref/prompt", Name: "norway_search_companies"}, "municipality", "osl", []string{"0301"}},
+		{"municipality by number", mcp.CompleteReference{Type: "ref/prompt", Name: "norway_search_companies"}, "municipality", "46", []string{"4601"}},
+		{"municipality all, sorted", mcp.CompleteReference{Type: "ref/prompt", Name: "norway_search_subunits"}, "municipality", "", []string{"0301", "3103", "4601"}},
+		{"org form by code", mcp.CompleteReference{Type: "ref/prompt", Name: "norway_search_companies"}, "org_form", "as", []string{"AS", "ASA"}},
+		{"org form by description", mcp.CompleteReference{Type: "ref/prompt", Name: "norway_search_companies"}, "org_form", "enkelt", []string{"ENK"}},
+		{"company name", mcp.CompleteReference{Type: "ref/prompt", Name: "norway_search_companies"}, "query", "equi", []string{"EQUINOR ASA", "EQUINOR ENERGY AS"}},
+		{"org number from name", mcp.CompleteReference{Type: "ref/prompt", Name: "board_check"}, "org_number", "telenor", []string{"976820479"}},
+		{"org number in resource template", mcp.CompleteReference{Type: "ref/resource", URI: "nordic://no/company/{org_number}"}, "org_number", "equinor", []string{"923609016", "990888213"}},
+		{"numeric org number left alone", mcp.CompleteReference{Type: "ref/prompt", Name: "norway_get_company"}, "org_number", "9236", nil},
+		{"name too short to search", mcp.CompleteReference{Type: "ref/prompt", Name: "norway_search_companies"}, "query", "e", nil},
+		{"finnish company form", mcp.CompleteReference{Type: "ref/prompt", Name: "finland_search_companies"}, "company_form", "oy", []string{"OY", "OYJ"}},
+		{"finnish company form by name", mcp.CompleteReference{Type: "ref/prompt", Name: "finland_search_companies"}, "company_form", "Osuus", []string{"OSK"}},
+		{"country", mcp.CompleteReference{Type: "ref/prompt", Name: "vendor_verification"}, "country", "d", []string{"denmark"}},
+		{"country list", mcp.CompleteReference{Type: "ref/prompt", Name: "cross_border_presence"}, "countries", "norway,", []string{"norway, denmark", "norway, finland", "norway, sweden"}},
+		{"legal form category", mcp.CompleteReference{Type: "ref/prompt", Name: "nordic_list_legal_forms"}, "category", "p", []string{"partnership", "public_limited"}},
+		{"argument without completer", mcp.CompleteReference{Type: "ref/prompt", Name: "norway_search_companies"}, "size", "1", nil},
+		{"unknown reference", mcp.CompleteReference{Type: "ref/prompt", Name: "no_such_prompt"}, "country", "n", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := registry.CompleteArgument(context.Background(), &mcp.CompleteRequest{Params: &mcp.CompleteParams{
+				Ref:      &tt.ref,
+				Argument: mcp.CompleteParamsArgument{Name: tt.argument, Value: tt.value},
+			}})
+			if err != nil {
+				t.Fatalf("CompleteArgument failed: %v", err)
+			}
+			if res.Completion.Values == nil {
+				t.Fatal("values must be an empty list, not null")
+			}
+			if !slices.Equal(res.Completion.Values, tt.want) {
+				t.Errorf("values = %v, want %v", res.Completion.Values, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompleteArgument_Session(t *testing.T) {
+	registry := newCompletionTestRegistry(t)
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "1.0.0"}, &mcp.ServerOptions{
+		CompletionHandler: registry.CompleteArgument,
+	})
+	registry.RegisterAll(server)
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(context.Background(), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect server: %v", err)
+	}
+	defer serverSession.Close()
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect client: %v", err)
+	}
+	defer session.Close()
+
+	if session.InitializeResult().Capabilities.Completions == nil {
+		t.Error("server does not advertise completions")
+	}
+	res, err := session.Complete(context.Background(), &mcp.CompleteParams{
+		Ref:      &mcp.CompleteReference{Type: "ref/prompt", Name: "signing_authority"},
+		Argument: mcp.CompleteParamsArgument{Name: "org_number", Value: "Equinor"},
+	})
+	if err != nil {
+		t.Fatalf("Complete failed: %v", err)
+	}
+	if res.Completion.Total != 2 || res.Completion.Values[0] != "923609016" {
+		t.Errorf("unexpected completion %+v", res.Completion)
+	}
+}
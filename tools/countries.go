@@ -0,0 +1,104 @@
+package tools
+
+// CountrySpec describes a ToolSpec.Country for the generated documentation:
+// the server instructions and docs/API.md list tools country by country, in
+// AllCountries order. Like a tool, a country can have notes/<Country>.md for
+// its section in docs/API.md.
+type CountrySpec struct {
+	// Country is the ToolSpec.Country value (e.g., "norway")
+	Country string
+
+	// Name is the display name (e.g., "Norway")
+	Name string
+
+	// Registry is the registry behind the tools (e.g., "Brønnøysundregistrene").
+	// Empty for the cross-registry tools, which are not a registry of their own.
+	Registry string
+
+	// Source is the API host (e.g., "data.brreg.no")
+	Source string
+
+	// Summary describes the registry in the server instructions'
+	// "Available Countries" list
+	Summary string
+
+	// Lookups heads the country's tools in the server instructions
+	Lookups string
+
+	// Identifier describes the country's company identifier, or nil
+	Identifier *IdentifierFormat
+}
+
+// IdentifierFormat describes a company identifier and the spellings the
+// tools accept for it.
+type IdentifierFormat struct {
+	Name     string   // e.g., "Norwegian Organization Numbers"
+	Format   string   // What the identifier looks like and what is normalized away
+	Examples []string // Accepted spellings of one identifier
+}
+
+// AllCountries lists the countries of AllTools in documentation order.
+var AllCountries = []CountrySpec{
+	{
+		Country:  "norway",
+		Name:     "Norway",
+		Registry: "Brønnøysundregistrene",
+		Source:   "data.brreg.no",
+		Summary:  "Norwegian business registry",
+		Lookups:  "Norwegian Company Lookups",
+		Identifier: &IdentifierFormat{
+			Name:     "Norwegian Organization Numbers",
+			Format:   "Norwegian org numbers are 9 digits. Spaces and dashes are automatically removed.",
+			Examples: []string{"923609016", "923 609 016", "923-609-016"},
+		},
+	},
+	{
+		Country:  "denmark",
+		Name:     "Denmark",
+		Registry: "CVR",
+		Source:   "cvrapi.dk",
+		Summary:  "Danish business registry",
+		Lookups:  "Danish Company Lookups",
+		Identifier: &IdentifierFormat{
+			Name:     "Danish CVR Numbers",
+			Format:   `Danish CVR numbers are 8 digits. Spaces, dashes, and "DK" prefix are automatically removed.`,
+			Examples: []string{"10150817", "DK-10150817", "DK10150817"},
+		},
+	},
+	{
+		Country:  "finland",
+		Name:     "Finland",
+		Registry: "PRH",
+		Source:   "avoindata.prh.fi",
+		Summary:  "Finnish business registry",
+		Lookups:  "Finnish Company Lookups",
+		Identifier: &IdentifierFormat{
+			Name:     "Finnish Business IDs (Y-tunnus)",
+			Format:   "Finnish business IDs are 7 digits + hyphen + check digit (e.g., 0112038-9). The FI prefix is automatically removed.",
+			Examples: []string{"0112038-9", "FI0112038-9"},
+		},
+	},
+	{
+		Country:  "sweden",
+		Name:     "Sweden",
+		Registry: "Bolagsverket",
+		Source:   "api.bolagsverket.se",
+		Summary:  "Swedish business registry (requires OAuth2 credentials)",
+		Lookups:  "Swedish Company Lookups",
+		Identifier: &IdentifierFormat{
+			Name:     "Swedish Organization Numbers",
+			Format:   "Swedish org numbers are 10 digits; sole traders are identified by their 12-digit personal number. Spaces and dashes are automatically removed.",
+			Examples: []string{"5560125790", "556012-5790", "5560-1257-90"},
+		},
+	},
+	{
+		Country: "nordic",
+		Name:    "Cross-registry",
+		Lookups: "Cross-Registry Tools",
+	},
+	{
+		Country: "admin",
+		Name:    "Administration",
+		Lookups: "Cache and Circuit Breaker Administration",
+	},
+}
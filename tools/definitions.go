@@ -1,7 +1,11 @@
 package tools
 
+import "time"
+
 // AllTools contains tool specifications for the Nordic Registry MCP server.
-// Descriptions are concise for token efficiency. See docs/API.md for full documentation.
+// Descriptions are concise for token efficiency. Task, Examples, Hint and
+// Caveats feed the server instructions and the tool reference in docs/API.md
+// (see docs.go).
 var AllTools = []ToolSpec{
 	// ==========================================================================
 	// NORWAY - Brønnøysundregistrene (data.brreg.no)
@@ -13,6 +17,8 @@ var AllTools = []ToolSpec{
 		Category:    "search",
 		Country:     "norway",
 		Description: `Search Norwegian companies by name. USE WHEN: "find company named X", "search for companies in Oslo". Partial matches and case-insensitive. Returns a paginated list of matching companies with org number, name, status, and org form. If you have a 9-digit org number, use norway_get_company instead. Filters: org_form (AS/ENK/NUF), municipality (4-digit code, use norway_list_municipalities to look up codes), registered_in_vat, bankrupt, registered_in_voluntary.`,
+		Task:        "Search for companies by name",
+		Examples:    []string{"Find Norwegian companies named Equinor", "Search for AS companies in Oslo", "Find bankrupt companies named Restaurant", "Find voluntary organizations named Røde Kors"},
 		ReadOnly:    true,
 		OpenWorld:   true,
 	},
@@ -25,6 +31,8 @@ var AllTools = []ToolSpec{
 		Country:      "norway",
 		HeaderParams: map[string]string{"org_number": "Org-Number"},
 		Description:  `Get company details by 9-digit org number. USE WHEN: you have an org number and need company details. Spaces and dashes in org number are auto-stripped (e.g., "923 609 016" → "923609016"). Returns compact summary by default; set full=true for complete data including all addresses, industry codes, and capital info. FAILS WHEN: org number is not exactly 9 digits after stripping, or company not found.`,
+		Task:         "Get company details by org number",
+		Examples:     []string{"Get details for company 923609016", "Look up company 914778271"},
 		ReadOnly:     true,
 		OpenWorld:    true,
 	},
@@ -35,6 +43,8 @@ var AllTools = []ToolSpec{
 		Category:    "roles",
 		Country:     "norway",
 		Description: `Get board members, CEO, auditors, and other roles for a Norwegian company. USE WHEN: "who is on the board?", "list directors", "find CEO". Returns person names, birth dates, role types, and resignation status. For signature authority only, use norway_get_signature_rights.`,
+		Task:        "Get board members and roles",
+		Examples:    []string{"Who is on the board of 923609016?", "Find the CEO of Equinor", "List all directors for org 914778271"},
 		ReadOnly:    true,
 		OpenWorld:   true,
 	},
@@ -45,6 +55,8 @@ var AllTools = []ToolSpec{
 		Category:    "roles",
 		Country:     "norway",
 		Description: `Get who can sign for a company (signaturrett) and prokura holders. USE WHEN: "who can sign?", "signature rights", "prokura". Returns authorized signatories with signing rules (alone or jointly). For full board/role list, use norway_get_roles instead.`,
+		Task:        "Find out who can sign for a company",
+		Examples:    []string{"Who can sign for company 923609016?", "Get signature rights for Equinor", "Who has prokura for 914778271?"},
 		ReadOnly:    true,
 		OpenWorld:   true,
 	},
@@ -55,8 +67,12 @@ var AllTools = []ToolSpec{
 		Category:    "batch",
 		Country:     "norway",
 		Description: `Look up multiple companies at once (max 2000 org numbers). USE WHEN: you have a list of org numbers to validate or look up. More efficient than individual lookups. Returns company summaries and list of not_found entries. FAILS WHEN: any org number is not 9 digits (invalid entries are skipped, not failed).`,
+		Task:        "Look up a list of Norwegian companies",
+		Examples:    []string{"Look up these companies: 923609016, 914778271", "Validate these org numbers from my spreadsheet"},
+		Hint:        "max 2000 per call",
 		ReadOnly:    true,
 		OpenWorld:   true,
+		Timeout:     time.Minute,
 	},
 	{
 		Name:        "norway_get_subunits",
@@ -65,6 +81,8 @@ var AllTools = []ToolSpec{
 		Category:    "subunits",
 		Country:     "norway",
 		Description: `List all branches of a parent company by its org number. USE WHEN: "what branches does X have?", "list sub-units". For one specific branch by its own org number, use norway_get_subunit. To search branches by name, use norway_search_subunits.`,
+		Task:        "Get branch offices",
+		Examples:    []string{"What branches does company 923609016 have?", "List sub-units for Equinor"},
 		ReadOnly:    true,
 		OpenWorld:   true,
 	},
@@ -75,6 +93,8 @@ var AllTools = []ToolSpec{
 		Category:    "subunits",
 		Country:     "norway",
 		Description: `Get details for a specific sub-unit (branch office) by its org number. USE WHEN: you have a branch org number and want its details. Returns name, address, parent org number, and status. For listing all branches of a parent, use norway_get_subunits.`,
+		Task:        "Get a specific sub-unit",
+		Examples:    []string{"Get details for sub-unit 912345678"},
 		ReadOnly:    true,
 		OpenWorld:   true,
 	},
@@ -85,6 +105,8 @@ var AllTools = []ToolSpec{
 		Category:    "updates",
 		Country:     "norway",
 		Description: `Monitor main company registry changes since a timestamp. USE WHEN: "what companies changed recently?", "registry updates". Timestamp format: ISO 8601 datetime with timezone, e.g., "2024-01-08T00:00:00Z". Not cached. Returns list of org numbers with update timestamps. FAILS WHEN: timestamp is missing or malformed. For branch office changes only, use norway_get_subunit_updates.`,
+		Task:        "Monitor registry changes",
+		Examples:    []string{"What companies changed since yesterday?", "Get recent registry updates"},
 		ReadOnly:    true,
 		OpenWorld:   true,
 	},
@@ -95,6 +117,8 @@ var AllTools = []ToolSpec{
 		Category:    "search",
 		Country:     "norway",
 		Description: `Search Norwegian branch offices by name. USE WHEN: "find branches named X" and you don't have the parent org number. Partial matches and case-insensitive. Filter by municipality (4-digit code, use norway_list_municipalities to look up codes). For listing all branches of a known parent, use norway_get_subunits.`,
+		Task:        "Search for branch offices by name",
+		Examples:    []string{"Search for branch offices named Equinor", "Find sub-units named Coop in Oslo"},
 		ReadOnly:    true,
 		OpenWorld:   true,
 	},
@@ -105,6 +129,8 @@ var AllTools = []ToolSpec{
 		Category:    "reference",
 		Country:     "norway",
 		Description: `Get Norwegian municipality codes for filtering searches. Returns code-name pairs (e.g., 0301 = Oslo). Cached 24h. Use before norway_search_companies if you need a municipality code for filtering.`,
+		Task:        "Look up municipality codes",
+		Examples:    []string{"What is Oslo's municipality code?", "List all Norwegian municipalities"},
 		ReadOnly:    true,
 		OpenWorld:   true,
 	},
@@ -115,6 +141,8 @@ var AllTools = []ToolSpec{
 		Category:    "reference",
 		Country:     "norway",
 		Description: `Get organization form codes with descriptions (AS=limited, ENK=sole prop, NUF=foreign branch, etc.). Returns code-description pairs. Cached 24h. Use before norway_search_companies if you need an org_form code for filtering.`,
+		Task:        "Look up Norwegian organization form codes",
+		Examples:    []string{"What does ENK mean?", "List organization form codes"},
 		ReadOnly:    true,
 		OpenWorld:   true,
 	},
@@ -125,6 +153,8 @@ var AllTools = []ToolSpec{
 		Category:    "updates",
 		Country:     "norway",
 		Description: `Get sub-unit (branch) registry changes since a timestamp. USE WHEN: "what branches changed recently?", monitoring branch office updates. Timestamp format: ISO 8601 datetime with timezone, e.g., "2024-01-08T00:00:00Z". Returns list of changed branch org numbers with update timestamps. Not cached. FAILS WHEN: timestamp is missing or malformed. For main company updates, use norway_get_updates.`,
+		Task:        "Monitor branch office changes",
+		Examples:    []string{"What branch offices changed recently?"},
 		ReadOnly:    true,
 		OpenWorld:   true,
 	},
@@ -138,9 +168,26 @@ var AllTools = []ToolSpec{
 		Title:       "Search Danish Companies",
 		Category:    "search",
 		Country:     "denmark",
-		Description: `Search Danish companies by name. USE WHEN: "find Danish company X" and you don't have a CVR number. Partial matches and case-insensitive. WARNING: Returns only ONE result (CVR API limitation). Large companies often have multiple legal entities. Try variations: "[Company] Denmark", "[Company] A/S", "[Company] DK", "[Company] Holding", or pre-merger names. Returns company name, CVR number, address, status, and employee count. If you have an 8-digit CVR number, use denmark_get_company instead.`,
-		ReadOnly:    true,
-		OpenWorld:   true,
+		Description: `Search Danish companies by name. USE WHEN: "find Danish company X" and you don't have a CVR number. Partial matches and case-insensitive. WARNING: Returns only ONE result (CVR API limitation). Large companies often have multiple legal entities. Try variations: "[Company] Denmark", "[Company] A/S", "[Company] DK", "[Company] Holding", or pre-merger names. Returns company name, CVR number, address, status, and employee count. If you have an 8-digit CVR number, use denmark_get_company instead. If the client supports elicitation, the user confirms a match whose name differs from the query.`,
+		Task:        "Search for Danish companies by name",
+		Examples:    []string{"Find Danish company Novo Nordisk", "Search for Carlsberg in Denmark"},
+		Caveats: []Caveat{
+			{Title: "Danish Search Returns Only ONE Result", Text: `The CVR API returns only one company per search. Large companies often have multiple legal entities with similar names. When searching for well-known or international companies, TRY MULTIPLE VARIATIONS:
+
+1. "[Company] Denmark" - Danish subsidiary (e.g., "Tietoevry Denmark")
+2. "[Company] A/S" or "[Company] ApS" - with legal form
+3. "[Company] DK" - common naming pattern
+4. "[Company] Holding" - holding company vs operating company
+5. Pre-merger/historical names - companies change names after M&A
+6. "[Company] filial" - branch of foreign company
+
+Example: Searching "Tietoevry" returns TIETOEVRY DK A/S (11 employees), but "Tietoevry Denmark" returns TIETOEVRY DENMARK A/S (56 employees) - a completely different legal entity.
+
+Always ask the user to clarify if the first result seems wrong (wrong size, wrong address, wrong industry).`},
+		},
+		ReadOnly:  true,
+		OpenWorld: true,
+		Elicits:   true,
 	},
 	{
 		Name:        "denmark_get_company",
@@ -149,6 +196,8 @@ var AllTools = []ToolSpec{
 		Category:    "read",
 		Country:     "denmark",
 		Description: `Get company by 8-digit CVR number. USE WHEN: you have a CVR number; use denmark_search_companies to find one by name. DK prefix auto-removed. Returns summary by default; full=true for complete data with production units and owners. FAILS WHEN: CVR number is not exactly 8 digits.`,
+		Task:        "Get Danish company details by CVR",
+		Examples:    []string{"Get details for CVR 10150817", "Look up Danish company DK-10150817"},
 		ReadOnly:    true,
 		OpenWorld:   true,
 	},
@@ -159,6 +208,8 @@ var AllTools = []ToolSpec{
 		Category:    "subunits",
 		Country:     "denmark",
 		Description: `Get production units (P-numbers) for a Danish company by CVR. USE WHEN: "list branches", "production units for CVR X". Returns P-number, name, address, and industry code per unit. Paginated: 20 results per page by default, max 100. Use page parameter for more results. FAILS WHEN: CVR number is not exactly 8 digits.`,
+		Task:        "Get production units (P-numbers)",
+		Examples:    []string{"What production units does CVR 10150817 have?", "List P-numbers for Novo Nordisk"},
 		ReadOnly:    true,
 		OpenWorld:   true,
 	},
@@ -169,6 +220,8 @@ var AllTools = []ToolSpec{
 		Category:    "search",
 		Country:     "denmark",
 		Description: `Find Danish company by phone number. USE WHEN: you only have a phone number, not a company name or CVR. Returns company name, CVR number, and address if found; empty result if no match. +45 prefix auto-removed. Not all companies have registered phones.`,
+		Task:        "Find a Danish company by phone number",
+		Examples:    []string{"Find company with phone 33121212"},
 		ReadOnly:    true,
 		OpenWorld:   true,
 	},
@@ -179,8 +232,24 @@ var AllTools = []ToolSpec{
 		Category:    "read",
 		Country:     "denmark",
 		Description: `Get parent company for a production unit P-number. USE WHEN: you have a P-number and need the owning company. P-number is 10 digits (e.g., 1012345678), obtainable from denmark_get_production_units. Returns the parent company's CVR number, name, address, and status. FAILS WHEN: P-number not found.`,
+		Task:        "Find the company behind a P-number",
+		Examples:    []string{"Look up production unit P-number 1000067892"},
+		ReadOnly:    true,
+		OpenWorld:   true,
+	},
+	{
+		Name:        "denmark_batch_get_companies",
+		Method:      "DKBatchGetCompanies",
+		Title:       "Batch Get Danish Companies",
+		Category:    "batch",
+		Country:     "denmark",
+		Description: `Look up multiple Danish companies by CVR number at once (max 100). USE WHEN: you have a list of CVR numbers to enrich or check. Returns company summaries (name, address, industry, status), a missing list, and per-item errors with reason not_found, invalid or upstream_error. One bad CVR never fails the whole call.`,
+		Task:        "Look up a list of Danish companies",
+		Examples:    []string{"Get details for these CVR numbers: 10150817, 24256790", "Enrich these Danish customers: 10150817, 24256790, 61126228"},
+		Hint:        "max 100 per call",
 		ReadOnly:    true,
 		OpenWorld:   true,
+		Timeout:     2 * time.Minute,
 	},
 
 	// ==========================================================================
@@ -192,9 +261,22 @@ var AllTools = []ToolSpec{
 		Title:       "Search Finnish Companies",
 		Category:    "search",
 		Country:     "finland",
-		Description: `Search Finnish companies by name. USE WHEN: "find Finnish company X" and you don't have a Y-tunnus. Partial matches and case-insensitive. Returns company name, business ID, form, and status. Paginated: 20 results per page by default, max 100. Common names return 900+ results. To narrow: use company_form=OY/OYJ for main companies, add location for city, or search exact name "Nokia Oyj" instead of "Nokia". FAILS WHEN: API is unreachable. If you have a Y-tunnus, use finland_get_company instead.`,
-		ReadOnly:    true,
-		OpenWorld:   true,
+		Description: `Search Finnish companies by name. USE WHEN: "find Finnish company X" and you don't have a Y-tunnus. Partial matches and case-insensitive. Returns company name, business ID, form, and status. Paginated: 20 results per page by default, max 100. Common names return 900+ results. To narrow: use company_form=OY/OYJ for main companies, add location for city, or search exact name "Nokia Oyj" instead of "Nokia". If the client supports elicitation and several companies match, the user picks one (declining returns the list). FAILS WHEN: API is unreachable. If you have a Y-tunnus, use finland_get_company instead.`,
+		Task:        "Search for Finnish companies by name",
+		Examples:    []string{"Find Finnish company Nokia", "Search for companies named Kone in Espoo"},
+		Caveats: []Caveat{
+			{Title: "Finnish Search Can Return 900+ Results", Text: `Common company names return too many results. To narrow down:
+
+1. Use exact legal name: "Nokia Oyj" instead of "Nokia"
+2. Filter by company_form: OY (private) or OYJ (public) for main operating companies
+3. Filter by location: city name to narrow geographically
+4. Combine filters: company_form=OY AND location=Helsinki
+
+Example: Searching "Nokia" returns 900+ results. Searching "Nokia Oyj" with company_form=OYJ returns just the main company.`},
+		},
+		ReadOnly:  true,
+		OpenWorld: true,
+		Elicits:   true,
 	},
 	{
 		Name:        "finland_get_company",
@@ -203,9 +285,25 @@ var AllTools = []ToolSpec{
 		Category:    "read",
 		Country:     "finland",
 		Description: `Get company by Y-tunnus (e.g., 0112038-9). USE WHEN: you have a Finnish business ID; use finland_search_companies to find one by name. FI prefix auto-removed. Returns summary by default; full=true for complete data with previous names and registry entries. FAILS WHEN: Y-tunnus format is invalid (must be 7 digits, hyphen, check digit).`,
+		Task:        "Get Finnish company details by business ID",
+		Examples:    []string{"Get details for business ID 0112038-9", "Look up business ID FI0112038-9"},
 		ReadOnly:    true,
 		OpenWorld:   true,
 	},
+	{
+		Name:        "finland_batch_get_companies",
+		Method:      "FIBatchGetCompanies",
+		Title:       "Batch Get Finnish Companies",
+		Category:    "batch",
+		Country:     "finland",
+		Description: `Look up multiple Finnish companies by business ID (Y-tunnus) at once (max 100). USE WHEN: you have a list of business IDs to enrich or check. Returns company summaries (name, form, address, industry, status), a missing list, and per-item errors with reason not_found, invalid (bad format or check digit) or upstream_error. One bad ID never fails the whole call.`,
+		Task:        "Look up a list of Finnish companies",
+		Examples:    []string{"Look up these Finnish suppliers: 0112038-9, 1927400-1"},
+		Hint:        "max 100 per call",
+		ReadOnly:    true,
+		OpenWorld:   true,
+		Timeout:     2 * time.Minute,
+	},
 
 	// ==========================================================================
 	// SWEDEN - Bolagsverket (requires OAuth2 credentials)
@@ -216,9 +314,15 @@ var AllTools = []ToolSpec{
 		Title:       "Get Swedish Company Details",
 		Category:    "read",
 		Country:     "sweden",
-		Description: `Get company by 10-digit org number (or 12-digit personal/coordination number). USE WHEN: you have a Swedish org number and need company details. Returns company name, organization form, legal form, business description, registration date, postal address, active status, deregistration info, ongoing proceedings, and industry codes. No name search available in this API; ask user for org number if not provided. FAILS WHEN: org number is not 10 or 12 digits, or company not found in Bolagsverket. Requires Sweden OAuth2 credentials configured server-side; use sweden_check_status to verify availability first.`,
-		ReadOnly:    true,
-		OpenWorld:   true,
+		Description: `Get company by 10-digit org number (or 12-digit personal/coordination number). USE WHEN: you have a Swedish org number and need company details. Returns company name, organization form, legal form, business description, registration date, postal address, active status, deregistration info, ongoing proceedings, and industry codes. If several organisations share a personal number, the user picks one when the client supports elicitation; otherwise the first is returned. No name search available in this API; ask user for org number if not provided. FAILS WHEN: org number is not 10 or 12 digits, or company not found in Bolagsverket. Requires Sweden OAuth2 credentials configured server-side; use sweden_check_status to verify availability first.`,
+		Task:        "Get Swedish company details",
+		Examples:    []string{"Get Swedish company 5560125790", "Look up 556012-5790"},
+		Caveats: []Caveat{
+			{Title: "Sweden Has No Name Search", Text: "Sweden has NO name search in this API - you must have the 10-digit organization number. Ask the user for the org number if not provided."},
+		},
+		ReadOnly:  true,
+		OpenWorld: true,
+		Elicits:   true,
 	},
 	{
 		Name:        "sweden_get_document_list",
@@ -227,6 +331,8 @@ var AllTools = []ToolSpec{
 		Category:    "documents",
 		Country:     "sweden",
 		Description: `List available årsredovisningar (annual reports) for a Swedish company. USE WHEN: "what reports are available?", "list annual reports". Returns document IDs, financial year dates, and filing dates. Metadata only; use sweden_download_document with a document ID to get the actual report. FAILS WHEN: org number is not 10 digits, or company has no filed reports. Requires Sweden OAuth2 credentials configured server-side; use sweden_check_status to verify availability first.`,
+		Task:        "List annual reports (årsredovisningar)",
+		Examples:    []string{"What annual reports exist for 5560125790?", "Show årsredovisningar for Volvo"},
 		ReadOnly:    true,
 		OpenWorld:   true,
 	},
@@ -237,6 +343,8 @@ var AllTools = []ToolSpec{
 		Category:    "status",
 		Country:     "sweden",
 		Description: `Check if Bolagsverket API is available. USE WHEN: Sweden tools return errors, or you want to verify connectivity before a batch of Swedish lookups. Returns availability status, circuit breaker state ("closed", "open", or "half-open"), and number of cached entries.`,
+		Task:        "Check the Swedish registry connection",
+		Examples:    []string{"Is the Swedish API working?", "Check connection to Bolagsverket"},
 		ReadOnly:    true,
 		OpenWorld:   true,
 	},
@@ -247,9 +355,131 @@ var AllTools = []ToolSpec{
 		Category:    "documents",
 		Country:     "sweden",
 		Description: `Download an annual report (årsredovisning) by document ID. USE WHEN: you have a document ID from sweden_get_document_list. Writes the ZIP (XBRL/iXBRL files, typically 1-10 MB) to a local file and returns its path plus size_bytes — the bytes are NOT inlined, so this is safe to call inside an agent pipeline. Read or unzip the file at the returned path to inspect the report. FAILS WHEN: document ID is not found or the file has been removed from Bolagsverket. Requires Sweden OAuth2 credentials configured server-side; use sweden_check_status to verify availability first.`,
+		Task:        "Download an annual report",
+		Examples:    []string{"Download the 2023 annual report for 5560125790", "Get the årsredovisning with document ID abc123"},
+		Hint:        "use a document ID from sweden_get_document_list",
+		ReadOnly:    true,
+		OpenWorld:   true,
+		Timeout:     2 * time.Minute,
+	},
+	{
+		Name:        "sweden_batch_get_companies",
+		Method:      "SEBatchGetCompanies",
+		Title:       "Batch Get Swedish Companies",
+		Category:    "batch",
+		Country:     "sweden",
+		Description: `Look up multiple Swedish companies by organization number at once (max 100). USE WHEN: you have a list of Swedish org numbers to enrich or check. Returns company summaries (name, form, status, address), a missing list, and per-item errors with reason not_found, invalid or upstream_error. One bad number never fails the whole call. Requires Sweden OAuth2 credentials configured server-side.`,
+		Task:        "Look up a list of Swedish companies",
+		Examples:    []string{"Check these Swedish org numbers: 5560125790, 5565475489"},
+		Hint:        "max 100 per call",
+		ReadOnly:    true,
+		OpenWorld:   true,
+		Timeout:     2 * time.Minute,
+	},
+
+	// ==========================================================================
+	// NORDIC - Cross-registry tools
+	// ==========================================================================
+	{
+		Name:        "nordic_validate_identifiers",
+		Method:      "NordicValidateIdentifiers",
+		Title:       "Validate Nordic Company Identifiers",
+		Category:    "batch",
+		Country:     "nordic",
+		Description: `Validate a list of mixed Nordic company identifiers (max 5000) in one call. USE WHEN: "check these supplier IDs", "which org numbers are invalid or dissolved?", cleaning imported master data. Detects the country of each entry (NO org number, DK CVR, FI business ID, SE org/personal number; VAT forms accepted), normalizes it and verifies the check digit. Set check_registry=true to also report whether each valid entry exists and is active; set issues_only=true to return only problem entries. Bare 8-digit numbers are ambiguous between DK and FI: pass default_country to decide. Swedish registry checks need Bolagsverket credentials configured server-side.`,
+		Task:        "Validate a list of identifiers from several countries",
+		Examples:    []string{"Check these supplier IDs and tell me which are invalid or dissolved", "Check these 800 supplier IDs before the payment run and list the bad ones", "Which of these org numbers belong to dissolved companies?"},
+		Hint:        "set check_registry=true for existence/active status, issues_only=true for a short report",
+		ReadOnly:    true,
+		OpenWorld:   true,
+		Timeout:     5 * time.Minute,
+	},
+	{
+		Name:        "nordic_check_vat",
+		Method:      "NordicCheckVAT",
+		Title:       "Check Nordic VAT Number",
+		Category:    "read",
+		Country:     "nordic",
+		Description: `Check whether a Nordic VAT number is live and whom it belongs to. USE WHEN: "is this VAT number valid?", verifying a supplier or customer before invoicing. DK, FI and SE numbers are checked in EU VIES, which returns the registered name and address when the member state discloses them; NO numbers are checked against the Norwegian VAT register (Merverdiavgiftsregisteret). The VAT-registered name is cross-checked against the national company registry: name_match is exact, similar, mismatch or unknown. Accepts NO923609016MVA, DK10150817, FI01120389, SE556012579001 or the bare identifier. FAILS WHEN: the number is malformed, or a VIES member-state service is temporarily unavailable (retry later).`,
+		Task:        "Check a VAT number before invoicing",
+		Examples:    []string{"Is DK10150817 a live VAT number, and is it Novo Nordisk's?", "Check the VAT number on this invoice belongs to Nokia Oyj"},
+		Hint:        "VIES for DK/FI/SE, the Norwegian VAT register for NO; compare registered_name with registry_name via name_match",
 		ReadOnly:    true,
 		OpenWorld:   true,
 	},
+	{
+		Name:        "nordic_lookup_industry_code",
+		Method:      "NordicLookupIndustryCode",
+		Title:       "Look Up Industry Code (NACE)",
+		Category:    "reference",
+		Country:     "nordic",
+		Description: `Translate a national industry code to canonical NACE with English labels. USE WHEN: comparing or segmenting companies across countries, "what does SN2007 62.010 mean?", finding the NACE division for a DB07, TOL 2008 or SNI code. Schemes: nace (Rev.2, default), nace2.1, sn2007/sn2025 (NO), db07/db25 (DK), tol2008/tol2025 (FI), sni2007/sni2025 (SE). Returns section, division, group and class; Rev.2 codes also carry their Rev.2.1 section. Pass a section letter to list its divisions, or no code to list all sections. Company results from every country already carry a nace field. Works offline from embedded tables. FAILS WHEN: the scheme is unknown, the division, group or class does not exist in that NACE revision, or the code means "industry not specified".`,
+		Task:        "Compare industries across countries",
+		Examples:    []string{"Which of these Norwegian and Swedish companies are in IT services?", "What NACE code is Danish branchekode 620100?", "List the divisions in NACE section C"},
+		Hint:        "to translate a national code (SN2007, DB07, TOL 2008, SNI) or list NACE sections/divisions; company results carry a nace field, so compare nace.division across countries",
+		ReadOnly:    true,
+	},
+	{
+		Name:        "nordic_list_legal_forms",
+		Method:      "NordicListLegalForms",
+		Title:       "List Nordic Legal Forms",
+		Category:    "reference",
+		Country:     "nordic",
+		Description: `List the legal forms of all four registries (AS, ASA, ApS, A/S, OY, OYJ, AB, HB, ...) with a common category, owner liability and ISO 20275 ELF code. USE WHEN: "only limited companies" or "only sole traders" must be applied across countries, finding which national code to pass to norway_search_companies org_form or finland_search_companies company_form, explaining an unfamiliar abbreviation. Categories: limited, public_limited, partnership, sole_trader, cooperative, association, foundation, branch_of_foreign. Liability: limited, unlimited, mixed, proportional, none, parent. Company results already carry the same classification as legal_form_class. Works offline from an embedded table. FAILS WHEN: country or category is not one of the listed values.`,
+		Task:        "Filter by legal form across countries",
+		Examples:    []string{"Only limited companies, please", "What is a Finnish Ky?", "Which Danish legal forms have limited liability?"},
+		Hint:        "category filter; company results carry legal_form_class.category",
+		ReadOnly:    true,
+	},
+
+	// ==========================================================================
+	// ADMIN - response caches and circuit breakers (only with -admin-tools)
+	// ==========================================================================
+	{
+		Name:        "admin_cache_stats",
+		Method:      "AdminCacheStats",
+		Title:       "List Registry Cache Statistics",
+		Category:    "admin",
+		Country:     "admin",
+		Description: `List the response cache of each registry client: entries held, remembered not-found outcomes, and how often those answered a call. USE WHEN: an operator asks how much is cached, or before purging. Pass registry for one client. FAILS WHEN: registry is not one of the configured clients.`,
+		Task:        "Inspect the response caches",
+		Examples:    []string{"How many Norwegian companies are cached?", "Show the cache statistics of every registry"},
+		ReadOnly:    true,
+	},
+	{
+		Name:        "admin_purge_cache",
+		Method:      "AdminPurgeCache",
+		Title:       "Purge Registry Cache",
+		Category:    "admin",
+		Country:     "admin",
+		Description: `Drop cached answers of one registry so the next call goes to the registry: everything cached for an identifier, every entry under a cache key prefix, or the whole cache. USE WHEN: a registry corrected a record and the cached copy is wrong, or after an incident served bad data. Give exactly one of identifier, prefix and all. FAILS WHEN: registry is unknown, or not exactly one of identifier, prefix and all is given.`,
+		Task:        "Purge cached answers",
+		Examples:    []string{"Drop the cached record of 923609016", "Clear the whole VIES cache"},
+		Destructive: true,
+		Idempotent:  true,
+	},
+	{
+		Name:        "admin_circuit_status",
+		Method:      "AdminCircuitStatus",
+		Title:       "List Registry Circuit Breakers",
+		Category:    "admin",
+		Country:     "admin",
+		Description: `List the circuit breaker of each registry client: closed, open or half-open, consecutive failures, the last failure, and whether an operator forced it open. USE WHEN: calls to a registry fail fast and you want to know why. Pass registry for one client. FAILS WHEN: registry is not one of the configured clients.`,
+		Task:        "Inspect the circuit breakers",
+		Examples:    []string{"Is the circuit to the Danish registry open?", "Show every circuit breaker"},
+		ReadOnly:    true,
+	},
+	{
+		Name:        "admin_set_circuit",
+		Method:      "AdminSetCircuit",
+		Title:       "Reset or Open Registry Circuit",
+		Category:    "admin",
+		Country:     "admin",
+		Description: `Reset a registry's circuit breaker or force it open. USE WHEN: a registry has recovered and should be tried again now (reset), or is known to be down and should not be called until further notice (open). A forced-open circuit stays open until reset; meanwhile tools answer from stale cache entries where they can. FAILS WHEN: registry is unknown, or action is not reset or open.`,
+		Task:        "Reset or force open a circuit breaker",
+		Examples:    []string{"Stop calling the Finnish registry until I say so", "Reset the circuit to Bolagsverket"},
+		Idempotent:  true,
+	},
 }
 
 // ToolsByCountry returns tools filtered by country.
@@ -42,6 +42,8 @@ func TestToolNamingPrefix(t *testing.T) {
 		"denmark": "denmark_",
 		"finland": "finland_",
 		"sweden":  "sweden_",
+		"nordic":  "nordic_",
+		"admin":   "admin_",
 	}
 
 	for _, tool := range AllTools {
@@ -64,6 +66,8 @@ func TestToolCountries(t *testing.T) {
 		"denmark": true,
 		"finland": true,
 		"sweden":  true,
+		"nordic":  true,
+		"admin":   true,
 	}
 
 	for _, tool := range AllTools {
@@ -86,6 +90,7 @@ func TestToolCategories(t *testing.T) {
 		"reference": true,
 		"documents": true,
 		"status":    true,
+		"admin":     true,
 	}
 
 	for _, tool := range AllTools {
@@ -97,9 +102,19 @@ func TestToolCategories(t *testing.T) {
 	}
 }
 
+// offlineTools answer from data embedded in the binary and never call a registry.
+var offlineTools = map[string]bool{
+	"nordic_lookup_industry_code": true,
+	"nordic_list_legal_forms":     true,
+}
+
 func TestToolAnnotations(t *testing.T) {
 	for _, tool := range AllTools {
 		t.Run(tool.Name, func(t *testing.T) {
+			if tool.Category == "admin" {
+				checkAdminAnnotations(t, tool)
+				return
+			}
 			if !tool.ReadOnly {
 				t.Errorf("tool %q should be ReadOnly (all registries are read-only)", tool.Name)
 			}
@@ -114,6 +129,12 @@ func TestToolAnnotations(t *testing.T) {
 			if tool.Idempotent {
 				t.Errorf("tool %q is ReadOnly and must not also be Idempotent", tool.Name)
 			}
+			if offlineTools[tool.Name] {
+				if tool.OpenWorld {
+					t.Errorf("tool %q answers from embedded data and must not be OpenWorld", tool.Name)
+				}
+				return
+			}
 			if !tool.OpenWorld {
 				t.Errorf("tool %q should be OpenWorld (accesses external registries)", tool.Name)
 			}
@@ -121,8 +142,24 @@ func TestToolAnnotations(t *testing.T) {
 	}
 }
 
+// checkAdminAnnotations checks an admin tool: it acts on this server's own
+// caches and circuit breakers, never on a registry, and the ones that change
+// them can be repeated safely.
+func checkAdminAnnotations(t *testing.T, tool ToolSpec) {
+	t.Helper()
+	if tool.OpenWorld {
+		t.Errorf("admin tool %q must not be OpenWorld", tool.Name)
+	}
+	if tool.ReadOnly && (tool.Idempotent || tool.Destructive) {
+		t.Errorf("admin tool %q is ReadOnly and must not also be Idempotent or Destructive", tool.Name)
+	}
+	if !tool.ReadOnly && !tool.Idempotent {
+		t.Errorf("admin tool %q changes state and should be Idempotent", tool.Name)
+	}
+}
+
 func TestToolCount(t *testing.T) {
-	expectedCount := 23
+	expectedCount := 34
 	if len(AllTools) != expectedCount {
 		t.Errorf("expected %d tools, got %d", expectedCount, len(AllTools))
 	}
@@ -131,9 +168,11 @@ func TestToolCount(t *testing.T) {
 func TestToolCountByCountry(t *testing.T) {
 	expected := map[string]int{
 		"norway":  12,
-		"denmark": 5,
-		"finland": 2,
-		"sweden":  4,
+		"denmark": 6,
+		"finland": 3,
+		"sweden":  5,
+		"nordic":  4,
+		"admin":   4,
 	}
 
 	for country, want := range expected {
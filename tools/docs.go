@@ -0,0 +1,284 @@
+package tools
+
+import (
+	"embed"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// The server instructions and the tool reference in docs/API.md are both
+// rendered from AllTools and AllCountries, so that neither can drift from the
+// registered tools. TestToolDocumentation fails when a tool lacks the fields
+// they need, and TestAPIReference when docs/API.md is stale ("make docs"
+// regenerates it).
+
+// notes holds the docs/API.md-only Markdown of tools and countries, named
+// after ToolSpec.Name or CountrySpec.Country.
+//
+//go:embed notes/*.md
+var notes embed.FS
+
+// note returns the notes for name, or "" when there are none.
+func note(name string) string {
+	b, err := notes.ReadFile("notes/" + name + ".md")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
+
+const legalFormsInstructions = `## Legal Forms
+
+Company results carry legal_form_class with a common category (limited, public_limited, partnership, sole_trader, cooperative, association, foundation, branch_of_foreign), owner liability and ISO 20275 ELF code.
+To restrict to "limited companies" or similar across countries, filter on legal_form_class.category.`
+
+// Instructions renders the server instructions for the registered tools,
+// resources and prompts.
+func (h *HandlerRegistry) Instructions() string {
+	registered := h.RegisteredTools()
+	parts := []string{"Nordic Registry MCP Server - Access Nordic Business Registries"}
+
+	available := "## Available Countries\n\nCurrently supports:"
+	for _, c := range AllCountries {
+		if c.Registry != "" && hasCountry(registered, c.Country) {
+			available += fmt.Sprintf("\n- **%s** (%s / %s) - %s", c.Name, c.Registry, c.Source, c.Summary)
+		}
+	}
+	parts = append(parts, available)
+
+	for _, c := range AllCountries {
+		if !hasCountry(registered, c.Country) {
+			continue
+		}
+		parts = append(parts, "## "+c.Lookups)
+		for _, spec := range registered {
+			if spec.Country != c.Country {
+				continue
+			}
+			use := "-> USE: " + spec.Name
+			if spec.Hint != "" {
+				use += " (" + spec.Hint + ")"
+			}
+			parts = append(parts, fmt.Sprintf("### %s:\n%q\n%s", spec.Task, spec.Examples[0], use))
+			for _, cv := range spec.Caveats {
+				parts = append(parts, "### IMPORTANT: "+cv.Title+"\n\n"+cv.Text)
+			}
+		}
+	}
+
+	if section := h.resourceInstructions(); section != "" {
+		parts = append(parts, section)
+	}
+	if section := h.promptInstructions(); section != "" {
+		parts = append(parts, section)
+	}
+
+	for _, c := range AllCountries {
+		if id := c.Identifier; id != nil && hasCountry(registered, c.Country) {
+			examples := make([]string, len(id.Examples))
+			for i, e := range id.Examples {
+				examples[i] = fmt.Sprintf("%q", e)
+			}
+			parts = append(parts, fmt.Sprintf("## %s\n\n%s\nExamples: %s all work.", id.Name, id.Format, strings.Join(examples, ", ")))
+		}
+	}
+	legalForms := legalFormsInstructions
+	if h.hasTool("nordic_list_legal_forms") {
+		legalForms += "\n-> USE: nordic_list_legal_forms to explain a code (AS, ApS, OYJ, HB) or find the national codes for a category"
+	}
+	parts = append(parts, legalForms)
+
+	return strings.Join(parts, "\n\n")
+}
+
+// resourceInstructions lists the registered resources, or returns "" when
+// there are none.
+func (h *HandlerRegistry) resourceInstructions() string {
+	var templates, static []string
+	for _, spec := range h.RegisteredResources() {
+		if spec.Template {
+			templates = append(templates, spec.URI)
+		} else {
+			static = append(static, spec.URI)
+		}
+	}
+	var lines []string
+	if len(templates) > 0 {
+		lines = append(lines, "Company records can also be attached as MCP resources: "+strings.Join(templates, ", ")+".")
+	}
+	if len(static) > 0 {
+		lines = append(lines, "Reference data: "+strings.Join(static, ", ")+".")
+	}
+	if len(templates) > 0 {
+		lines = append(lines, "Subscribe to a company resource to be notified when the registry record changes during a long session.")
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return "## Resources\n\n" + strings.Join(lines, "\n")
+}
+
+// promptInstructions lists the registered prompts with their arguments, or
+// returns "" when there are none.
+func (h *HandlerRegistry) promptInstructions() string {
+	var prompts []string
+	for _, spec := range h.RegisteredPrompts() {
+		var args []string
+		for _, arg := range h.prompts[spec.Method].arguments {
+			args = append(args, arg.Name)
+		}
+		prompts = append(prompts, spec.Name+" ("+strings.Join(args, ", ")+")")
+	}
+	if len(prompts) == 0 {
+		return ""
+	}
+	return "## Prompts\n\nDue-diligence workflows are available as MCP prompts: " + strings.Join(prompts, ", ") + "."
+}
+
+// hasCountry reports whether any of specs belongs to country.
+func hasCountry(specs []ToolSpec, country string) bool {
+	return slices.ContainsFunc(specs, func(spec ToolSpec) bool { return spec.Country == country })
+}
+
+// APIReference renders the tool reference of docs/API.md for the registered
+// tools: per country its identifier format, and per tool its purpose,
+// caveats, notes, parameters and result fields from the advertised schemas,
+// and example prompts.
+func (h *HandlerRegistry) APIReference() string {
+	registered := h.RegisteredTools()
+	// Registering a tool builds its schemas; a scratch server keeps that
+	// from touching the one the registry serves.
+	scratch := mcp.NewServer(&mcp.Implementation{Name: "docs"}, nil)
+
+	var b strings.Builder
+	for _, c := range AllCountries {
+		if !hasCountry(registered, c.Country) {
+			continue
+		}
+		heading := c.Name
+		if c.Registry != "" {
+			heading += " (" + c.Registry + ")"
+		}
+		fmt.Fprintf(&b, "## %s\n\n", heading)
+		if n := note(c.Country); n != "" {
+			b.WriteString(n + "\n\n")
+		}
+		if id := c.Identifier; id != nil {
+			fmt.Fprintf(&b, "**Identifier:** %s Accepted spellings: `%s`.\n\n", id.Format, strings.Join(id.Examples, "`, `"))
+		}
+
+		for _, spec := range registered {
+			if spec.Country != c.Country {
+				continue
+			}
+			tool := h.buildTool(spec)
+			h.handlers[spec.Method](scratch, tool, spec)
+			writeToolReference(&b, spec, tool)
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// writeToolReference writes the docs/API.md section of one tool. tool must
+// carry the schemas set at registration.
+func writeToolReference(b *strings.Builder, spec ToolSpec, tool *mcp.Tool) {
+	fmt.Fprintf(b, "### %s\n\n%s.\n\n", spec.Name, spec.Task)
+	fmt.Fprintf(b, "**Category:** %s · **Timeout:** %s", spec.Category, formatDuration(spec.timeout()))
+	if spec.Elicits {
+		b.WriteString(" · **Elicitation:** yes")
+	}
+	b.WriteString("\n\n")
+	for _, cv := range spec.Caveats {
+		fmt.Fprintf(b, "**⚠️ Important: %s**\n\n%s\n\n", cv.Title, cv.Text)
+	}
+	if n := note(spec.Name); n != "" {
+		b.WriteString(n + "\n\n")
+	}
+
+	in, _ := tool.InputSchema.(*jsonschema.Schema)
+	if in == nil || len(in.Properties) == 0 {
+		b.WriteString("**Parameters:** None\n\n")
+	} else {
+		b.WriteString("**Parameters:**\n\n| Name | Type | Required | Description |\n|------|------|----------|-------------|\n")
+		for _, name := range propertyNames(in) {
+			required := "No"
+			if slices.Contains(in.Required, name) {
+				required = "Yes"
+			}
+			p := in.Properties[name]
+			fmt.Fprintf(b, "| `%s` | %s | %s | %s |\n", name, schemaType(p), required, tableCell(p.Description))
+		}
+		b.WriteString("\n")
+	}
+	headers := make([]string, 0, len(spec.HeaderParams))
+	for prop, header := range spec.HeaderParams {
+		headers = append(headers, fmt.Sprintf("`%s` is also sent as the `Mcp-Param-%s` header over Streamable HTTP.", prop, header))
+	}
+	slices.Sort(headers)
+	for _, line := range headers {
+		b.WriteString(line + "\n\n")
+	}
+
+	if out, _ := tool.OutputSchema.(*jsonschema.Schema); out != nil && len(out.Properties) > 0 {
+		b.WriteString("**Returns:**\n\n| Field | Type | Description |\n|-------|------|-------------|\n")
+		for _, name := range propertyNames(out) {
+			p := out.Properties[name]
+			fmt.Fprintf(b, "| `%s` | %s | %s |\n", name, schemaType(p), tableCell(p.Description))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("**Example prompts:**\n")
+	for _, e := range spec.Examples {
+		fmt.Fprintf(b, "- %q\n", e)
+	}
+	b.WriteString("\n---\n\n")
+}
+
+// propertyNames returns the properties of s in declaration order.
+func propertyNames(s *jsonschema.Schema) []string {
+	names := slices.Clone(s.PropertyOrder)
+	var rest []string
+	for name := range s.Properties {
+		if !slices.Contains(names, name) {
+			rest = append(rest, name)
+		}
+	}
+	slices.Sort(rest)
+	return append(names, rest...)
+}
+
+// schemaType renders the JSON type of s, e.g. "string" or "object[]".
+func schemaType(s *jsonschema.Schema) string {
+	t := s.Type
+	for _, candidate := range s.Types {
+		if t == "" && candidate != "null" {
+			t = candidate
+		}
+	}
+	switch {
+	case t == "array" && s.Items != nil:
+		return schemaType(s.Items) + "[]"
+	case t == "":
+		return "any"
+	}
+	return t
+}
+
+// formatDuration renders d as "30s" or "2m" rather than "2m0s".
+func formatDuration(d time.Duration) string {
+	if d >= time.Minute && d%time.Minute == 0 {
+		return fmt.Sprintf("%dm", d/time.Minute)
+	}
+	return d.String()
+}
+
+// tableCell makes text safe for a Markdown table cell.
+func tableCell(text string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(text, "|", `\|`), "\n", " ")
+}
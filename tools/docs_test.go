@@ -0,0 +1,98 @@
+package tools
+
+import (
+	"flag"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the generated tool reference in docs/API.md")
+
+const (
+	apiReferencePath  = "../docs/API.md"
+	apiReferenceBegin = "<!-- BEGIN GENERATED TOOL REFERENCE"
+	apiReferenceEnd   = "<!-- END GENERATED TOOL REFERENCE -->"
+)
+
+func TestToolDocumentation(t *testing.T) {
+	var countries, names []string
+	for _, c := range AllCountries {
+		countries = append(countries, c.Country)
+	}
+	for _, spec := range AllTools {
+		names = append(names, spec.Name)
+		if spec.Task == "" {
+			t.Errorf("%s: missing Task", spec.Name)
+		}
+		if len(spec.Examples) == 0 {
+			t.Errorf("%s: missing Examples", spec.Name)
+		}
+		if !slices.Contains(countries, spec.Country) {
+			t.Errorf("%s: country %q is not in AllCountries", spec.Name, spec.Country)
+		}
+	}
+
+	entries, err := notes.ReadDir("notes")
+	if err != nil {
+		t.Fatalf("reading notes: %v", err)
+	}
+	for _, e := range entries {
+		name := strings.TrimSuffix(e.Name(), ".md")
+		if !slices.Contains(names, name) && !slices.Contains(countries, name) {
+			t.Errorf("notes/%s names no tool or country", e.Name())
+		}
+	}
+
+	registry := newPromptTestRegistry(t, true)
+	instructions := registry.Instructions()
+	for _, spec := range registry.RegisteredTools() {
+		if !strings.Contains(instructions, "-> USE: "+spec.Name) {
+			t.Errorf("instructions do not document %s", spec.Name)
+		}
+	}
+}
+
+func TestInstructions_Filtered(t *testing.T) {
+	registry := newPromptTestRegistry(t, true)
+	registry.filter = ToolFilter{Countries: []string{"norway"}, Deny: []string{"norway_get_roles"}}
+	instructions := registry.Instructions()
+
+	if !strings.Contains(instructions, "-> USE: norway_get_company") {
+		t.Error("instructions do not document norway_get_company")
+	}
+	for _, absent := range []string{"norway_get_roles", "denmark_", "finland_", "sweden_", "nordic_", "**Denmark**", "board_check", "Danish CVR Numbers"} {
+		if strings.Contains(instructions, absent) {
+			t.Errorf("instructions mention %q, which the filter disables", absent)
+		}
+	}
+}
+
+// TestAPIReference fails when the generated section of docs/API.md is stale.
+// Run it with -update to rewrite that section.
+func TestAPIReference(t *testing.T) {
+	data, err := os.ReadFile(apiReferencePath)
+	if err != nil {
+		t.Fatalf("reading %s: %v", apiReferencePath, err)
+	}
+	doc := string(data)
+	begin := strings.Index(doc, apiReferenceBegin)
+	end := strings.Index(doc, apiReferenceEnd)
+	if begin < 0 || end < begin {
+		t.Fatalf("%s lacks the generated tool reference markers", apiReferencePath)
+	}
+	begin += strings.Index(doc[begin:], "\n") + 1
+
+	want := "\n" + newPromptTestRegistry(t, true).APIReference() + "\n\n"
+	if doc[begin:end] == want {
+		return
+	}
+	if !*update {
+		t.Fatalf("%s is stale; run make docs", apiReferencePath)
+	}
+	doc = doc[:begin] + want + doc[end:]
+	if err := os.WriteFile(apiReferencePath, []byte(doc), 0o644); err != nil {
+		t.Fatalf("writing %s: %v", apiReferencePath, err)
+	}
+}
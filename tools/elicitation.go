@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
+)
+
+// Elicitation lets a tool ask the user to settle an ambiguous lookup instead
+// of leaving the LLM to guess. Client code asks through infra.Ask; the
+// question travels as a multi round-trip input request (SEP-2322): the call
+// returns the elicitation in inputRequests, and runs again with the answer in
+// inputResponses. The SDK makes that round trip itself for clients on older
+// protocol versions, sending elicitation/create and re-invoking the handler,
+// so the tool code is the same for both. A re-run hits the cache, so asking
+// costs no second upstream request.
+
+// Properties of the elicitation forms.
+const (
+	choiceProperty  = "choice"
+	confirmProperty = "confirm"
+)
+
+// inputRequiredError suspends a tool call until the client answers requests.
+// register turns it into an input-required result.
+type inputRequiredError struct {
+	requests mcp.InputRequestMap
+}
+
+func (e *inputRequiredError) Error() string {
+	return "waiting for the user to answer an elicitation"
+}
+
+// canElicit reports whether the session's client accepts form elicitation.
+// A client that declares no elicitation mode supports form, as MCP defines.
+func canElicit(session *mcp.ServerSession) bool {
+	if session == nil {
+		return false
+	}
+	params := session.InitializeParams()
+	if params == nil || params.Capabilities == nil || params.Capabilities.Elicitation == nil {
+		return false
+	}
+	caps := params.Capabilities.Elicitation
+	return caps.Form != nil || caps.URL == nil
+}
+
+// askFunc returns the infra.AskFunc for one tool call. It answers a question
+// from the call's inputResponses when the client has already answered it, and
+// otherwise suspends the call with the question as an input request.
+func askFunc(responses mcp.InputResponseMap) infra.AskFunc {
+	return func(_ context.Context, q infra.Question) (infra.Answer, error) {
+		resp, ok := responses[q.Key]
+		if !ok {
+			return infra.Answer{}, &inputRequiredError{requests: mcp.InputRequestMap{q.Key: elicitParams(q)}}
+		}
+		res, ok := resp.(*mcp.ElicitResult)
+		if !ok {
+			return infra.Answer{}, nil
+		}
+		return answerFrom(q, res), nil
+	}
+}
+
+// elicitParams builds the form for q: a single-select of the choices, or a
+// yes/no confirmation when there are none.
+func elicitParams(q infra.Question) *mcp.ElicitParams {
+	schema := &jsonschema.Schema{Type: "object"}
+	if len(q.Choices) == 0 {
+		schema.Properties = map[string]*jsonschema.Schema{
+			confirmProperty: {Type: "boolean", Title: "Yes, this is the one"},
+		}
+		schema.Required = []string{confirmProperty}
+	} else {
+		choice := &jsonschema.Schema{Type: "string", Title: "Company"}
+		for _, c := range q.Choices {
+			value := any(c.Value)
+			choice.OneOf = append(choice.OneOf, &jsonschema.Schema{Const: &value, Title: c.Label})
+		}
+		schema.Properties = map[string]*jsonschema.Schema{choiceProperty: choice}
+		schema.Required = []string{choiceProperty}
+	}
+	return &mcp.ElicitParams{Mode: "form", Message: q.Message, RequestedSchema: schema}
+}
+
+// answerFrom reads the user's answer to q. Anything but an accepted form with
+// a valid value counts as unanswered: clients on the multi round-trip protocol
+// send the answer with the retried call, unchecked against the form.
+func answerFrom(q infra.Question, res *mcp.ElicitResult) infra.Answer {
+	if res.Action != "accept" {
+		return infra.Answer{}
+	}
+	if len(q.Choices) == 0 {
+		confirmed, ok := res.Content[confirmProperty].(bool)
+		return infra.Answer{Answered: ok, Confirmed: confirmed}
+	}
+	value, _ := res.Content[choiceProperty].(string)
+	if !slices.ContainsFunc(q.Choices, func(c infra.Choice) bool { return c.Value == value }) {
+		return infra.Answer{}
+	}
+	return infra.Answer{Answered: true, Value: value}
+}
@@ -0,0 +1,252 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/finland"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/sweden"
+)
+
+const (
+	// Two Nokias, neither named exactly as the query "Nokia".
+	fixtureFinlandTwoCompanies = `{"totalResults": 2, "companies": [
+		{"businessId": {"value": "0112038-9"}, "names": [{"name": "Nokia Oyj", "type": "1"}], "companyForms": [{"type": "OYJ"}], "status": "2"},
+		{"businessId": {"value": "2386496-9"}, "names": [{"name": "Nokia Solutions and Networks Oy", "type": "1"}], "companyForms": [{"type": "OY"}], "status": "2"}
+	]}`
+
+	// Two sole-proprietor firms registered under one personal number.
+	fixtureSwedenTwoOrganisations = `{"organisationer": [
+		{"organisationsidentitet": {"identitetsbeteckning": "198001011234"}, "organisationsnamn": {"organisationsnamnLista": [{"namn": "ANDERSSONS MÅLERI"}]}, "organisationsform": {"kod": "EF", "klartext": "Enskild näringsverksamhet"}},
+		{"organisationsidentitet": {"identitetsbeteckning": "198001011234"}, "organisationsnamn": {"organisationsnamnLista": [{"namn": "ANDERSSONS SNICKERI"}]}, "organisationsform": {"kod": "EF", "klartext": "Enskild näringsverksamhet"}}
+	]}`
+)
+
+// elicitHandler answers every elicitation with result and records the
+// messages it was shown.
+func elicitHandler(result *mcp.ElicitResult, asked *[]string) func(context.Context, *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+	return func(_ context.Context, req *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+		*asked = append(*asked, req.Params.Message)
+		return result, nil
+	}
+}
+
+// connectWithElicitation connects a client to registry's tools. A nil
+// handler leaves the client without the elicitation capability.
+func connectWithElicitation(t *testing.T, registry *HandlerRegistry, handler func(context.Context, *mcp.ElicitRequest) (*mcp.ElicitResult, error)) *mcp.ClientSession {
+	t.Helper()
+	server := createTestMCPServer()
+	registry.RegisterAll(server)
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(context.Background(), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect server: %v", err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, &mcp.ClientOptions{ElicitationHandler: handler})
+	session, err := client.Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect client: %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+// callStructured calls a tool and decodes its structured content into out.
+func callStructured(t *testing.T, session *mcp.ClientSession, name string, args map[string]any, out any) {
+	t.Helper()
+	res, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: args})
+	if err != nil {
+		t.Fatalf("%s: CallTool failed: %v", name, err)
+	}
+	if res.IsError {
+		t.Fatalf("%s: tool error: %s", name, res.Content[0].(*mcp.TextContent).Text)
+	}
+	data, err := json.Marshal(res.StructuredContent)
+	if err != nil {
+		t.Fatalf("%s: marshaling structured content: %v", name, err)
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		t.Fatalf("%s: decoding structured content: %v", name, err)
+	}
+}
+
+// newSingleAPIRegistry returns a registry whose Finnish company search and
+// Swedish organisation lookup both answer with body.
+func newSingleAPIRegistry(t *testing.T, body string) *HandlerRegistry {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /fi/companies", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, body)
+	})
+	mux.HandleFunc("POST /se/token", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"access_token": "test-token", "token_type": "Bearer", "expires_in": 3600}`)
+	})
+	mux.HandleFunc("POST /se/organisationer", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, body)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	fiClient := finland.NewClient(finland.WithLogger(logger)).WithBaseURL(srv.URL + "/fi")
+	t.Cleanup(fiClient.Close)
+	seClient, err := sweden.NewClient(
+		sweden.WithCredentials("test-id", "test-secret"),
+		sweden.WithTokenURL(srv.URL+"/se/token"),
+		sweden.WithBaseURL(srv.URL+"/se"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create Sweden client: %v", err)
+	}
+	t.Cleanup(seClient.Close)
+	return NewHandlerRegistry(HandlerRegistryConfig{FinlandClient: fiClient, SwedenClient: seClient, Logger: logger})
+}
+
+func TestElicitation_FinlandPick(t *testing.T) {
+	var asked []string
+	session := connectWithElicitation(t, newSingleAPIRegistry(t, fixtureFinlandTwoCompanies), elicitHandler(
+		&mcp.ElicitResult{Action: "accept", Content: map[string]any{choiceProperty: "2386496-9"}}, &asked))
+
+	var got finland.SearchCompaniesResult
+	callStructured(t, session, "finland_search_companies", map[string]any{"query": "Nokia"}, &got)
+
+	if len(asked) != 1 || !strings.Contains(asked[0], `2 companies matching "Nokia"`) {
+		t.Errorf("elicitation messages = %q, want one naming the match count", asked)
+	}
+	if !got.PickedByUser || len(got.Companies) != 1 || got.Companies[0].BusinessID != "2386496-9" {
+		t.Errorf("result = %+v, want only the picked company 2386496-9", got)
+	}
+}
+
+func TestElicitation_FinlandDeclineKeepsList(t *testing.T) {
+	var asked []string
+	session := connectWithElicitation(t, newSingleAPIRegistry(t, fixtureFinlandTwoCompanies), elicitHandler(
+		&mcp.ElicitResult{Action: "decline"}, &asked))
+
+	var got finland.SearchCompaniesResult
+	callStructured(t, session, "finland_search_companies", map[string]any{"query": "Nokia"}, &got)
+
+	if len(asked) != 1 {
+		t.Errorf("asked %d times, want 1", len(asked))
+	}
+	if got.PickedByUser || len(got.Companies) != 2 {
+		t.Errorf("result = %+v, want both matches", got)
+	}
+}
+
+func TestElicitation_NotAskedWithoutCapability(t *testing.T) {
+	session := connectWithElicitation(t, newSingleAPIRegistry(t, fixtureFinlandTwoCompanies), nil)
+
+	var got finland.SearchCompaniesResult
+	callStructured(t, session, "finland_search_companies", map[string]any{"query": "Nokia"}, &got)
+	if got.PickedByUser || len(got.Companies) != 2 {
+		t.Errorf("result = %+v, want both matches", got)
+	}
+}
+
+func TestElicitation_FinlandExactNameNotAsked(t *testing.T) {
+	var asked []string
+	session := connectWithElicitation(t, newSingleAPIRegistry(t, fixtureFinlandTwoCompanies), elicitHandler(
+		&mcp.ElicitResult{Action: "accept", Content: map[string]any{choiceProperty: "2386496-9"}}, &asked))
+
+	var got finland.SearchCompaniesResult
+	callStructured(t, session, "finland_search_companies", map[string]any{"query": "Nokia Oyj"}, &got)
+	if len(asked) != 0 {
+		t.Errorf("asked %q, want no question when one match has exactly the queried name", asked)
+	}
+	if len(got.Companies) != 2 {
+		t.Errorf("got %d companies, want 2", len(got.Companies))
+	}
+}
+
+func TestElicitation_DenmarkConfirm(t *testing.T) {
+	tests := []struct {
+		name      string
+		confirm   bool
+		wantFound bool
+	}{
+		{"confirmed", true, true},
+		{"rejected", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var asked []string
+			session := connectWithElicitation(t, newFixtureRegistry(t), elicitHandler(
+				&mcp.ElicitResult{Action: "accept", Content: map[string]any{confirmProperty: tt.confirm}}, &asked))
+
+			var got struct {
+				Found   bool            `json:"found"`
+				Message string          `json:"message"`
+				Company json.RawMessage `json:"company"`
+			}
+			callStructured(t, session, "denmark_search_companies", map[string]any{"query": "Carlsberg"}, &got)
+
+			if len(asked) != 1 || !strings.Contains(asked[0], "CARLSBERG A/S (CVR 10150817") {
+				t.Errorf("elicitation messages = %q, want one describing the match", asked)
+			}
+			if got.Found != tt.wantFound {
+				t.Errorf("found = %v, want %v", got.Found, tt.wantFound)
+			}
+			if !tt.wantFound && !strings.Contains(got.Message, "rejected") {
+				t.Errorf("message = %q, want it to say the match was rejected", got.Message)
+			}
+		})
+	}
+}
+
+func TestElicitation_SwedenPick(t *testing.T) {
+	var asked []string
+	session := connectWithElicitation(t, newSingleAPIRegistry(t, fixtureSwedenTwoOrganisations), elicitHandler(
+		&mcp.ElicitResult{Action: "accept", Content: map[string]any{choiceProperty: "1"}}, &asked))
+
+	var got sweden.GetCompanyResult
+	callStructured(t, session, "sweden_get_company", map[string]any{"org_number": "198001011234"}, &got)
+
+	if len(asked) != 1 || !strings.Contains(asked[0], "2 organisations") {
+		t.Errorf("elicitation messages = %q, want one naming the organisation count", asked)
+	}
+	if got.Company == nil || got.Company.Name != "ANDERSSONS SNICKERI" {
+		t.Errorf("company = %+v, want the picked ANDERSSONS SNICKERI", got.Company)
+	}
+}
+
+func TestAnswerFrom(t *testing.T) {
+	choose := infra.Question{Key: "company", Choices: []infra.Choice{{Value: "a", Label: "A"}, {Value: "b", Label: "B"}}}
+	confirm := infra.Question{Key: "company"}
+	tests := []struct {
+		name string
+		q    infra.Question
+		res  *mcp.ElicitResult
+		want infra.Answer
+	}{
+		{"picked", choose, &mcp.ElicitResult{Action: "accept", Content: map[string]any{choiceProperty: "b"}}, infra.Answer{Answered: true, Value: "b"}},
+		{"unknown choice", choose, &mcp.ElicitResult{Action: "accept", Content: map[string]any{choiceProperty: "c"}}, infra.Answer{}},
+		{"declined", choose, &mcp.ElicitResult{Action: "decline"}, infra.Answer{}},
+		{"cancelled", confirm, &mcp.ElicitResult{Action: "cancel"}, infra.Answer{}},
+		{"confirmed", confirm, &mcp.ElicitResult{Action: "accept", Content: map[string]any{confirmProperty: true}}, infra.Answer{Answered: true, Confirmed: true}},
+		{"rejected", confirm, &mcp.ElicitResult{Action: "accept", Content: map[string]any{confirmProperty: false}}, infra.Answer{Answered: true}},
+		{"no confirm value", confirm, &mcp.ElicitResult{Action: "accept", Content: map[string]any{}}, infra.Answer{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := answerFrom(tt.q, tt.res); got != tt.want {
+				t.Errorf("answerFrom = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ToolFilter selects the tools a deployment exposes, so that one that only
+// needs Norway does not put every Nordic tool in front of the model. The zero
+// value enables everything.
+//
+// A tool is enabled when it passes both the Countries and the Categories
+// selector (an empty selector passes everything). Allow names tools to enable
+// regardless of the selectors; on its own it is an allowlist, enabling only
+// the named tools. Deny always wins.
+type ToolFilter struct {
+	Countries  []string // ToolSpec.Country values, e.g. "norway", "nordic"
+	Categories []string // ToolSpec.Category values, e.g. "search", "read"
+	Allow      []string // Tool names to enable
+	Deny       []string // Tool names to disable
+}
+
+// Enabled reports whether the filter lets spec through.
+func (f ToolFilter) Enabled(spec ToolSpec) bool {
+	if slices.Contains(f.Deny, spec.Name) {
+		return false
+	}
+	if slices.Contains(f.Allow, spec.Name) {
+		return true
+	}
+	if len(f.Countries) == 0 && len(f.Categories) == 0 {
+		return len(f.Allow) == 0
+	}
+	return (len(f.Countries) == 0 || slices.Contains(f.Countries, spec.Country)) &&
+		(len(f.Categories) == 0 || slices.Contains(f.Categories, spec.Category))
+}
+
+// resourceEnabled reports whether the filter lets spec through. Resources
+// are selected by country only; categories and tool names do not apply.
+func (f ToolFilter) resourceEnabled(spec ResourceSpec) bool {
+	return len(f.Countries) == 0 || slices.Contains(f.Countries, spec.Country)
+}
+
+// Validate reports values that match no tool, so that a typo in the
+// configuration fails at startup instead of silently hiding tools.
+func (f ToolFilter) Validate() error {
+	var countries, categories, names []string
+	for _, spec := range AllTools {
+		countries = append(countries, spec.Country)
+		categories = append(categories, spec.Category)
+		names = append(names, spec.Name)
+	}
+	return errors.Join(
+		unknownValues("country", f.Countries, countries),
+		unknownValues("category", f.Categories, categories),
+		unknownValues("tool", f.Allow, names),
+		unknownValues("tool", f.Deny, names),
+	)
+}
+
+// unknownValues returns an error naming the values that are not in known.
+func unknownValues(kind string, values, known []string) error {
+	var unknown []string
+	for _, v := range values {
+		if !slices.Contains(known, v) {
+			unknown = append(unknown, v)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	if kind == "tool" {
+		return fmt.Errorf("unknown %s %s", kind, strings.Join(unknown, ", "))
+	}
+	slices.Sort(known)
+	return fmt.Errorf("unknown %s %s (want one of %s)", kind, strings.Join(unknown, ", "), strings.Join(slices.Compact(known), ", "))
+}
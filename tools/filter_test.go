@@ -0,0 +1,121 @@
+package tools
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestToolFilter_Enabled(t *testing.T) {
+	norwaySearch := ToolSpec{Name: "norway_search_companies", Country: "norway", Category: "search"}
+	norwayRoles := ToolSpec{Name: "norway_get_roles", Country: "norway", Category: "roles"}
+	danishSearch := ToolSpec{Name: "denmark_search_companies", Country: "denmark", Category: "search"}
+
+	tests := []struct {
+		name   string
+		filter ToolFilter
+		spec   ToolSpec
+		want   bool
+	}{
+		{"zero value", ToolFilter{}, danishSearch, true},
+		{"country match", ToolFilter{Countries: []string{"norway"}}, norwayRoles, true},
+		{"country mismatch", ToolFilter{Countries: []string{"norway"}}, danishSearch, false},
+		{"category match", ToolFilter{Categories: []string{"search"}}, danishSearch, true},
+		{"category mismatch", ToolFilter{Categories: []string{"search"}}, norwayRoles, false},
+		{"country and category", ToolFilter{Countries: []string{"norway"}, Categories: []string{"search"}}, norwaySearch, true},
+		{"country but not category", ToolFilter{Countries: []string{"norway"}, Categories: []string{"search"}}, norwayRoles, false},
+		{"allowlist only", ToolFilter{Allow: []string{"norway_get_roles"}}, norwayRoles, true},
+		{"not on allowlist", ToolFilter{Allow: []string{"norway_get_roles"}}, norwaySearch, false},
+		{"allow adds to selectors", ToolFilter{Countries: []string{"denmark"}, Allow: []string{"norway_get_roles"}}, norwayRoles, true},
+		{"deny", ToolFilter{Deny: []string{"norway_get_roles"}}, norwayRoles, false},
+		{"deny wins over allow", ToolFilter{Allow: []string{"norway_get_roles"}, Deny: []string{"norway_get_roles"}}, norwayRoles, false},
+		{"deny leaves the rest", ToolFilter{Deny: []string{"norway_get_roles"}}, norwaySearch, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Enabled(tt.spec); got != tt.want {
+				t.Errorf("Enabled(%s) = %v, want %v", tt.spec.Name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToolFilter_Validate(t *testing.T) {
+	valid := ToolFilter{
+		Countries:  []string{"norway", "nordic"},
+		Categories: []string{"search", "read", "roles", "documents"},
+		Allow:      []string{"sweden_get_company"},
+		Deny:       []string{"norway_get_updates"},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+
+	invalid := ToolFilter{
+		Countries:  []string{"norway", "iceland"},
+		Categories: []string{"serach"},
+		Deny:       []string{"norway_get_company", "norway_delete_company"},
+	}
+	err := invalid.Validate()
+	if err == nil {
+		t.Fatal("Validate() = nil, want an error")
+	}
+	for _, want := range []string{"unknown country iceland", "unknown category serach", "unknown tool norway_delete_company"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() = %q, want it to contain %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "norway_get_company") {
+		t.Errorf("Validate() = %q, reported a known tool", err)
+	}
+}
+
+func TestRegistryFilter(t *testing.T) {
+	registry := newPromptTestRegistry(t, true)
+	registry.filter = ToolFilter{Countries: []string{"norway"}, Deny: []string{"norway_get_updates"}}
+
+	for _, spec := range registry.RegisteredTools() {
+		if spec.Country != "norway" || spec.Name == "norway_get_updates" {
+			t.Errorf("RegisteredTools() includes %s", spec.Name)
+		}
+	}
+	for _, spec := range registry.RegisteredResources() {
+		if spec.Country != "norway" {
+			t.Errorf("RegisteredResources() includes %s", spec.URI)
+		}
+	}
+	var prompts []string
+	for _, spec := range registry.RegisteredPrompts() {
+		prompts = append(prompts, spec.Name)
+	}
+	if !slices.Equal(prompts, []string{"board_check", "signing_authority"}) {
+		t.Errorf("RegisteredPrompts() = %v, want only the Norway-only prompts", prompts)
+	}
+
+	// tools/list must agree with RegisteredTools.
+	server := createTestMCPServer()
+	registry.RegisterAll(server)
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(context.Background(), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect server: %v", err)
+	}
+	defer func() { _ = serverSession.Close() }()
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect client: %v", err)
+	}
+	defer func() { _ = session.Close() }()
+
+	listed, err := session.ListTools(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListTools failed: %v", err)
+	}
+	if got, want := len(listed.Tools), len(registry.RegisteredTools()); got != want {
+		t.Errorf("tools/list returned %d tools, want %d", got, want)
+	}
+}
@@ -3,16 +3,23 @@ package tools
 import (
 	"context"
 	"crypto/rand"
+	"crypto/subtle"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"log/slog"
 	"runtime/debug"
+	"sync"
 	"time"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/admin"
 	"github.com/olgasafonova/nordic-registry-mcp-server/internal/denmark"
 	"github.com/olgasafonova/nordic-registry-mcp-server/internal/finland"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/lei"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/nordic"
 	"github.com/olgasafonova/nordic-registry-mcp-server/internal/norway"
 	"github.com/olgasafonova/nordic-registry-mcp-server/internal/sweden"
 	"github.com/olgasafonova/nordic-registry-mcp-server/metrics"
@@ -31,18 +38,41 @@ type HandlerRegistry struct {
 	denmarkClient *denmark.Client
 	finlandClient *finland.Client
 	swedenClient  *sweden.Client // May be nil if OAuth2 credentials not configured
+	nordicClient  *nordic.Client // May be nil; cross-registry tools are then skipped
+	leiIndex      *lei.Index     // May be nil; get_company results are then not LEI-enriched
+	adminConsole  *admin.Console // May be nil; admin tools are then skipped
+	adminToken    string         // Required as X-Admin-Token on admin tool calls over HTTP
+	filter        ToolFilter     // Tools and resources this deployment exposes
 	logger        *slog.Logger
 	handlers      map[string]registrationFunc // Method name -> registration function
+	resources     map[string]resourceFunc     // ResourceSpec.Method -> read function
+	prompts       map[string]registeredPrompt // PromptSpec.Method -> renderer
+	completers    map[string]completeFunc     // argumentCompletions method -> completer
+
+	// Resource subscriptions, see subscriptions.go
+	subsMu        sync.Mutex
+	subscriptions map[string]*watchedResource // Subscribed URI -> sessions and last content
+	feedCursor    time.Time                   // brreg update feed position
+	lastUpdateID  int                         // Last brreg update feed entry seen
 }
 
 // HandlerRegistryConfig bundles the per-country clients and the logger
 // supplied to NewHandlerRegistry. SwedenClient may be nil when Bolagsverket
-// OAuth credentials are not configured.
+// OAuth credentials are not configured; NordicClient may be nil to leave out
+// the cross-registry tools. LEIIndex, when set, adds LEI data to every
+// get_company result. Admin, when set, adds the admin_* tools; over HTTP
+// their calls must carry AdminToken in the X-Admin-Token header. Filter limits
+// the tools and resources exposed; its zero value exposes all of them.
 type HandlerRegistryConfig struct {
 	NorwayClient  *norway.Client
 	DenmarkClient *denmark.Client
 	FinlandClient *finland.Client
 	SwedenClient  *sweden.Client
+	NordicClient  *nordic.Client
+	LEIIndex      *lei.Index
+	Admin         *admin.Console
+	AdminToken    string
+	Filter        ToolFilter
 	Logger        *slog.Logger
 }
 
@@ -53,10 +83,19 @@ func NewHandlerRegistry(cfg HandlerRegistryConfig) *HandlerRegistry {
 		denmarkClient: cfg.DenmarkClient,
 		finlandClient: cfg.FinlandClient,
 		swedenClient:  cfg.SwedenClient,
+		nordicClient:  cfg.NordicClient,
+		leiIndex:      cfg.LEIIndex,
+		adminConsole:  cfg.Admin,
+		adminToken:    cfg.AdminToken,
+		filter:        cfg.Filter,
 		logger:        cfg.Logger,
 		handlers:      make(map[string]registrationFunc),
+		subscriptions: make(map[string]*watchedResource),
 	}
 	h.initHandlers()
+	h.initResources()
+	h.initPrompts()
+	h.initCompleters()
 	return h
 }
 
@@ -65,7 +104,7 @@ func NewHandlerRegistry(cfg HandlerRegistryConfig) *HandlerRegistry {
 func (h *HandlerRegistry) initHandlers() {
 	// Norway tools
 	h.handlers["SearchCompanies"] = makeHandler(h, h.norwayClient.SearchCompaniesMCP)
-	h.handlers["GetCompany"] = makeHandler(h, h.norwayClient.GetCompanyMCP)
+	h.handlers["GetCompany"] = makeHandler(h, withLEI(h.leiIndex, h.norwayClient.GetCompanyMCP))
 	h.handlers["GetRoles"] = makeHandler(h, h.norwayClient.GetRolesMCP)
 	h.handlers["GetSubUnits"] = makeHandler(h, h.norwayClient.GetSubUnitsMCP)
 	h.handlers["GetSubUnit"] = makeHandler(h, h.norwayClient.GetSubUnitMCP)
@@ -79,21 +118,40 @@ func (h *HandlerRegistry) initHandlers() {
 
 	// Denmark tools
 	h.handlers["DKSearchCompanies"] = makeHandler(h, h.denmarkClient.SearchCompaniesMCP)
-	h.handlers["DKGetCompany"] = makeHandler(h, h.denmarkClient.GetCompanyMCP)
+	h.handlers["DKGetCompany"] = makeHandler(h, withLEI(h.leiIndex, h.denmarkClient.GetCompanyMCP))
 	h.handlers["DKGetProductionUnits"] = makeHandler(h, h.denmarkClient.GetProductionUnitsMCP)
 	h.handlers["DKSearchByPhone"] = makeHandler(h, h.denmarkClient.SearchByPhoneMCP)
 	h.handlers["DKGetByPNumber"] = makeHandler(h, h.denmarkClient.GetByPNumberMCP)
+	h.handlers["DKBatchGetCompanies"] = makeHandler(h, h.denmarkClient.BatchGetCompaniesMCP)
 
 	// Finland tools
 	h.handlers["FISearchCompanies"] = makeHandler(h, h.finlandClient.SearchCompaniesMCP)
-	h.handlers["FIGetCompany"] = makeHandler(h, h.finlandClient.GetCompanyMCP)
+	h.handlers["FIGetCompany"] = makeHandler(h, withLEI(h.leiIndex, h.finlandClient.GetCompanyMCP))
+	h.handlers["FIBatchGetCompanies"] = makeHandler(h, h.finlandClient.BatchGetCompaniesMCP)
 
 	// Sweden tools (only if client configured)
 	if h.swedenClient != nil {
-		h.handlers["SEGetCompany"] = makeHandler(h, h.swedenClient.GetCompanyMCP)
+		h.handlers["SEGetCompany"] = makeHandler(h, withLEI(h.leiIndex, h.swedenClient.GetCompanyMCP))
 		h.handlers["SEGetDocumentList"] = makeHandler(h, h.swedenClient.GetDocumentListMCP)
 		h.handlers["SECheckStatus"] = makeHandler(h, h.swedenClient.CheckStatusMCP)
 		h.handlers["SEDownloadDocument"] = makeHandler(h, h.swedenClient.DownloadDocumentMCP)
+		h.handlers["SEBatchGetCompanies"] = makeHandler(h, h.swedenClient.BatchGetCompaniesMCP)
+	}
+
+	// Cross-registry tools (only if client configured)
+	if h.nordicClient != nil {
+		h.handlers["NordicValidateIdentifiers"] = makeHandler(h, h.nordicClient.ValidateIdentifiersMCP)
+		h.handlers["NordicCheckVAT"] = makeHandler(h, h.nordicClient.CheckVATMCP)
+		h.handlers["NordicLookupIndustryCode"] = makeHandler(h, h.nordicClient.LookupIndustryCodeMCP)
+		h.handlers["NordicListLegalForms"] = makeHandler(h, h.nordicClient.ListLegalFormsMCP)
+	}
+
+	// Admin tools (only if enabled)
+	if h.adminConsole != nil {
+		h.handlers["AdminCacheStats"] = makeHandler(h, h.adminConsole.CacheStatsMCP)
+		h.handlers["AdminPurgeCache"] = makeHandler(h, h.adminConsole.PurgeCacheMCP)
+		h.handlers["AdminCircuitStatus"] = makeHandler(h, h.adminConsole.CircuitStatusMCP)
+		h.handlers["AdminSetCircuit"] = makeHandler(h, h.adminConsole.SetCircuitMCP)
 	}
 }
 
@@ -105,15 +163,38 @@ func makeHandler[Args, Result any](h *HandlerRegistry, method func(context.Conte
 	}
 }
 
-// RegisterAll registers all tools with the MCP server.
+// withLEI wraps a get_company method so successful results carry the
+// company's LEI entity from idx. With no index the method is returned as is.
+func withLEI[Args, Result any, PR interface {
+	*Result
+	lei.Enrichable
+}](idx *lei.Index, method func(context.Context, Args) (Result, error)) func(context.Context, Args) (Result, error) {
+	if idx == nil {
+		return method
+	}
+	return func(ctx context.Context, args Args) (Result, error) {
+		res, err := method(ctx, args)
+		if err == nil {
+			idx.Enrich(PR(&res))
+		}
+		return res, err
+	}
+}
+
+// RegisterAll registers all tools, resources and prompts with the MCP server.
 func (h *HandlerRegistry) RegisterAll(server *mcp.Server) {
 	registered := 0
 	for _, spec := range AllTools {
+		if !h.filter.Enabled(spec) {
+			continue
+		}
 		if h.registerTool(server, spec) {
 			registered++
 		}
 	}
 	h.logger.Info("Registered all tools", "count", registered)
+	h.logger.Info("Registered all resources", "count", h.registerResources(server))
+	h.logger.Info("Registered all prompts", "count", h.registerPrompts(server))
 }
 
 // registerTool uses the pre-built handler map for O(1) dispatch.
@@ -129,12 +210,19 @@ func (h *HandlerRegistry) registerTool(server *mcp.Server, spec ToolSpec) bool {
 	return true
 }
 
-// RegisteredTools returns only the tools that have registered handlers.
-// Use this for discovery endpoints to avoid showing unavailable tools.
+// toolEnabled reports whether spec has a handler and passes the filter.
+func (h *HandlerRegistry) toolEnabled(spec ToolSpec) bool {
+	_, ok := h.handlers[spec.Method]
+	return ok && h.filter.Enabled(spec)
+}
+
+// RegisteredTools returns only the tools that have registered handlers and
+// pass the filter. Use this for discovery endpoints to avoid showing
+// unavailable tools.
 func (h *HandlerRegistry) RegisteredTools() []ToolSpec {
 	registered := make([]ToolSpec, 0, len(AllTools))
 	for _, spec := range AllTools {
-		if _, ok := h.handlers[spec.Method]; ok {
+		if h.toolEnabled(spec) {
 			registered = append(registered, spec)
 		}
 	}
@@ -162,14 +250,20 @@ func (h *HandlerRegistry) buildTool(spec ToolSpec) *mcp.Tool {
 	}
 }
 
-// ToolTimeout is the maximum time allowed for a tool to complete
+// ToolTimeout is the maximum time allowed for a tool to complete, unless its
+// ToolSpec sets a Timeout
 const ToolTimeout = 30 * time.Second
 
+// progressInterval is the minimum gap between two progress notifications for
+// one call; a 5000-identifier validation would otherwise send 5000.
+const progressInterval = 250 * time.Millisecond
+
 // headerAnnotatedSchema infers the input schema for Args and attaches the
 // spec's x-mcp-header annotations (SEP-2243 per-parameter header
 // passthrough). The SDK only infers a schema when the tool does not provide
 // one, and jsonschema struct tags cannot carry arbitrary keywords, so tools
 // with HeaderParams pre-build their schema here and hand it to AddTool.
+// Without HeaderParams the schema is what AddTool would infer.
 //
 // It panics on an unknown or non-primitive property because the SDK's
 // annotation extraction silently skips malformed annotations — a wrong shape
@@ -198,6 +292,23 @@ func headerAnnotatedSchema[Args any](spec ToolSpec) *jsonschema.Schema {
 	return schema
 }
 
+// resultSchema infers the output schema for Result, with the jsonschema tag
+// of each field as its description. mcp.AddTool would infer the same schema
+// when the tool has none; building it here, as headerAnnotatedSchema does for
+// inputs, makes the output contract part of the tool definition and fails
+// registration loudly on a Result that cannot be described. MCP requires
+// structured content to be a JSON object, so Result must be a struct.
+func resultSchema[Result any](spec ToolSpec) *jsonschema.Schema {
+	schema, err := jsonschema.For[Result](nil)
+	if err != nil {
+		panic(fmt.Sprintf("tool %s: inferring output schema: %v", spec.Name, err))
+	}
+	if schema.Type != "object" {
+		panic(fmt.Sprintf("tool %s: output schema has type %q; structured content must be an object", spec.Name, schema.Type))
+	}
+	return schema
+}
+
 // register is a generic helper that registers a tool with the MCP server.
 // It wraps the client method with panic recovery, metrics, tracing, and logging.
 //
@@ -213,16 +324,51 @@ func register[Args, Result any](
 	spec ToolSpec,
 	method func(context.Context, Args) (Result, error),
 ) {
-	if len(spec.HeaderParams) > 0 {
-		tool.InputSchema = headerAnnotatedSchema[Args](spec)
-	}
+	// Both schemas are built here rather than left to mcp.AddTool, so the
+	// tool definition carries them; docs.go renders them into docs/API.md.
+	tool.InputSchema = headerAnnotatedSchema[Args](spec)
+	tool.OutputSchema = resultSchema[Result](spec)
+	_, carriesFreshness := any(new(Result)).(freshnessSetter)
 	mcp.AddTool(server, tool, func(ctx context.Context, req *mcp.CallToolRequest, args Args) (res *mcp.CallToolResult, out Result, err error) {
 		defer h.recoverPanic(spec.Name, &err)
 
-		// Add timeout to prevent hanging requests
-		ctx, cancel := context.WithTimeout(ctx, ToolTimeout)
+		if spec.Category == "admin" && !h.adminAuthorized(req) {
+			var zero Result
+			return nil, zero, fmt.Errorf("%s failed: %w", spec.Name, errAdminToken)
+		}
+
+		// Add timeout to prevent hanging requests. Cancellation by the client
+		// (notifications/cancelled) cancels ctx as well.
+		timeout := spec.timeout()
+		ctx, cancel := context.WithTimeout(ctx, timeout)
 		defer cancel()
 
+		if req != nil && req.Params != nil && req.Session != nil {
+			if token := req.Params.GetProgressToken(); token != nil {
+				ctx = infra.WithProgress(ctx, h.progressNotifier(ctx, req.Session, token))
+			}
+		}
+		if spec.Elicits && req != nil && req.Params != nil && canElicit(req.Session) {
+			ctx = infra.WithAsk(ctx, askFunc(req.Params.InputResponses))
+		}
+		// Tools with a cache argument let the caller skip the cache or the
+		// registry.
+		if cc, ok := any(args).(cacheController); ok {
+			mode, modeErr := cc.CacheMode()
+			if modeErr != nil {
+				var zero Result
+				return nil, zero, fmt.Errorf("%s failed: %w", spec.Name, modeErr)
+			}
+			ctx = infra.WithCacheMode(ctx, mode)
+		}
+		// Results that carry Freshness say when their data was fetched, and
+		// may be answered from a stale cache entry when the registry cannot
+		// be reached.
+		var provenance *infra.Provenance
+		if carriesFreshness {
+			ctx, provenance = infra.TrackProvenance(ctx)
+		}
+
 		// Start trace span
 		ctx, span := tracing.StartSpan(ctx, "mcp.tool."+spec.Name)
 		defer span.End()
@@ -244,14 +390,35 @@ func register[Args, Result any](
 
 		span.SetAttributes(attribute.Float64("mcp.tool.duration_seconds", duration))
 
+		var inputRequired *inputRequiredError
+		if errors.As(methodErr, &inputRequired) {
+			// Not a failure: the call runs again once the user has answered.
+			span.SetAttributes(attribute.Bool("mcp.tool.input_required", true))
+			var zero Result
+			return &mcp.CallToolResult{InputRequests: inputRequired.requests}, zero, nil
+		}
+
 		if methodErr != nil {
 			span.RecordError(methodErr)
 			span.SetStatus(codes.Error, methodErr.Error())
 			metrics.RecordRequest(spec.Name, duration, false)
 			var zero Result
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, zero, fmt.Errorf("%s timed out after %s: %w", spec.Name, timeout, methodErr)
+			}
 			return nil, zero, fmt.Errorf("%s failed: %w", spec.Name, methodErr)
 		}
 
+		if provenance != nil {
+			if freshness, ok := provenance.Freshness(); ok {
+				any(&result).(freshnessSetter).SetFreshness(freshness)
+				span.SetAttributes(
+					attribute.Bool("mcp.tool.cache_hit", freshness.CacheHit),
+					attribute.Bool("mcp.tool.stale", freshness.Stale),
+				)
+			}
+		}
+
 		span.SetStatus(codes.Ok, "")
 		metrics.RecordRequest(spec.Name, duration, true)
 		h.logExecution(spec, args, result)
@@ -259,6 +426,55 @@ func register[Args, Result any](
 	})
 }
 
+// errAdminToken is returned by admin tools called over HTTP without the
+// admin token.
+var errAdminToken = errors.New("admin tools require a valid X-Admin-Token header")
+
+// adminAuthorized reports whether req may call an admin tool. Calls over
+// HTTP must carry the admin token in X-Admin-Token, separate from the bearer
+// token that admits them to the server; calls over stdio come from the
+// operator who started the server and need none.
+func (h *HandlerRegistry) adminAuthorized(req *mcp.CallToolRequest) bool {
+	if req == nil || req.Extra == nil || req.Extra.Header == nil {
+		return true
+	}
+	token := req.Extra.Header.Get("X-Admin-Token")
+	return h.adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(h.adminToken)) == 1
+}
+
+// progressNotifier returns an infra.ProgressFunc that forwards progress to
+// the client as notifications/progress for token. MCP requires progress to
+// increase with every notification, so reports that arrive out of order from
+// concurrent workers are dropped; reports closer together than
+// progressInterval are dropped too, except the final one.
+func (h *HandlerRegistry) progressNotifier(ctx context.Context, session *mcp.ServerSession, token any) infra.ProgressFunc {
+	var (
+		mu   sync.Mutex
+		last int64
+		sent time.Time
+	)
+	return func(done, total int64, message string) {
+		mu.Lock()
+		defer mu.Unlock()
+		if done <= last {
+			return
+		}
+		if done != total && time.Since(sent) < progressInterval {
+			return
+		}
+		last, sent = done, time.Now()
+		err := session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+			ProgressToken: token,
+			Progress:      float64(done),
+			Total:         float64(total),
+			Message:       message,
+		})
+		if err != nil {
+			h.logger.Debug("Progress notification failed", "error", err)
+		}
+	}
+}
+
 // recoverPanic recovers from panics in tool handlers and converts them into a
 // structured error with a correlation ID. The panic value and stack are logged
 // server-side; only the correlation ID reaches the MCP caller.
@@ -303,6 +519,18 @@ func (h *HandlerRegistry) logExecution(spec ToolSpec, args, result any) {
 	h.logger.Info("Tool executed", attrs...)
 }
 
+// freshnessSetter is implemented by result types that embed infra.Freshness
+// and so can say when their data was fetched and whether it came from the
+// cache.
+type freshnessSetter interface {
+	SetFreshness(infra.Freshness)
+}
+
+// cacheController is implemented by arg types that embed infra.CacheControl.
+type cacheController interface {
+	CacheMode() (infra.CacheMode, error)
+}
+
 // logAttrsProvider is implemented by arg and result types that expose the
 // structured-log attributes for the "Tool executed" log line. Each country
 // package owns its types' attribute shapes (matches the per-country
@@ -7,13 +7,18 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/admin"
 	"github.com/olgasafonova/nordic-registry-mcp-server/internal/denmark"
 	"github.com/olgasafonova/nordic-registry-mcp-server/internal/finland"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/lei"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/nordic"
 	"github.com/olgasafonova/nordic-registry-mcp-server/internal/norway"
 	"github.com/olgasafonova/nordic-registry-mcp-server/internal/sweden"
 )
@@ -251,14 +256,27 @@ func TestToolSpecMethods(t *testing.T) {
 		"DKGetProductionUnits": true,
 		"DKSearchByPhone":      true,
 		"DKGetByPNumber":       true,
+		"DKBatchGetCompanies":  true,
 		// Finland tools
-		"FISearchCompanies": true,
-		"FIGetCompany":      true,
+		"FISearchCompanies":   true,
+		"FIGetCompany":        true,
+		"FIBatchGetCompanies": true,
 		// Sweden tools
-		"SEGetCompany":       true,
-		"SEGetDocumentList":  true,
-		"SEDownloadDocument": true,
-		"SECheckStatus":      true,
+		"SEGetCompany":        true,
+		"SEGetDocumentList":   true,
+		"SEDownloadDocument":  true,
+		"SECheckStatus":       true,
+		"SEBatchGetCompanies": true,
+		// Cross-registry tools
+		"NordicValidateIdentifiers": true,
+		"NordicCheckVAT":            true,
+		"NordicLookupIndustryCode":  true,
+		"NordicListLegalForms":      true,
+		// Admin tools
+		"AdminCacheStats":    true,
+		"AdminPurgeCache":    true,
+		"AdminCircuitStatus": true,
+		"AdminSetCircuit":    true,
 	}
 
 	for _, spec := range AllTools {
@@ -333,8 +351,8 @@ func TestRegisteredTools(t *testing.T) {
 
 		registeredTools := registry.RegisteredTools()
 
-		// Should have Norway (12) + Denmark (5) + Finland (2) = 19 tools
-		expectedCount := 19
+		// Should have Norway (12) + Denmark (6) + Finland (3) = 21 tools
+		expectedCount := 21
 		if len(registeredTools) != expectedCount {
 			t.Errorf("Expected %d registered tools without Sweden, got %d", expectedCount, len(registeredTools))
 		}
@@ -389,8 +407,8 @@ func TestRegisteredTools(t *testing.T) {
 		registry := NewHandlerRegistry(HandlerRegistryConfig{NorwayClient: noClient, DenmarkClient: dkClient, FinlandClient: fiClient, SwedenClient: seClient, Logger: logger})
 		registeredTools := registry.RegisteredTools()
 
-		// Should have Norway (12) + Denmark (5) + Finland (2) + Sweden (4) = 23 tools
-		expectedCount := 23
+		// Should have Norway (12) + Denmark (6) + Finland (3) + Sweden (5) = 26 tools
+		expectedCount := 26
 		if len(registeredTools) != expectedCount {
 			t.Errorf("Expected %d registered tools with Sweden, got %d", expectedCount, len(registeredTools))
 		}
@@ -402,8 +420,20 @@ func TestRegisteredTools(t *testing.T) {
 				swedenTools++
 			}
 		}
-		if swedenTools != 4 {
-			t.Errorf("Expected 4 Sweden tools, got %d", swedenTools)
+		if swedenTools != 5 {
+			t.Errorf("Expected 5 Sweden tools, got %d", swedenTools)
+		}
+	})
+
+	t.Run("with Nordic client", func(t *testing.T) {
+		nordicClient := nordic.NewClient(nordic.Config{Norway: noClient, Denmark: dkClient, Finland: fiClient, Logger: logger})
+		registry := NewHandlerRegistry(HandlerRegistryConfig{NorwayClient: noClient, DenmarkClient: dkClient, FinlandClient: fiClient, NordicClient: nordicClient, Logger: logger})
+		registeredTools := registry.RegisteredTools()
+
+		// Should have Norway (12) + Denmark (6) + Finland (3) + Nordic (4) = 25 tools
+		expectedCount := 25
+		if len(registeredTools) != expectedCount {
+			t.Errorf("Expected %d registered tools with Nordic, got %d", expectedCount, len(registeredTools))
 		}
 	})
 }
@@ -865,6 +895,233 @@ func TestToolInvocation_Success(t *testing.T) {
 	})
 }
 
+// TestToolInvocation_LEIEnrichment tests that get_company results carry the
+// LEI from a configured golden-copy index.
+func TestToolInvocation_LEIEnrichment(t *testing.T) {
+	mockServer := createMockNorwayServer()
+	defer mockServer.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	noClient := norway.NewClient(norway.WithLogger(logger), norway.WithBaseURL(mockServer.URL))
+	defer noClient.Close()
+	dkClient := denmark.NewClient(denmark.WithLogger(logger))
+	defer dkClient.Close()
+	fiClient := finland.NewClient(finland.WithLogger(logger))
+	defer fiClient.Close()
+
+	goldenCopy := filepath.Join(t.TempDir(), "lei.csv")
+	csv := "LEI,Entity.LegalName,Entity.LegalJurisdiction,Entity.EntityStatus,Entity.RegistrationAuthority.RegistrationAuthorityEntityID,Registration.RegistrationStatus\n" +
+		"OW6OFBNCKXC4US5C7523,EQUINOR ASA,NO,ACTIVE,923609016,ISSUED\n"
+	if err := os.WriteFile(goldenCopy, []byte(csv), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	index, err := lei.Load(goldenCopy, "", lei.NordicJurisdictions...)
+	if err != nil {
+		t.Fatalf("lei.Load: %v", err)
+	}
+
+	registry := NewHandlerRegistry(HandlerRegistryConfig{NorwayClient: noClient, DenmarkClient: dkClient, FinlandClient: fiClient, LEIIndex: index, Logger: logger})
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "1.0.0"}, nil)
+	registry.RegisterAll(server)
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(context.Background(), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect server: %v", err)
+	}
+	defer serverSession.Close()
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	clientSession, err := client.Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect client: %v", err)
+	}
+	defer clientSession.Close()
+
+	result, err := clientSession.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "norway_get_company",
+		Arguments: map[string]any{"org_number": "923609016"},
+	})
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	raw, err := json.Marshal(result.StructuredContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got norway.GetCompanyResult
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("decoding result: %v", err)
+	}
+	if got.LEI == nil || got.LEI.LEI != "OW6OFBNCKXC4US5C7523" || got.LEI.Status != "ISSUED" {
+		t.Errorf("LEI = %+v, want Equinor's issued LEI", got.LEI)
+	}
+}
+
+func TestToolInvocation_StaleOnOpenCircuit(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	noClient := norway.NewClient(norway.WithLogger(logger), norway.WithBaseURL("http://127.0.0.1:0"))
+	defer noClient.Close()
+	dkClient := denmark.NewClient(denmark.WithLogger(logger))
+	defer dkClient.Close()
+	fiClient := finland.NewClient(finland.WithLogger(logger))
+	defer fiClient.Close()
+
+	// An entry that went out of date 20 minutes ago, and a registry that is
+	// down.
+	company := &norway.Company{OrganizationNumber: "923609016", Name: "EQUINOR ASA"}
+	noClient.Cache.SetWithStale("company:923609016", company, -20*time.Minute, time.Hour)
+	for range 5 {
+		noClient.RecordFailure()
+	}
+
+	registry := NewHandlerRegistry(HandlerRegistryConfig{NorwayClient: noClient, DenmarkClient: dkClient, FinlandClient: fiClient, Logger: logger})
+	server := createTestMCPServer()
+	registry.RegisterAll(server)
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(context.Background(), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect server: %v", err)
+	}
+	defer serverSession.Close()
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	clientSession, err := client.Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect client: %v", err)
+	}
+	defer clientSession.Close()
+
+	result, err := clientSession.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "norway_get_company",
+		Arguments: map[string]any{"org_number": "923609016"},
+	})
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("tool error: %v", result.Content)
+	}
+	raw, err := json.Marshal(result.StructuredContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got norway.GetCompanyResult
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("decoding result: %v", err)
+	}
+	if got.Summary == nil || got.Summary.Name != "EQUINOR ASA" {
+		t.Errorf("summary = %+v, want the cached company", got.Summary)
+	}
+	if !got.Stale || got.FetchedAt == "" {
+		t.Errorf("stale = %v, fetched_at = %q; want a stale answer with its fetch time", got.Stale, got.FetchedAt)
+	}
+
+	// Results that cannot say they are stale get the error instead.
+	noClient.Cache.SetWithStale("roles:923609016", &norway.RolesResponse{}, -20*time.Minute, time.Hour)
+	result, err = clientSession.CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "norway_get_roles",
+		Arguments: map[string]any{"org_number": "923609016"},
+	})
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if !result.IsError {
+		t.Error("norway_get_roles answered from a stale entry it cannot mark")
+	}
+}
+
+// TestToolInvocation_CacheArgument tests that the cache argument of a read
+// tool decides whether the registry is asked, and that the result says so.
+func TestToolInvocation_CacheArgument(t *testing.T) {
+	var requests atomic.Int32
+	mock := createMockNorwayServer()
+	defer mock.Close()
+	counting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		mock.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer counting.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	noClient := norway.NewClient(norway.WithLogger(logger), norway.WithBaseURL(counting.URL))
+	defer noClient.Close()
+	dkClient := denmark.NewClient(denmark.WithLogger(logger))
+	defer dkClient.Close()
+	fiClient := finland.NewClient(finland.WithLogger(logger))
+	defer fiClient.Close()
+
+	registry := NewHandlerRegistry(HandlerRegistryConfig{NorwayClient: noClient, DenmarkClient: dkClient, FinlandClient: fiClient, Logger: logger})
+	server := createTestMCPServer()
+	registry.RegisterAll(server)
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(context.Background(), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect server: %v", err)
+	}
+	defer serverSession.Close()
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	clientSession, err := client.Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect client: %v", err)
+	}
+	defer clientSession.Close()
+
+	getCompany := func(orgNumber, cache string) (*mcp.CallToolResult, norway.GetCompanyResult) {
+		t.Helper()
+		args := map[string]any{"org_number": orgNumber}
+		if cache != "" {
+			args["cache"] = cache
+		}
+		result, err := clientSession.CallTool(context.Background(), &mcp.CallToolParams{Name: "norway_get_company", Arguments: args})
+		if err != nil {
+			t.Fatalf("CallTool failed: %v", err)
+		}
+		var got norway.GetCompanyResult
+		if !result.IsError {
+			raw, _ := json.Marshal(result.StructuredContent)
+			if err := json.Unmarshal(raw, &got); err != nil {
+				t.Fatalf("decoding result: %v", err)
+			}
+		}
+		return result, got
+	}
+
+	tests := []struct {
+		cache        string
+		wantCacheHit bool
+		wantRequests int32
+	}{
+		{"", false, 1},
+		{"default", true, 1},
+		{"only-if-cached", true, 1},
+		{"no-cache", false, 2},
+	}
+	for _, tt := range tests {
+		result, got := getCompany("923609016", tt.cache)
+		if result.IsError {
+			t.Fatalf("cache=%q: tool error: %v", tt.cache, result.Content)
+		}
+		if got.CacheHit != tt.wantCacheHit || got.FetchedAt == "" {
+			t.Errorf("cache=%q: cache_hit = %v, fetched_at = %q; want %v with a fetch time", tt.cache, got.CacheHit, got.FetchedAt, tt.wantCacheHit)
+		}
+		if n := requests.Load(); n != tt.wantRequests {
+			t.Errorf("cache=%q: %d registry requests so far, want %d", tt.cache, n, tt.wantRequests)
+		}
+	}
+
+	// Nothing cached and the registry not to be asked, or a mode that does
+	// not exist: both are errors.
+	for _, cache := range []string{"only-if-cached", "no-store"} {
+		if result, _ := getCompany("974760673", cache); !result.IsError {
+			t.Errorf("cache=%q on an uncached company succeeded", cache)
+		}
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("%d registry requests, want 2", n)
+	}
+}
+
 // TestToolInvocation_Error tests error handling in tool invocation
 func TestToolInvocation_Error(t *testing.T) {
 	// Create mock server that returns errors
@@ -992,3 +1249,78 @@ func TestToolInvocation_ListTools(t *testing.T) {
 		}
 	}
 }
+
+// headerTransport adds a fixed header to every request.
+type headerTransport struct {
+	name, value string
+}
+
+func (t headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	if t.value != "" {
+		req.Header.Set(t.name, t.value)
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// TestToolInvocation_AdminToken tests that admin tools called over HTTP
+// need the admin token, and that they act on the client's circuit breaker.
+func TestToolInvocation_AdminToken(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	noClient := norway.NewClient(norway.WithLogger(logger))
+	defer noClient.Close()
+	dkClient := denmark.NewClient(denmark.WithLogger(logger))
+	defer dkClient.Close()
+	fiClient := finland.NewClient(finland.WithLogger(logger))
+	defer fiClient.Close()
+
+	registry := NewHandlerRegistry(HandlerRegistryConfig{
+		NorwayClient:  noClient,
+		DenmarkClient: dkClient,
+		FinlandClient: fiClient,
+		Admin:         admin.NewConsole(admin.Target{Registry: "norway", Cache: noClient.Cache, Breaker: noClient.CircuitBreaker, Invalidate: noClient.InvalidateCompany}),
+		AdminToken:    "admin-secret",
+		Logger:        logger,
+	})
+	server := createTestMCPServer()
+	registry.RegisterAll(server)
+	httpServer := httptest.NewServer(mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return server }, nil))
+	defer httpServer.Close()
+
+	for _, tc := range []struct {
+		name, token string
+		wantOpen    bool
+	}{
+		{"no token", "", false},
+		{"wrong token", "guess", false},
+		{"admin token", "admin-secret", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			noClient.CircuitBreaker.Reset()
+			client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+			transport := &mcp.StreamableClientTransport{
+				Endpoint:   httpServer.URL,
+				HTTPClient: &http.Client{Transport: headerTransport{"X-Admin-Token", tc.token}},
+			}
+			session, err := client.Connect(context.Background(), transport, nil)
+			if err != nil {
+				t.Fatalf("Failed to connect client: %v", err)
+			}
+			defer session.Close()
+
+			result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+				Name:      "admin_set_circuit",
+				Arguments: map[string]any{"registry": "norway", "action": "open"},
+			})
+			if err != nil {
+				t.Fatalf("CallTool failed: %v", err)
+			}
+			if result.IsError == tc.wantOpen {
+				t.Errorf("IsError = %v, want %v: %v", result.IsError, !tc.wantOpen, result.Content)
+			}
+			if got := noClient.CircuitBreaker.Stats().Forced; got != tc.wantOpen {
+				t.Errorf("circuit forced open = %v, want %v", got, tc.wantOpen)
+			}
+		})
+	}
+}
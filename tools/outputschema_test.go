@@ -0,0 +1,391 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/admin"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/denmark"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/finland"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/nordic"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/norway"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/sweden"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/vies"
+)
+
+// Registry fixtures in each API's wire format, rich enough that the optional
+// result fields (NACE, legal form class, addresses, roles) are populated.
+const (
+	fixtureNorwayCompany = `{
+		"organisasjonsnummer": "923609016",
+		"navn": "EQUINOR ASA",
+		"organisasjonsform": {"kode": "ASA", "beskrivelse": "Allmennaksjeselskap"},
+		"registreringsdatoEnhetsregisteret": "1995-03-12",
+		"forretningsadresse": {"adresse": ["Forusbeen 50"], "postnummer": "4035", "poststed": "STAVANGER", "kommunenummer": "1103", "land": "Norge"},
+		"postadresse": {"adresse": ["Postboks 8500 Forus"], "postnummer": "4035", "poststed": "STAVANGER"},
+		"naeringskode1": {"kode": "06.100", "beskrivelse": "Utvinning av råolje"},
+		"antallAnsatte": 21000,
+		"hjemmeside": "www.equinor.com",
+		"registrertIMvaregisteret": true,
+		"kapital": {"belop": 7971617757.5, "valuta": "NOK", "antallAksjer": 3185000000},
+		"_links": {"self": {"href": "https://data.brreg.no/enhetsregisteret/api/enheter/923609016"}}
+	}`
+	fixtureNorwaySubUnit = `{
+		"organisasjonsnummer": "973152351",
+		"navn": "EQUINOR ASA AVD FORUS",
+		"overordnetEnhet": "923609016",
+		"organisasjonsform": {"kode": "BEDR", "beskrivelse": "Underenhet til næringsdrivende og offentlig forvaltning"},
+		"beliggenhetsadresse": {"adresse": ["Forusbeen 50"], "postnummer": "4035", "poststed": "STAVANGER"},
+		"naeringskode1": {"kode": "06.100", "beskrivelse": "Utvinning av råolje"},
+		"antallAnsatte": 3500
+	}`
+	fixtureNorwayRoles = `{"rollegrupper": [
+		{"type": {"kode": "STYR", "beskrivelse": "Styre"}, "sistEndret": "2024-05-15", "roller": [
+			{"type": {"kode": "LEDE", "beskrivelse": "Styrets leder"}, "person": {"navn": {"fornavn": "Jon Erik", "etternavn": "Reinhardsen"}, "fodselsdato": "1956-06-12", "erDod": false}, "fratraadt": false},
+			{"type": {"kode": "MEDL", "beskrivelse": "Styremedlem"}, "person": {"navn": {"fornavn": "Anne", "etternavn": "Drinkwater"}, "fodselsdato": "1956-11-23", "erDod": false}, "fratraadt": false}
+		]},
+		{"type": {"kode": "SIGN", "beskrivelse": "Signatur"}, "roller": [
+			{"type": {"kode": "SIGN", "beskrivelse": "Signatur"}, "person": {"navn": {"fornavn": "Anders", "etternavn": "Opedal"}, "fodselsdato": "1968-02-12", "erDod": false}, "fratraadt": false}
+		]},
+		{"type": {"kode": "PROK", "beskrivelse": "Prokura"}, "roller": [
+			{"type": {"kode": "PROK", "beskrivelse": "Prokura"}, "enhet": {"organisasjonsnummer": "974760673", "navn": ["REGISTERENHETEN I BRØNNØYSUND"]}, "fratraadt": false}
+		]},
+		{"type": {"kode": "REVI", "beskrivelse": "Revisor"}, "roller": [
+			{"type": {"kode": "REVI", "beskrivelse": "Revisor"}, "enhet": {"organisasjonsnummer": "987009713", "navn": ["ERNST & YOUNG AS"]}, "fratraadt": false}
+		]}
+	]}`
+	fixtureDenmarkCompany = `{
+		"vat": 10150817, "name": "CARLSBERG A/S", "address": "J.C. Jacobsens Gade 1", "zipcode": "1799", "city": "København V",
+		"country": "DK", "phone": "33273300", "email": "info@carlsberg.com", "startdate": "01/01 - 1987",
+		"employees": 1000, "companydesc": "Aktieselskab", "industrycode": 701000, "industrydesc": "Hovedsæders virksomhed",
+		"creditbankrupt": false,
+		"productionunits": [
+			{"pno": 1003388394, "main": true, "name": "CARLSBERG A/S", "address": "J.C. Jacobsens Gade 1", "zipcode": "1799", "city": "København V", "employees": "500-999", "industrycode": 701000, "industrydesc": "Hovedsæders virksomhed"},
+			{"pno": 1017396455, "main": false, "name": "CARLSBERG FREDERICIA", "zipcode": "7000", "city": "Fredericia", "employees": null}
+		],
+		"owners": [{"name": "Carlsbergfondet"}]
+	}`
+	fixtureFinlandCompanies = `{"totalResults": 1, "companies": [{
+		"businessId": {"value": "0112038-9", "registrationDate": "1978-03-15"},
+		"euId": {"value": "FIFPRO.0112038-9"},
+		"names": [
+			{"name": "Nokia Oyj", "type": "1", "registrationDate": "1997-09-01"},
+			{"name": "Oy Nokia Ab", "type": "2", "registrationDate": "1967-01-01", "endDate": "1997-08-31"},
+			{"name": "Nokia Networks", "type": "3", "registrationDate": "2000-01-01"}
+		],
+		"mainBusinessLine": {"type": "70100", "descriptions": [{"languageCode": "1", "description": "Pääkonttorien toiminta"}, {"languageCode": "3", "description": "Activities of head offices"}]},
+		"website": {"url": "www.nokia.com"},
+		"companyForms": [{"type": "OYJ", "descriptions": [{"languageCode": "3", "description": "Public limited company"}], "registrationDate": "1997-09-01"}],
+		"companySituations": [{"type": "SANE", "registrationDate": "2020-01-01", "endDate": "2020-06-01"}],
+		"registeredEntries": [{"registrationStatus": "1", "registerDescriptions": [{"languageCode": "3", "description": "Trade Register"}], "authorityDescriptions": [{"languageCode": "3", "description": "Finnish Patent and Registration Office"}], "registrationDate": "1978-03-15"}],
+		"addresses": [
+			{"type": 1, "street": "Karakaari", "buildingNumber": "7", "postCode": "02610", "postOffices": [{"city": "ESPOO", "languageCode": "1"}]},
+			{"type": 2, "postOfficeBox": "226", "postCode": "00045", "postOffices": [{"city": "NOKIA GROUP", "languageCode": "1"}]}
+		],
+		"tradeRegisterStatus": "1", "status": "2", "registrationDate": "1978-03-15", "lastModified": "2024-06-01T10:00:00"
+	}]}`
+	fixtureSwedenCompanies = `{"organisationer": [{
+		"organisationsidentitet": {"identitetsbeteckning": "5560125790", "typ": {"kod": "ORGNO", "klartext": "Organisationsnummer"}},
+		"organisationsnamn": {"organisationsnamnLista": [{"namn": "AKTIEBOLAGET VOLVO", "organisationsnamntyp": {"kod": "FORETAGSNAMN", "klartext": "Företagsnamn"}}]},
+		"organisationsform": {"kod": "AB", "klartext": "Aktiebolag"},
+		"juridiskForm": {"kod": "49", "klartext": "Övriga aktiebolag"},
+		"verksamOrganisation": {"kod": "JA"},
+		"organisationsdatum": {"registreringsdatum": "1927-04-14"},
+		"verksamhetsbeskrivning": {"beskrivning": "Tillverkning av lastvagnar och bussar"},
+		"naringsgrenOrganisation": {"sni": [{"kod": "29101", "klartext": "Tillverkning av personbilar och andra lätta motorfordon"}]},
+		"postadressOrganisation": {"postadress": {"utdelningsadress": "AB Volvo", "postnummer": "40508", "postort": "GÖTEBORG"}}
+	}]}`
+	fixtureSwedenDocuments = `{"dokument": [
+		{"dokumentId": "doc-123", "filformat": "application/zip", "rapporteringsperiodTom": "2023-12-31", "registreringstidpunkt": "2024-03-15T10:00:00Z"}
+	]}`
+)
+
+// createFixtureServer serves every upstream API the tools call, each under
+// its own path prefix.
+func createFixtureServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	writeJSON := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, body)
+		}
+	}
+
+	// Brønnøysund
+	page := `"page": {"size": 20, "totalElements": 1, "totalPages": 1, "number": 0}`
+	mux.HandleFunc("GET /no/enheter", writeJSON(`{"_embedded": {"enheter": [`+fixtureNorwayCompany+`]}, `+page+`}`))
+	mux.HandleFunc("GET /no/enheter/{org}", writeJSON(fixtureNorwayCompany))
+	mux.HandleFunc("GET /no/enheter/{org}/roller", writeJSON(fixtureNorwayRoles))
+	mux.HandleFunc("GET /no/underenheter", writeJSON(`{"_embedded": {"underenheter": [`+fixtureNorwaySubUnit+`]}, `+page+`}`))
+	mux.HandleFunc("GET /no/underenheter/{org}", writeJSON(fixtureNorwaySubUnit))
+	mux.HandleFunc("GET /no/oppdateringer/enheter", writeJSON(`{"_embedded": {"oppdaterteEnheter": [
+		{"oppdateringsid": 101, "organisasjonsnummer": "923609016", "dato": "2024-01-08T06:12:00.000Z", "endringstype": "Endring"}]}}`))
+	mux.HandleFunc("GET /no/oppdateringer/underenheter", writeJSON(`{"_embedded": {"oppdaterteUnderenheter": [
+		{"oppdateringsid": 202, "organisasjonsnummer": "973152351", "dato": "2024-01-08T06:12:00.000Z", "endringstype": "Ny"}]}}`))
+	mux.HandleFunc("GET /no/kommuner", writeJSON(`{"_embedded": {"kommuner": [{"nummer": "0301", "navn": "OSLO"}, {"nummer": "1103", "navn": "STAVANGER"}]}}`))
+	mux.HandleFunc("GET /no/organisasjonsformer", writeJSON(`{"_embedded": {"organisasjonsformer": [{"kode": "AS", "beskrivelse": "Aksjeselskap"}, {"kode": "ASA", "beskrivelse": "Allmennaksjeselskap"}]}}`))
+
+	// CVR API: every lookup parameter answers with the same company
+	mux.HandleFunc("GET /dk", writeJSON(fixtureDenmarkCompany))
+
+	// PRH
+	mux.HandleFunc("GET /fi/companies", writeJSON(fixtureFinlandCompanies))
+
+	// Bolagsverket
+	mux.HandleFunc("POST /se/token", writeJSON(`{"access_token": "test-token", "token_type": "Bearer", "expires_in": 3600}`))
+	mux.HandleFunc("POST /se/organisationer", writeJSON(fixtureSwedenCompanies))
+	mux.HandleFunc("POST /se/dokumentlista", writeJSON(fixtureSwedenDocuments))
+	mux.HandleFunc("GET /se/dokument/{id}", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+		_, _ = w.Write([]byte("PK\x05\x06" + strings.Repeat("\x00", 18)))
+	})
+	mux.HandleFunc("GET /se/isalive", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "OK")
+	})
+
+	// VIES
+	mux.HandleFunc("GET /vies/ms/{cc}/vat/{number}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"countryCode": r.PathValue("cc"),
+			"vatNumber":   r.PathValue("number"),
+			"isValid":     true,
+			"requestDate": "2024-06-01T10:00:00.000Z",
+			"userError":   "VALID",
+			"name":        "CARLSBERG A/S",
+			"address":     "J.C. Jacobsens Gade 1\n1799 København V",
+		})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// newFixtureRegistry returns a registry with every client, the Nordic one
+// included, pointed at the fixture server, so every tool is registered.
+func newFixtureRegistry(t *testing.T) *HandlerRegistry {
+	t.Helper()
+	fixtures := createFixtureServer(t)
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+
+	noClient := norway.NewClient(norway.WithLogger(logger), norway.WithBaseURL(fixtures.URL+"/no"))
+	t.Cleanup(noClient.Close)
+	dkClient := denmark.NewClient(denmark.WithLogger(logger), denmark.WithBaseURL(fixtures.URL+"/dk"))
+	t.Cleanup(dkClient.Close)
+	fiClient := finland.NewClient(finland.WithLogger(logger)).WithBaseURL(fixtures.URL + "/fi")
+	t.Cleanup(fiClient.Close)
+	seClient, err := sweden.NewClient(
+		sweden.WithCredentials("test-id", "test-secret"),
+		sweden.WithTokenURL(fixtures.URL+"/se/token"),
+		sweden.WithBaseURL(fixtures.URL+"/se"),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create Sweden client: %v", err)
+	}
+	t.Cleanup(seClient.Close)
+	viesClient := vies.NewClient(vies.WithLogger(logger), vies.WithBaseURL(fixtures.URL+"/vies"))
+	t.Cleanup(viesClient.Close)
+
+	return NewHandlerRegistry(HandlerRegistryConfig{
+		NorwayClient:  noClient,
+		DenmarkClient: dkClient,
+		FinlandClient: fiClient,
+		SwedenClient:  seClient,
+		NordicClient:  nordic.NewClient(nordic.Config{Norway: noClient, Denmark: dkClient, Finland: fiClient, Sweden: seClient, VIES: viesClient, Logger: logger}),
+		Admin: admin.NewConsole(
+			admin.Target{Registry: "norway", Cache: noClient.Cache, Breaker: noClient.CircuitBreaker, Invalidate: noClient.InvalidateCompany},
+			admin.Target{Registry: "vies", Cache: viesClient.Cache, Breaker: viesClient.CircuitBreaker, Invalidate: viesClient.InvalidateVAT},
+		),
+		Logger: logger,
+	})
+}
+
+// contractCalls holds arguments for one call of every tool. The contract
+// test fails for a registered tool missing here, so new tools must add one.
+var contractCalls = map[string][]map[string]any{
+	"norway_search_companies":      {{"query": "Equinor"}},
+	"norway_get_company":           {{"org_number": "923609016"}, {"org_number": "923609016", "full": true}},
+	"norway_get_roles":             {{"org_number": "923609016"}},
+	"norway_get_signature_rights":  {{"org_number": "923609016"}},
+	"norway_batch_get_companies":   {{"org_numbers": []string{"923609016", "974760673"}}},
+	"norway_get_subunits":          {{"parent_org_number": "923609016"}},
+	"norway_get_subunit":           {{"org_number": "973152351"}},
+	"norway_get_updates":           {{"since": "2024-01-08T00:00:00Z"}},
+	"norway_search_subunits":       {{"query": "Equinor"}},
+	"norway_list_municipalities":   {{}},
+	"norway_list_org_forms":        {{}},
+	"norway_get_subunit_updates":   {{"since": "2024-01-08T00:00:00Z"}},
+	"denmark_search_companies":     {{"query": "Carlsberg"}},
+	"denmark_get_company":          {{"cvr": "10150817"}, {"cvr": "10150817", "full": true}},
+	"denmark_get_production_units": {{"cvr": "10150817"}},
+	"denmark_search_by_phone":      {{"phone": "33273300"}},
+	"denmark_get_by_pnumber":       {{"p_number": "1003388394"}},
+	"denmark_batch_get_companies":  {{"cvrs": []string{"10150817", "x"}}},
+	"finland_search_companies":     {{"query": "Nokia"}},
+	"finland_get_company":          {{"business_id": "0112038-9"}, {"business_id": "0112038-9", "full": true}},
+	"finland_batch_get_companies":  {{"business_ids": []string{"0112038-9", "x"}}},
+	"sweden_get_company":           {{"org_number": "5560125790"}},
+	"sweden_get_document_list":     {{"org_number": "5560125790"}},
+	"sweden_check_status":          {{}},
+	"sweden_download_document":     {{"document_id": "doc-123"}},
+	"sweden_batch_get_companies":   {{"org_numbers": []string{"5560125790", "x"}}},
+	"nordic_validate_identifiers":  {{"identifiers": []string{"923609016", "DK10150817", "0112038-9", "5560125790", "12345"}, "check_registry": true}},
+	"nordic_check_vat":             {{"vat_number": "DK10150817"}, {"vat_number": "NO923609016MVA"}},
+	"nordic_lookup_industry_code":  {{}, {"code": "62.010", "scheme": "sn2007"}, {"code": "J"}},
+	"nordic_list_legal_forms":      {{}, {"country": "finland", "category": "limited"}},
+	"admin_cache_stats":            {{}, {"registry": "norway"}},
+	"admin_purge_cache":            {{"registry": "norway", "identifier": "923609016"}, {"registry": "vies", "all": true}},
+	"admin_circuit_status":         {{}},
+	"admin_set_circuit":            {{"registry": "vies", "action": "reset"}},
+}
+
+// connectFixtureSession registers every tool against the fixtures and
+// returns a connected client session.
+func connectFixtureSession(t *testing.T) *mcp.ClientSession {
+	t.Helper()
+	registry := newFixtureRegistry(t)
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "1.0.0"}, nil)
+	registry.RegisterAll(server)
+	if got := len(registry.RegisteredTools()); got != len(AllTools) {
+		t.Fatalf("registered %d tools, want all %d", got, len(AllTools))
+	}
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(context.Background(), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect server: %v", err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect client: %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+// advertisedSchemas lists the tools and decodes each advertised output
+// schema.
+func advertisedSchemas(t *testing.T, session *mcp.ClientSession) map[string]*jsonschema.Schema {
+	t.Helper()
+	list, err := session.ListTools(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListTools failed: %v", err)
+	}
+	schemas := make(map[string]*jsonschema.Schema, len(list.Tools))
+	for _, tool := range list.Tools {
+		if tool.OutputSchema == nil {
+			t.Errorf("%s advertises no output schema", tool.Name)
+			continue
+		}
+		raw, err := json.Marshal(tool.OutputSchema)
+		if err != nil {
+			t.Fatalf("%s: marshaling output schema: %v", tool.Name, err)
+		}
+		var schema jsonschema.Schema
+		if err := json.Unmarshal(raw, &schema); err != nil {
+			t.Fatalf("%s: decoding output schema: %v", tool.Name, err)
+		}
+		schemas[tool.Name] = &schema
+	}
+	return schemas
+}
+
+// rawRecordProperties are results that pass an upstream record through
+// unchanged (full=true and the sub-unit lookup). They mirror the registry's
+// own field names and carry no descriptions of their own.
+var rawRecordProperties = map[string]bool{
+	"norway_get_company.company":  true,
+	"norway_get_subunit.sub_unit": true,
+	"denmark_get_company.company": true,
+}
+
+// TestOutputSchemas checks that every tool advertises an object output
+// schema whose properties are all described, down through nested results.
+func TestOutputSchemas(t *testing.T) {
+	session := connectFixtureSession(t)
+	for name, schema := range advertisedSchemas(t, session) {
+		if schema.Type != "object" {
+			t.Errorf("%s: output schema type %q, want object", name, schema.Type)
+		}
+		if len(schema.Properties) == 0 {
+			t.Errorf("%s: output schema has no properties", name)
+		}
+		for _, missing := range undescribedProperties(name, schema) {
+			t.Errorf("output property %s has no description", missing)
+		}
+	}
+}
+
+// undescribedProperties walks schema and returns the paths of properties
+// without a description, skipping rawRecordProperties.
+func undescribedProperties(path string, schema *jsonschema.Schema) []string {
+	var missing []string
+	for prop, s := range schema.Properties {
+		p := path + "." + prop
+		if rawRecordProperties[p] {
+			continue
+		}
+		if s.Description == "" {
+			missing = append(missing, p)
+		}
+		missing = append(missing, undescribedProperties(p, s)...)
+	}
+	if schema.Items != nil {
+		missing = append(missing, undescribedProperties(path+"[]", schema.Items)...)
+	}
+	return missing
+}
+
+// TestToolOutputContract calls every registered tool against the fixtures
+// and validates the structured content the client receives against the
+// output schema the server advertises.
+func TestToolOutputContract(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir()) // sweden_download_document writes the ZIP here
+	session := connectFixtureSession(t)
+	schemas := advertisedSchemas(t, session)
+
+	for _, spec := range AllTools {
+		calls, ok := contractCalls[spec.Name]
+		if !ok {
+			t.Errorf("%s has no contract call; add one to contractCalls", spec.Name)
+			continue
+		}
+		resolved, err := schemas[spec.Name].Resolve(nil)
+		if err != nil {
+			t.Errorf("%s: resolving output schema: %v", spec.Name, err)
+			continue
+		}
+		for _, args := range calls {
+			res, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: spec.Name, Arguments: args})
+			if err != nil {
+				t.Errorf("%s(%v): %v", spec.Name, args, err)
+				continue
+			}
+			if res.IsError {
+				t.Errorf("%s(%v) failed: %s", spec.Name, args, res.Content[0].(*mcp.TextContent).Text)
+				continue
+			}
+			if res.StructuredContent == nil {
+				t.Errorf("%s(%v) returned no structured content", spec.Name, args)
+				continue
+			}
+			if err := resolved.Validate(res.StructuredContent); err != nil {
+				t.Errorf("%s(%v) output does not match its schema: %v", spec.Name, args, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/infra"
+)
+
+type progressTestArgs struct {
+	Reports []int64 `json:"reports,omitempty" jsonschema:"Progress values to report, in order"`
+}
+
+type progressTestResult struct {
+	Done bool `json:"done" jsonschema:"Whether the call completed"`
+}
+
+// connectTestTool registers one tool built by register around method and
+// connects a client whose progress notifications are sent to progress.
+func connectTestTool(t *testing.T, spec ToolSpec, method func(context.Context, progressTestArgs) (progressTestResult, error), progress chan<- *mcp.ProgressNotificationParams) *mcp.ClientSession {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	h := &HandlerRegistry{logger: logger}
+	server := createTestMCPServer()
+	register(h, server, h.buildTool(spec), spec, method)
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(context.Background(), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect server: %v", err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, &mcp.ClientOptions{
+		ProgressNotificationHandler: func(_ context.Context, req *mcp.ProgressNotificationClientRequest) {
+			progress <- req.Params
+		},
+	})
+	session, err := client.Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect client: %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+func TestRegister_ProgressNotifications(t *testing.T) {
+	progress := make(chan *mcp.ProgressNotificationParams, 10)
+	spec := ToolSpec{Name: "progress_tool", Method: "ProgressTool"}
+	session := connectTestTool(t, spec, func(ctx context.Context, args progressTestArgs) (progressTestResult, error) {
+		for _, done := range args.Reports {
+			infra.ReportProgress(ctx, done, 3, "working")
+		}
+		return progressTestResult{Done: true}, nil
+	}, progress)
+
+	// 1 goes backwards and must be dropped; 3 completes the call and must be
+	// sent however soon it follows 2.
+	_, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+		Meta:      mcp.Meta{"progressToken": "tok-1"},
+		Name:      "progress_tool",
+		Arguments: map[string]any{"reports": []int64{2, 1, 3}},
+	})
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+
+	var got []float64
+	for len(got) < 2 {
+		select {
+		case p := <-progress:
+			if p.ProgressToken != "tok-1" {
+				t.Errorf("progress token = %v, want tok-1", p.ProgressToken)
+			}
+			if p.Total != 3 {
+				t.Errorf("total = %v, want 3", p.Total)
+			}
+			got = append(got, p.Progress)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for progress; got %v", got)
+		}
+	}
+	if got[0] != 2 || got[1] != 3 {
+		t.Errorf("progress = %v, want [2 3]", got)
+	}
+}
+
+func TestRegister_NoProgressWithoutToken(t *testing.T) {
+	progress := make(chan *mcp.ProgressNotificationParams, 10)
+	spec := ToolSpec{Name: "progress_tool", Method: "ProgressTool"}
+	session := connectTestTool(t, spec, func(ctx context.Context, args progressTestArgs) (progressTestResult, error) {
+		infra.ReportProgress(ctx, 1, 1, "working")
+		return progressTestResult{Done: true}, nil
+	}, progress)
+
+	if _, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "progress_tool"}); err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	select {
+	case p := <-progress:
+		t.Errorf("unexpected progress notification without a token: %+v", p)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestRegister_ToolTimeout(t *testing.T) {
+	spec := ToolSpec{Name: "slow_tool", Method: "SlowTool", Timeout: 20 * time.Millisecond}
+	session := connectTestTool(t, spec, func(ctx context.Context, _ progressTestArgs) (progressTestResult, error) {
+		<-ctx.Done()
+		return progressTestResult{}, ctx.Err()
+	}, make(chan *mcp.ProgressNotificationParams, 1))
+
+	res, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "slow_tool"})
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if !res.IsError {
+		t.Fatal("expected a tool error after the timeout")
+	}
+	text := res.Content[0].(*mcp.TextContent).Text
+	if !strings.Contains(text, "slow_tool timed out after 20ms") {
+		t.Errorf("error = %q, want it to name the tool and its timeout", text)
+	}
+}
+
+func TestToolSpecTimeout(t *testing.T) {
+	if got := (ToolSpec{}).timeout(); got != ToolTimeout {
+		t.Errorf("default timeout = %v, want ToolTimeout (%v)", got, ToolTimeout)
+	}
+	if got := (ToolSpec{Timeout: time.Minute}).timeout(); got != time.Minute {
+		t.Errorf("timeout = %v, want 1m", got)
+	}
+
+	// Batch tools fan out over many lookups and must not be held to the
+	// single-lookup default.
+	for _, spec := range AllTools {
+		if spec.Category == "batch" && spec.timeout() <= ToolTimeout {
+			t.Errorf("%s: timeout %v, want more than ToolTimeout", spec.Name, spec.timeout())
+		}
+	}
+}
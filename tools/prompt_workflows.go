@@ -0,0 +1,216 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+)
+
+// VendorVerificationArgs are the arguments of the vendor_verification prompt.
+type VendorVerificationArgs struct {
+	Company   string `json:"company" jsonschema:"Company name or registry identifier: Norwegian org number, Danish CVR, Finnish business ID or Swedish org number"`
+	Country   string `json:"country" jsonschema:"Registry country: norway, denmark, finland or sweden"`
+	VATNumber string `json:"vat_number,omitempty" jsonschema:"VAT number from the vendor's invoice or contract, checked against VIES or the Norwegian VAT register"`
+	Signer    string `json:"signer,omitempty" jsonschema:"Name of the person signing for the vendor; checked against signature rights in Norway"`
+}
+
+// BoardCheckArgs are the arguments of the board_check prompt.
+type BoardCheckArgs struct {
+	OrgNumber string `json:"org_number" jsonschema:"9-digit Norwegian organization number"`
+	Person    string `json:"person,omitempty" jsonschema:"Person to look for among the role holders"`
+}
+
+// CrossBorderPresenceArgs are the arguments of the cross_border_presence prompt.
+type CrossBorderPresenceArgs struct {
+	CompanyName string `json:"company_name" jsonschema:"Company or group name to search for, e.g. Telenor"`
+	Countries   string `json:"countries,omitempty" jsonschema:"Comma-separated countries to cover: norway, denmark, finland, sweden (default: all)"`
+}
+
+// SigningAuthorityArgs are the arguments of the signing_authority prompt.
+type SigningAuthorityArgs struct {
+	OrgNumber string `json:"org_number" jsonschema:"9-digit Norwegian organization number"`
+	Signers   string `json:"signers,omitempty" jsonschema:"Comma-separated names of the people expected to sign"`
+}
+
+// countryTools names the per-country tools the workflows call. Sweden has
+// no name search.
+var countryTools = map[string]struct{ search, get string }{
+	"norway":  {"norway_search_companies", "norway_get_company"},
+	"denmark": {"denmark_search_companies", "denmark_get_company"},
+	"finland": {"finland_search_companies", "finland_get_company"},
+	"sweden":  {"", "sweden_get_company"},
+}
+
+// countryAdjectives names the registries in prose.
+var countryAdjectives = map[string]string{
+	"norway":  "Norwegian",
+	"denmark": "Danish",
+	"finland": "Finnish",
+	"sweden":  "Swedish",
+}
+
+// promptCountries is the order countries are listed in.
+var promptCountries = []string{"norway", "denmark", "finland", "sweden"}
+
+// promptCountry normalizes a country argument.
+func promptCountry(field, value string) (string, error) {
+	country := strings.ToLower(strings.TrimSpace(value))
+	if _, ok := countryTools[country]; !ok {
+		return "", apierrors.NewValidationError(field, value, "must be norway, denmark, finland or sweden")
+	}
+	return country, nil
+}
+
+// countryTitle capitalizes a country name for prose.
+func countryTitle(country string) string {
+	return strings.ToUpper(country[:1]) + country[1:]
+}
+
+// workflow builds the numbered instructions of a prompt.
+type workflow struct {
+	b     strings.Builder
+	steps int
+}
+
+func newWorkflow(goal string) *workflow {
+	w := &workflow{}
+	w.b.WriteString(goal)
+	w.b.WriteString("\n\nFollow these steps, calling the tools named:\n")
+	return w
+}
+
+func (w *workflow) step(format string, args ...any) {
+	w.steps++
+	fmt.Fprintf(&w.b, "\n%d. %s", w.steps, fmt.Sprintf(format, args...))
+}
+
+func (w *workflow) finish(report string) string {
+	w.b.WriteString("\n\n")
+	w.b.WriteString(report)
+	w.b.WriteString("\n")
+	return w.b.String()
+}
+
+func (h *HandlerRegistry) vendorVerificationPrompt(args VendorVerificationArgs) (string, error) {
+	country, err := promptCountry("country", args.Country)
+	if err != nil {
+		return "", err
+	}
+	tools := countryTools[country]
+	if !h.hasTool(tools.get) {
+		return "", apierrors.NewValidationError("country", args.Country, "registry not configured on this server")
+	}
+
+	w := newWorkflow(fmt.Sprintf("Verify %q as a vendor against the %s business registry.", args.Company, countryAdjectives[country]))
+
+	w.step("Identify the company. If %q is an identifier, check its format and checksum with `nordic_validate_identifiers` (default_country=%s) and stop with a finding if it is invalid.", args.Company, country)
+	if tools.search != "" {
+		fmt.Fprintf(&w.b, " If it is a name, find the organization number with `%s`; when several entities match, list them and ask which one is meant.", tools.search)
+	} else {
+		w.b.WriteString(" Bolagsverket has no name search: if only a name was given, ask for the 10-digit organization number.")
+	}
+
+	w.step("Fetch the registry record with `%s`. Note the legal name, address, registration date and `legal_form_class` (category and owner liability).", tools.get)
+	switch country {
+	case "norway":
+		w.step("Check the status: the record must not be bankrupt (konkurs), under liquidation or deleted.")
+	case "sweden":
+		w.step("Check the status with `sweden_check_status`: the company must be active with no ongoing proceedings (konkurs, likvidation, rekonstruktion).")
+	default:
+		w.step("Check the `status` field: the company must be active, not bankrupt, dissolved or in liquidation.")
+	}
+
+	if args.VATNumber != "" {
+		w.step("Check the VAT number %q with `nordic_check_vat`. It must be valid and `name_match` must not be `mismatch`; a VAT number belonging to another company is a red flag.", args.VATNumber)
+	} else {
+		w.step("Check the company's VAT registration with `nordic_check_vat`, passing its registry identifier (country=%s).", country)
+	}
+
+	if country == "norway" {
+		if args.Signer != "" {
+			w.step("Check that %q may sign for the company with `norway_get_signature_rights`. Note whether they sign alone, jointly with others, or not at all.", args.Signer)
+		} else {
+			w.step("List who may sign for the company with `norway_get_signature_rights`.")
+		}
+		w.step("Check the board and auditor with `norway_get_roles`; a missing auditor on an AS or ASA is worth noting.")
+	} else if args.Signer != "" {
+		w.step("Signature rights are only available for Norway; note that the authority of %q must be confirmed from the vendor's own documents.", args.Signer)
+	}
+
+	return w.finish("Report a verdict (verified, verified with remarks, or not verified), then a table of each check with its result and source tool. List every red flag explicitly."), nil
+}
+
+func (h *HandlerRegistry) boardCheckPrompt(args BoardCheckArgs) (string, error) {
+	w := newWorkflow(fmt.Sprintf("Review the governance of the Norwegian company %s.", args.OrgNumber))
+
+	w.step("Fetch the company with `norway_get_company` (org_number=%s) and confirm it exists and is not bankrupt or under liquidation.", args.OrgNumber)
+	w.step("List the role holders with `norway_get_roles`: chair (LEDE), board members (MEDL), deputies (VARA), CEO (DAGL), auditor (REVI) and accountant (REGN). Skip roles marked as resigned.")
+	w.step("Check who may sign with `norway_get_signature_rights`, including prokura.")
+	w.step("Where a role is held by another company, look it up with `norway_get_company` to see who stands behind it.")
+	if args.Person != "" {
+		w.step("Find %q among the role holders and the signature rights, and list every position they hold. Match on name and birth date, not name alone.", args.Person)
+	}
+
+	return w.finish("Report the board composition as a table (role, name, since), then the CEO, auditor and signing rules. Flag a missing auditor, a board without a chair, or the same person holding conflicting roles such as CEO and auditor."), nil
+}
+
+func (h *HandlerRegistry) crossBorderPresencePrompt(args CrossBorderPresenceArgs) (string, error) {
+	countries := promptCountries
+	if strings.TrimSpace(args.Countries) != "" {
+		countries = nil
+		for _, c := range strings.Split(args.Countries, ",") {
+			country, err := promptCountry("countries", c)
+			if err != nil {
+				return "", err
+			}
+			countries = append(countries, country)
+		}
+	}
+
+	w := newWorkflow(fmt.Sprintf("Map where %q is registered in the Nordic business registries.", args.CompanyName))
+
+	for _, country := range countries {
+		tools := countryTools[country]
+		switch {
+		case !h.hasTool(tools.get):
+			w.step("%s: the registry is not configured on this server; report it as not checked.", countryTitle(country))
+		case tools.search == "":
+			w.step("Sweden: Bolagsverket has no name search. If a Swedish organization number is known, for example from an annual report or a parent company record, fetch it with `%s`; otherwise report Sweden as not checked.", tools.get)
+		case country == "denmark":
+			w.step("Denmark: search with `%s`. It returns only the best match, so also try variants such as %q and %q.", tools.search, args.CompanyName+" A/S", args.CompanyName+" ApS")
+		default:
+			w.step("%s: search with `%s` and keep the entities whose name matches.", countryTitle(country), tools.search)
+		}
+	}
+
+	w.step("Fetch each candidate's record with the matching `*_get_company` tool and compare names, `nace` industry codes and `legal_form_class`, so that an AS, A/S, OY and AB can be told apart from branches (`branch_of_foreign`) and unrelated namesakes.")
+	if h.hasTool("norway_get_subunits") {
+		w.step("For Norwegian entities, list local branches with `norway_get_subunits`.")
+	}
+	if h.hasTool("denmark_get_production_units") {
+		w.step("For Danish entities, list production units with `denmark_get_production_units`.")
+	}
+	w.step("Use `nordic_list_legal_forms` to explain any unfamiliar legal form.")
+
+	return w.finish("Report one row per country (entity name, identifier, legal form category, industry, status) and say which entities most likely belong to the same group and why. Mark countries where nothing was found or nothing could be checked."), nil
+}
+
+func (h *HandlerRegistry) signingAuthorityPrompt(args SigningAuthorityArgs) (string, error) {
+	w := newWorkflow(fmt.Sprintf("Determine who can sign on behalf of the Norwegian company %s.", args.OrgNumber))
+
+	w.step("Fetch the company with `norway_get_company` (org_number=%s) and confirm it is active; a bankrupt company is represented by its estate administrator instead.", args.OrgNumber)
+	w.step("Read the signing rules with `norway_get_signature_rights`: who holds signatur (SIGN), whether they sign alone or jointly, and who holds prokura (PROK).")
+	w.step("Cross-check the names against `norway_get_roles`, since signing rules often refer to roles such as \"the chair and one board member jointly\".")
+	if args.Signers != "" {
+		var names []string
+		for _, n := range strings.Split(args.Signers, ",") {
+			if n = strings.TrimSpace(n); n != "" {
+				names = append(names, fmt.Sprintf("%q", n))
+			}
+		}
+		w.step("Decide whether %s together satisfy the signing rules, alone or jointly.", strings.Join(names, ", "))
+	}
+
+	return w.finish("Answer first who may sign and in which combinations, then cite the registered signing rule verbatim. Prokura authorizes day-to-day business only; say so if the document being signed may go beyond it."), nil
+}
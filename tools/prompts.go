@@ -0,0 +1,196 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+)
+
+// PromptSpec defines an MCP prompt for declarative registration, the prompt
+// counterpart of ToolSpec and ResourceSpec.
+type PromptSpec struct {
+	// Name is the prompt name (e.g., "vendor_verification")
+	Name string
+
+	// Title is the human-readable name
+	Title string
+
+	// Description is the prompt description shown in prompt pickers
+	Description string
+
+	// Tools lists the tools every expansion of the prompt calls; the prompt
+	// is registered only when all of them are
+	Tools []string
+
+	// Method keys the handler, like ToolSpec.Method
+	Method string
+}
+
+// AllPrompts contains all prompt definitions. Each expands into the
+// step-by-step due-diligence workflow of skills/company-lookup, naming the
+// tools to call, so clients without the skill file get the same guidance.
+var AllPrompts = []PromptSpec{
+	{
+		Name:        "vendor_verification",
+		Title:       "Vendor Verification",
+		Description: "Verify a supplier before onboarding or payment: registry record, active status, VAT registration, legal form and, in Norway, the signer's authority.",
+		Tools:       []string{"nordic_validate_identifiers", "nordic_check_vat"},
+		Method:      "VendorVerification",
+	},
+	{
+		Name:        "board_check",
+		Title:       "Board Check",
+		Description: "Review the board, CEO and auditor of a Norwegian company, optionally checking one person's positions.",
+		Tools:       []string{"norway_get_company", "norway_get_roles", "norway_get_signature_rights"},
+		Method:      "BoardCheck",
+	},
+	{
+		Name:        "cross_border_presence",
+		Title:       "Cross-Border Presence",
+		Description: "Find where a company group is registered across the Nordic registries and compare the entities.",
+		Tools:       []string{"norway_search_companies", "denmark_search_companies", "finland_search_companies", "nordic_list_legal_forms"},
+		Method:      "CrossBorderPresence",
+	},
+	{
+		Name:        "signing_authority",
+		Title:       "Signing Authority",
+		Description: "Determine who can sign on behalf of a Norwegian company and whether named people may sign alone or jointly.",
+		Tools:       []string{"norway_get_company", "norway_get_signature_rights", "norway_get_roles"},
+		Method:      "SigningAuthority",
+	},
+}
+
+// promptFunc renders a prompt from its raw string arguments.
+type promptFunc func(ctx context.Context, args map[string]string) (string, error)
+
+// registeredPrompt is a prompt's renderer and the arguments it advertises.
+type registeredPrompt struct {
+	arguments []*mcp.PromptArgument
+	render    promptFunc
+}
+
+// promptMethod adapts a typed render function to a registeredPrompt. The
+// advertised arguments are inferred from Args the way tool input schemas
+// are: the jsonschema tag is the description and fields without omitempty
+// are required.
+func promptMethod[Args any](render func(Args) (string, error)) registeredPrompt {
+	schema, err := jsonschema.For[Args](nil)
+	if err != nil {
+		panic(fmt.Sprintf("inferring prompt arguments for %T: %v", *new(Args), err))
+	}
+
+	// schema.Properties is a map; list the arguments in struct field order
+	t := reflect.TypeFor[Args]()
+	arguments := make([]*mcp.PromptArgument, 0, t.NumField())
+	for i := range t.NumField() {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		prop, ok := schema.Properties[name]
+		if !ok {
+			continue
+		}
+		arguments = append(arguments, &mcp.PromptArgument{
+			Name:        name,
+			Description: prop.Description,
+			Required:    slices.Contains(schema.Required, name),
+		})
+	}
+
+	return registeredPrompt{
+		arguments: arguments,
+		render: func(_ context.Context, raw map[string]string) (string, error) {
+			for _, arg := range arguments {
+				if arg.Required && strings.TrimSpace(raw[arg.Name]) == "" {
+					return "", apierrors.NewValidationError(arg.Name, "", "is required")
+				}
+			}
+			data, err := json.Marshal(raw)
+			if err != nil {
+				return "", err
+			}
+			var args Args
+			if err := json.Unmarshal(data, &args); err != nil {
+				return "", fmt.Errorf("decoding prompt arguments: %w", err)
+			}
+			return render(args)
+		},
+	}
+}
+
+// initPrompts builds the prompt handler map.
+func (h *HandlerRegistry) initPrompts() {
+	h.prompts = map[string]registeredPrompt{
+		"VendorVerification":  promptMethod(h.vendorVerificationPrompt),
+		"BoardCheck":          promptMethod(h.boardCheckPrompt),
+		"CrossBorderPresence": promptMethod(h.crossBorderPresencePrompt),
+		"SigningAuthority":    promptMethod(h.signingAuthorityPrompt),
+	}
+}
+
+// hasTool reports whether the named tool is registered.
+func (h *HandlerRegistry) hasTool(name string) bool {
+	for _, spec := range AllTools {
+		if spec.Name == name {
+			return h.toolEnabled(spec)
+		}
+	}
+	return false
+}
+
+// RegisteredPrompts returns the prompts whose handler and tools are all
+// registered.
+func (h *HandlerRegistry) RegisteredPrompts() []PromptSpec {
+	registered := make([]PromptSpec, 0, len(AllPrompts))
+	for _, spec := range AllPrompts {
+		if _, ok := h.prompts[spec.Method]; !ok {
+			continue
+		}
+		if !slices.ContainsFunc(spec.Tools, func(tool string) bool { return !h.hasTool(tool) }) {
+			registered = append(registered, spec)
+		}
+	}
+	return registered
+}
+
+// registerPrompts adds every registered prompt to the server and returns
+// how many were added.
+func (h *HandlerRegistry) registerPrompts(server *mcp.Server) int {
+	registered := h.RegisteredPrompts()
+	for _, spec := range registered {
+		p := h.prompts[spec.Method]
+		server.AddPrompt(&mcp.Prompt{
+			Name:        spec.Name,
+			Title:       spec.Title,
+			Description: spec.Description,
+			Arguments:   p.arguments,
+		}, h.promptHandler(spec, p))
+	}
+	return len(registered)
+}
+
+// promptHandler renders the prompt as a single user message. Invalid
+// arguments are reported as errors, as tool calls report them.
+func (h *HandlerRegistry) promptHandler(spec PromptSpec, p registeredPrompt) mcp.PromptHandler {
+	return func(ctx context.Context, req *mcp.GetPromptRequest) (res *mcp.GetPromptResult, err error) {
+		defer h.recoverPanic(spec.Name, &err)
+
+		text, err := p.render(ctx, req.Params.Arguments)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", spec.Name, err)
+		}
+		h.logger.Info("Prompt rendered", "prompt", spec.Name)
+		return &mcp.GetPromptResult{
+			Description: spec.Description,
+			Messages: []*mcp.PromptMessage{
+				{Role: "user", Content: &mcp.TextContent{Text: text}},
+			},
+		}, nil
+	}
+}
@@ -0,0 +1,225 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/admin"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/denmark"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/finland"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/nordic"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/norway"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/sweden"
+)
+
+// newPromptTestRegistry returns a registry with every client configured.
+// Prompts only look at which tools are registered, so the clients are never
+// called.
+func newPromptTestRegistry(t *testing.T, withNordic bool) *HandlerRegistry {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	noClient := norway.NewClient(norway.WithLogger(logger))
+	t.Cleanup(noClient.Close)
+	dkClient := denmark.NewClient(denmark.WithLogger(logger))
+	t.Cleanup(dkClient.Close)
+	fiClient := finland.NewClient(finland.WithLogger(logger))
+	t.Cleanup(fiClient.Close)
+	seClient, err := sweden.NewClient(sweden.WithCredentials("test-id", "test-secret"))
+	if err != nil {
+		t.Fatalf("Failed to create Sweden client: %v", err)
+	}
+	t.Cleanup(seClient.Close)
+
+	cfg := HandlerRegistryConfig{NorwayClient: noClient, DenmarkClient: dkClient, FinlandClient: fiClient, SwedenClient: seClient, Logger: logger}
+	if withNordic {
+		cfg.NordicClient = nordic.NewClient(nordic.Config{Norway: noClient, Denmark: dkClient, Finland: fiClient, Sweden: seClient, Logger: logger})
+		cfg.Admin = admin.NewConsole(admin.Target{Registry: "norway", Cache: noClient.Cache, Breaker: noClient.CircuitBreaker, Invalidate: noClient.InvalidateCompany})
+	}
+	return NewHandlerRegistry(cfg)
+}
+
+func TestPromptSpecs(t *testing.T) {
+	toolNames := make(map[string]bool)
+	for _, spec := range AllTools {
+		toolNames[spec.Name] = true
+	}
+	registry := newPromptTestRegistry(t, true)
+
+	seen := make(map[string]bool)
+	for _, spec := range AllPrompts {
+		t.Run(spec.Name, func(t *testing.T) {
+			if seen[spec.Name] {
+				t.Errorf("duplicate prompt %q", spec.Name)
+			}
+			seen[spec.Name] = true
+			if spec.Title == "" || spec.Description == "" {
+				t.Error("prompt has empty metadata")
+			}
+			if _, ok := registry.prompts[spec.Method]; !ok {
+				t.Errorf("no handler for method %q", spec.Method)
+			}
+			for _, tool := range spec.Tools {
+				if !toolNames[tool] {
+					t.Errorf("references unknown tool %q", tool)
+				}
+			}
+		})
+	}
+}
+
+func TestPromptMethod_Arguments(t *testing.T) {
+	p := promptMethod(func(VendorVerificationArgs) (string, error) { return "", nil })
+	want := []struct {
+		name     string
+		required bool
+	}{{"company", true}, {"country", true}, {"vat_number", false}, {"signer", false}}
+
+	if len(p.arguments) != len(want) {
+		t.Fatalf("got %d arguments, want %d", len(p.arguments), len(want))
+	}
+	for i, w := range want {
+		arg := p.arguments[i]
+		if arg.Name != w.name || arg.Required != w.required {
+			t.Errorf("argument %d = %s (required %v), want %s (required %v)", i, arg.Name, arg.Required, w.name, w.required)
+		}
+		if arg.Description == "" {
+			t.Errorf("argument %s has no description", arg.Name)
+		}
+	}
+}
+
+// TestPromptToolReferences renders every prompt with a range of arguments
+// and checks that each tool name in the text is a registered tool.
+func TestPromptToolReferences(t *testing.T) {
+	registry := newPromptTestRegistry(t, true)
+	toolRef := regexp.MustCompile("`((?:norway|denmark|finland|sweden|nordic)_[a-z_]+)`")
+
+	cases := map[string][]map[string]string{
+		"VendorVerification": {
+			{"company": "923609016", "country": "norway", "signer": "Ola Nordmann", "vat_number": "NO923609016MVA"},
+			{"company": "Carlsberg", "country": "Denmark"},
+			{"company": "Kone", "country": "finland", "signer": "Matti Meikäläinen"},
+			{"company": "5560125790", "country": "sweden"},
+		},
+		"BoardCheck": {
+			{"org_number": "923609016"},
+			{"org_number": "923609016", "person": "Ola Nordmann"},
+		},
+		"CrossBorderPresence": {
+			{"company_name": "Telenor"},
+			{"company_name": "Telenor", "countries": "denmark, sweden"},
+		},
+		"SigningAuthority": {
+			{"org_number": "923609016"},
+			{"org_number": "923609016", "signers": "Ola Nordmann, Kari Nordmann"},
+		},
+	}
+	for method, argSets := range cases {
+		for _, args := range argSets {
+			text, err := registry.prompts[method].render(context.Background(), args)
+			if err != nil {
+				t.Errorf("%s(%v) failed: %v", method, args, err)
+				continue
+			}
+			refs := toolRef.FindAllStringSubmatch(text, -1)
+			if len(refs) == 0 {
+				t.Errorf("%s(%v) names no tools", method, args)
+			}
+			for _, ref := range refs {
+				if !registry.hasTool(ref[1]) {
+					t.Errorf("%s(%v) names unregistered tool %q", method, args, ref[1])
+				}
+			}
+		}
+	}
+}
+
+func TestPromptRender_Validation(t *testing.T) {
+	registry := newPromptTestRegistry(t, true)
+	tests := []struct {
+		method string
+		args   map[string]string
+		field  string
+	}{
+		{"VendorVerification", map[string]string{"country": "norway"}, "company"},
+		{"VendorVerification", map[string]string{"company": "Equinor", "country": "iceland"}, "country"},
+		{"BoardCheck", map[string]string{"org_number": "  "}, "org_number"},
+		{"CrossBorderPresence", map[string]string{"company_name": "Telenor", "countries": "norway,germany"}, "countries"},
+	}
+	for _, tt := range tests {
+		_, err := registry.prompts[tt.method].render(context.Background(), tt.args)
+		if err == nil || !strings.Contains(err.Error(), tt.field) {
+			t.Errorf("%s(%v) error = %v, want a validation error for %s", tt.method, tt.args, err, tt.field)
+		}
+	}
+}
+
+func TestRegisteredPrompts(t *testing.T) {
+	with := newPromptTestRegistry(t, true)
+	if got := len(with.RegisteredPrompts()); got != len(AllPrompts) {
+		t.Errorf("registered %d prompts, want %d", got, len(AllPrompts))
+	}
+
+	// vendor_verification needs the cross-registry tools.
+	without := newPromptTestRegistry(t, false)
+	for _, spec := range without.RegisteredPrompts() {
+		if spec.Name == "vendor_verification" {
+			t.Error("vendor_verification registered without the Nordic client")
+		}
+	}
+}
+
+func TestGetPrompt(t *testing.T) {
+	registry := newPromptTestRegistry(t, true)
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "1.0.0"}, nil)
+	registry.RegisterAll(server)
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(context.Background(), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect server: %v", err)
+	}
+	defer serverSession.Close()
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect client: %v", err)
+	}
+	defer session.Close()
+	ctx := context.Background()
+
+	list, err := session.ListPrompts(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListPrompts failed: %v", err)
+	}
+	if len(list.Prompts) != len(AllPrompts) {
+		t.Errorf("listed %d prompts, want %d", len(list.Prompts), len(AllPrompts))
+	}
+
+	res, err := session.GetPrompt(ctx, &mcp.GetPromptParams{
+		Name:      "signing_authority",
+		Arguments: map[string]string{"org_number": "923609016", "signers": "Ola Nordmann"},
+	})
+	if err != nil {
+		t.Fatalf("GetPrompt failed: %v", err)
+	}
+	if len(res.Messages) != 1 || res.Messages[0].Role != "user" {
+		t.Fatalf("unexpected messages: %+v", res.Messages)
+	}
+	text := res.Messages[0].Content.(*mcp.TextContent).Text
+	for _, want := range []string{"923609016", "`norway_get_signature_rights`", `"Ola Nordmann"`} {
+		if !strings.Contains(text, want) {
+			t.Errorf("prompt text missing %s:\n%s", want, text)
+		}
+	}
+
+	if _, err := session.GetPrompt(ctx, &mcp.GetPromptParams{Name: "board_check"}); err == nil {
+		t.Error("GetPrompt without the required org_number should fail")
+	}
+}
@@ -3,6 +3,8 @@
 // using type-safe handlers to register them.
 package tools
 
+import "time"
+
 // ToolSpec defines a tool's metadata for declarative registration.
 // Each spec maps to a registry client method with matching Args/Result types.
 type ToolSpec struct {
@@ -49,6 +51,49 @@ type ToolSpec struct {
 	// transport rejects the call with -32020 HeaderMismatch. New header
 	// suffixes must also be added to the CORS allowlist in security.go.
 	HeaderParams map[string]string
+
+	// Timeout bounds one call of the tool. Zero means ToolTimeout; batch
+	// tools and downloads that legitimately run longer set their own.
+	Timeout time.Duration
+
+	// Elicits marks a tool that may ask the user to settle an ambiguous
+	// lookup through MCP elicitation (see elicitation.go). It only asks when
+	// the client supports elicitation; otherwise it behaves as without.
+	Elicits bool
+
+	// Task says what the tool is for, as the user's goal (e.g., "Search for
+	// companies by name"). It heads the tool's entry in the server
+	// instructions and opens its section in docs/API.md.
+	Task string
+
+	// Examples are requests the tool answers, phrased as a user would ask
+	// them. The first is shown in the server instructions, all of them in
+	// docs/API.md.
+	Examples []string
+
+	// Hint follows the tool name in the server instructions, for the one
+	// thing the model should know when picking it (e.g., "max 100").
+	Hint string
+
+	// Caveats are pitfalls the model must know before relying on a result.
+	// They appear in both the server instructions and docs/API.md. Reference
+	// detail that would only cost tokens in the instructions, such as value
+	// tables, goes in notes/<Name>.md, which docs/API.md alone includes.
+	Caveats []Caveat
+}
+
+// Caveat is a pitfall of a tool, shown under its own heading.
+type Caveat struct {
+	Title string // e.g., "Danish Search Returns Only ONE Result"
+	Text  string // Markdown
+}
+
+// timeout returns the time a call of the tool may take.
+func (s ToolSpec) timeout() time.Duration {
+	if s.Timeout > 0 {
+		return s.Timeout
+	}
+	return ToolTimeout
 }
 
 // ptr is a helper to create a pointer to a value.
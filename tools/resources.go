@@ -0,0 +1,315 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/denmark"
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/finland"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/nace"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/nordic"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/norway"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/sweden"
+)
+
+// ResourceMIMEType is the content type of every resource this server serves.
+const ResourceMIMEType = "application/json"
+
+// ResourceSpec defines an MCP resource or resource template for declarative
+// registration, the resource counterpart of ToolSpec.
+type ResourceSpec struct {
+	// URI is the resource URI, or an RFC 6570 template when Template is set
+	// (e.g., "nordic://no/company/{org_number}")
+	URI string
+
+	// Template marks URI as a template; templates are not listed by
+	// resources/list, only by resources/templates/list
+	Template bool
+
+	// Name is the programmatic resource name
+	Name string
+
+	// Title is the human-readable name
+	Title string
+
+	// Description is the resource description shown to LLMs
+	Description string
+
+	// Country indicates which Nordic country the resource belongs to
+	Country string
+
+	// Method keys the handler, like ToolSpec.Method
+	Method string
+}
+
+// AllResources contains all resource definitions. Company templates serve
+// the same result as the matching get_company tool (summary form, LEI
+// enrichment included) through the same cached client methods.
+var AllResources = []ResourceSpec{
+	{
+		URI:         "nordic://no/company/{org_number}",
+		Template:    true,
+		Name:        "norway_company",
+		Title:       "Norwegian Company",
+		Description: "Company summary from Brønnøysundregistrene for a 9-digit organization number, as returned by norway_get_company.",
+		Country:     "norway",
+		Method:      "GetCompany",
+	},
+	{
+		URI:         "nordic://no/company/{org_number}/roles",
+		Template:    true,
+		Name:        "norway_company_roles",
+		Title:       "Norwegian Company Roles",
+		Description: "Board members, CEO, auditor and other roles of a Norwegian company, as returned by norway_get_roles.",
+		Country:     "norway",
+		Method:      "GetRoles",
+	},
+	{
+		URI:         "nordic://dk/company/{cvr}",
+		Template:    true,
+		Name:        "denmark_company",
+		Title:       "Danish Company",
+		Description: "Company summary from CVR for an 8-digit CVR number, as returned by denmark_get_company.",
+		Country:     "denmark",
+		Method:      "DKGetCompany",
+	},
+	{
+		URI:         "nordic://fi/company/{business_id}",
+		Template:    true,
+		Name:        "finland_company",
+		Title:       "Finnish Company",
+		Description: "Company summary from PRH for a business ID (Y-tunnus, e.g. 0112038-9), as returned by finland_get_company.",
+		Country:     "finland",
+		Method:      "FIGetCompany",
+	},
+	{
+		URI:         "nordic://se/company/{org_number}",
+		Template:    true,
+		Name:        "sweden_company",
+		Title:       "Swedish Company",
+		Description: "Company record from Bolagsverket for a 10-digit organization number, as returned by sweden_get_company.",
+		Country:     "sweden",
+		Method:      "SEGetCompany",
+	},
+	{
+		URI:         "nordic://no/municipalities",
+		Name:        "norway_municipalities",
+		Title:       "Norwegian Municipalities",
+		Description: "Norwegian municipality codes and names, for the municipality filter of norway_search_companies.",
+		Country:     "norway",
+		Method:      "ListMunicipalities",
+	},
+	{
+		URI:         "nordic://no/org-forms",
+		Name:        "norway_org_forms",
+		Title:       "Norwegian Organization Forms",
+		Description: "Brønnøysundregistrene organization form codes (AS, ASA, ENK, NUF, ...) with descriptions.",
+		Country:     "norway",
+		Method:      "ListOrgForms",
+	},
+	{
+		URI:         "nordic://reference/legal-forms",
+		Name:        "legal_forms",
+		Title:       "Nordic Legal Forms",
+		Description: "Legal forms of all four registries with category, owner liability and ISO 20275 ELF code, as returned by nordic_list_legal_forms.",
+		Country:     "nordic",
+		Method:      "NordicListLegalForms",
+	},
+	{
+		URI:         "nordic://reference/nace-rev2",
+		Name:        "nace_rev2",
+		Title:       "NACE Rev.2 Sections and Divisions",
+		Description: "NACE Rev.2 sections with their divisions and English labels. SN2007, DB07, TOL 2008 and SNI 2007 codes start with these divisions.",
+		Country:     "nordic",
+		Method:      "NACERev2",
+	},
+	{
+		URI:         "nordic://reference/nace-rev2.1",
+		Name:        "nace_rev2.1",
+		Title:       "NACE Rev.2.1 Sections and Divisions",
+		Description: "NACE Rev.2.1 sections with their divisions and English labels, the basis of the 2025 national schemes.",
+		Country:     "nordic",
+		Method:      "NACERev21",
+	},
+}
+
+// resourceFunc reads one resource. vars holds the template variables
+// matched from the requested URI; it is empty for static resources.
+type resourceFunc func(ctx context.Context, vars map[string]string) (any, error)
+
+// resourceMethod adapts a typed client method to a resourceFunc, building
+// its arguments from the URI variables.
+func resourceMethod[Args, Result any](method func(context.Context, Args) (Result, error), args func(vars map[string]string) Args) resourceFunc {
+	return func(ctx context.Context, vars map[string]string) (any, error) {
+		return method(ctx, args(vars))
+	}
+}
+
+// staticResource serves data that needs no client call.
+func staticResource(data func() any) resourceFunc {
+	return func(context.Context, map[string]string) (any, error) {
+		return data(), nil
+	}
+}
+
+// initResources builds the resource handler map. Resources whose client is
+// not configured are skipped, as their tools are.
+func (h *HandlerRegistry) initResources() {
+	h.resources = map[string]resourceFunc{
+		"GetCompany": resourceMethod(norwayFound(withLEI(h.leiIndex, h.norwayClient.GetCompanyMCP)), func(v map[string]string) norway.GetCompanyArgs {
+			return norway.GetCompanyArgs{OrgNumber: v["org_number"]}
+		}),
+		"GetRoles": resourceMethod(h.norwayClient.GetRolesMCP, func(v map[string]string) norway.GetRolesArgs {
+			return norway.GetRolesArgs{OrgNumber: v["org_number"]}
+		}),
+		"DKGetCompany": resourceMethod(withLEI(h.leiIndex, h.denmarkClient.GetCompanyMCP), func(v map[string]string) denmark.GetCompanyArgs {
+			return denmark.GetCompanyArgs{CVR: v["cvr"]}
+		}),
+		"FIGetCompany": resourceMethod(withLEI(h.leiIndex, h.finlandClient.GetCompanyMCP), func(v map[string]string) finland.GetCompanyArgs {
+			return finland.GetCompanyArgs{BusinessID: v["business_id"]}
+		}),
+		"ListMunicipalities": resourceMethod(h.norwayClient.ListMunicipalitiesMCP, func(map[string]string) norway.ListMunicipalitiesArgs {
+			return norway.ListMunicipalitiesArgs{}
+		}),
+		"ListOrgForms": resourceMethod(h.norwayClient.ListOrgFormsMCP, func(map[string]string) norway.ListOrgFormsArgs {
+			return norway.ListOrgFormsArgs{}
+		}),
+		"NACERev2":  staticResource(func() any { return nace.Sections(nace.Rev2) }),
+		"NACERev21": staticResource(func() any { return nace.Sections(nace.Rev21) }),
+	}
+
+	if h.swedenClient != nil {
+		h.resources["SEGetCompany"] = resourceMethod(withLEI(h.leiIndex, h.swedenClient.GetCompanyMCP), func(v map[string]string) sweden.GetCompanyArgs {
+			return sweden.GetCompanyArgs{OrgNumber: v["org_number"]}
+		})
+	}
+	if h.nordicClient != nil {
+		h.resources["NordicListLegalForms"] = resourceMethod(h.nordicClient.ListLegalFormsMCP, func(map[string]string) nordic.ListLegalFormsArgs {
+			return nordic.ListLegalFormsArgs{}
+		})
+	}
+}
+
+// norwayFound turns the found=false answer norway_get_company gives for an
+// unknown organization number into a NotFoundError, so the resource reports
+// "resource not found" like the other countries.
+func norwayFound(method func(context.Context, norway.GetCompanyArgs) (norway.GetCompanyResult, error)) func(context.Context, norway.GetCompanyArgs) (norway.GetCompanyResult, error) {
+	return func(ctx context.Context, args norway.GetCompanyArgs) (norway.GetCompanyResult, error) {
+		res, err := method(ctx, args)
+		if err == nil && !res.Found {
+			return res, apierrors.NewNotFoundError("norway", args.OrgNumber)
+		}
+		return res, err
+	}
+}
+
+// registerResources adds every registered resource to the server and returns
+// how many were added.
+func (h *HandlerRegistry) registerResources(server *mcp.Server) int {
+	registered := 0
+	for _, spec := range h.RegisteredResources() {
+		read := h.resources[spec.Method]
+		handler := h.resourceHandler(spec, read)
+		if spec.Template {
+			server.AddResourceTemplate(&mcp.ResourceTemplate{
+				URITemplate: spec.URI,
+				Name:        spec.Name,
+				Title:       spec.Title,
+				Description: spec.Description,
+				MIMEType:    ResourceMIMEType,
+			}, handler)
+		} else {
+			server.AddResource(&mcp.Resource{
+				URI:         spec.URI,
+				Name:        spec.Name,
+				Title:       spec.Title,
+				Description: spec.Description,
+				MIMEType:    ResourceMIMEType,
+			}, handler)
+		}
+		registered++
+	}
+	return registered
+}
+
+// RegisteredResources returns the resources that have registered handlers and
+// pass the filter.
+func (h *HandlerRegistry) RegisteredResources() []ResourceSpec {
+	registered := make([]ResourceSpec, 0, len(AllResources))
+	for _, spec := range AllResources {
+		if _, ok := h.resources[spec.Method]; ok && h.filter.resourceEnabled(spec) {
+			registered = append(registered, spec)
+		}
+	}
+	return registered
+}
+
+// resourceHandler wraps read with the same timeout and panic recovery as
+// tool calls and renders its result as JSON. A company that does not exist
+// is reported as "resource not found" rather than as a failed read.
+func (h *HandlerRegistry) resourceHandler(spec ResourceSpec, read resourceFunc) mcp.ResourceHandler {
+	return func(ctx context.Context, req *mcp.ReadResourceRequest) (res *mcp.ReadResourceResult, err error) {
+		defer h.recoverPanic(spec.Name, &err)
+
+		ctx, cancel := context.WithTimeout(ctx, ToolTimeout)
+		defer cancel()
+
+		uri := req.Params.URI
+		vars := map[string]string{}
+		if spec.Template {
+			var ok bool
+			if vars, ok = matchURITemplate(spec.URI, uri); !ok {
+				return nil, mcp.ResourceNotFoundError(uri)
+			}
+		}
+
+		result, err := read(ctx, vars)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, mcp.ResourceNotFoundError(uri)
+			}
+			return nil, fmt.Errorf("%s failed: %w", spec.Name, err)
+		}
+
+		body, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("%s: encoding result: %w", spec.Name, err)
+		}
+		h.logger.Info("Resource read", "resource", spec.Name, "uri", uri)
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{{URI: uri, MIMEType: ResourceMIMEType, Text: string(body)}},
+		}, nil
+	}
+}
+
+// matchURITemplate extracts the variables of a template made of literal
+// path segments and whole-segment {name} expressions, the only form
+// AllResources uses. Variable values are percent-decoded.
+func matchURITemplate(template, uri string) (map[string]string, bool) {
+	tmplParts := strings.Split(template, "/")
+	uriParts := strings.Split(uri, "/")
+	if len(tmplParts) != len(uriParts) {
+		return nil, false
+	}
+	vars := make(map[string]string)
+	for i, t := range tmplParts {
+		if strings.HasPrefix(t, "{") && strings.HasSuffix(t, "}") {
+			v, err := url.PathUnescape(uriParts[i])
+			if err != nil || v == "" {
+				return nil, false
+			}
+			vars[t[1:len(t)-1]] = v
+			continue
+		}
+		if t != uriParts[i] {
+			return nil, false
+		}
+	}
+	return vars, true
+}
@@ -0,0 +1,177 @@
+package tools
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/denmark"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/finland"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/nordic"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/norway"
+)
+
+func TestResourceSpecs(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, spec := range AllResources {
+		t.Run(spec.Name, func(t *testing.T) {
+			if seen[spec.URI] {
+				t.Errorf("duplicate resource URI %q", spec.URI)
+			}
+			seen[spec.URI] = true
+			if !strings.HasPrefix(spec.URI, "nordic://") {
+				t.Errorf("URI %q should use the nordic scheme", spec.URI)
+			}
+			if spec.Template != strings.Contains(spec.URI, "{") {
+				t.Errorf("Template = %v but URI is %q", spec.Template, spec.URI)
+			}
+			if !spec.Template {
+				if _, err := url.Parse(spec.URI); err != nil {
+					t.Errorf("invalid URI %q: %v", spec.URI, err)
+				}
+			}
+			if spec.Name == "" || spec.Title == "" || spec.Description == "" || spec.Method == "" {
+				t.Errorf("resource %q has empty metadata", spec.URI)
+			}
+		})
+	}
+}
+
+func TestMatchURITemplate(t *testing.T) {
+	tests := []struct {
+		template, uri string
+		want          map[string]string
+	}{
+		{"nordic://no/company/{org_number}", "nordic://no/company/923609016", map[string]string{"org_number": "923609016"}},
+		{"nordic://fi/company/{business_id}", "nordic://fi/company/0112038-9", map[string]string{"business_id": "0112038-9"}},
+		{"nordic://no/company/{org_number}/roles", "nordic://no/company/923%20609%20016/roles", map[string]string{"org_number": "923 609 016"}},
+		{"nordic://no/company/{org_number}", "nordic://no/company/923609016/roles", nil},
+		{"nordic://no/company/{org_number}", "nordic://dk/company/10150817", nil},
+		{"nordic://no/company/{org_number}", "nordic://no/company/", nil},
+	}
+	for _, tt := range tests {
+		got, ok := matchURITemplate(tt.template, tt.uri)
+		if ok != (tt.want != nil) {
+			t.Errorf("matchURITemplate(%q, %q) ok = %v", tt.template, tt.uri, ok)
+			continue
+		}
+		for k, v := range tt.want {
+			if got[k] != v {
+				t.Errorf("matchURITemplate(%q, %q)[%s] = %q, want %q", tt.template, tt.uri, k, got[k], v)
+			}
+		}
+	}
+}
+
+func TestRegisteredResources(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	noClient := norway.NewClient(norway.WithLogger(logger))
+	defer noClient.Close()
+	dkClient := denmark.NewClient(denmark.WithLogger(logger))
+	defer dkClient.Close()
+	fiClient := finland.NewClient(finland.WithLogger(logger))
+	defer fiClient.Close()
+
+	without := NewHandlerRegistry(HandlerRegistryConfig{NorwayClient: noClient, DenmarkClient: dkClient, FinlandClient: fiClient, Logger: logger})
+	// Sweden and the cross-registry legal-form table are left out.
+	if got, want := len(without.RegisteredResources()), len(AllResources)-2; got != want {
+		t.Errorf("registered %d resources without Sweden and Nordic clients, want %d", got, want)
+	}
+
+	nordicClient := nordic.NewClient(nordic.Config{Norway: noClient, Denmark: dkClient, Finland: fiClient, Logger: logger})
+	with := NewHandlerRegistry(HandlerRegistryConfig{NorwayClient: noClient, DenmarkClient: dkClient, FinlandClient: fiClient, NordicClient: nordicClient, Logger: logger})
+	if got, want := len(with.RegisteredResources()), len(AllResources)-1; got != want {
+		t.Errorf("registered %d resources without Sweden, want %d", got, want)
+	}
+}
+
+func TestResourceRead(t *testing.T) {
+	mockServer := createMockNorwayServer()
+	defer mockServer.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	noClient := norway.NewClient(norway.WithLogger(logger), norway.WithBaseURL(mockServer.URL))
+	defer noClient.Close()
+	dkClient := denmark.NewClient(denmark.WithLogger(logger))
+	defer dkClient.Close()
+	fiClient := finland.NewClient(finland.WithLogger(logger))
+	defer fiClient.Close()
+
+	registry := NewHandlerRegistry(HandlerRegistryConfig{NorwayClient: noClient, DenmarkClient: dkClient, FinlandClient: fiClient, Logger: logger})
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "1.0.0"}, nil)
+	registry.RegisterAll(server)
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(context.Background(), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect server: %v", err)
+	}
+	defer serverSession.Close()
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect client: %v", err)
+	}
+	defer session.Close()
+	ctx := context.Background()
+
+	t.Run("list", func(t *testing.T) {
+		resources, err := session.ListResources(ctx, nil)
+		if err != nil {
+			t.Fatalf("ListResources failed: %v", err)
+		}
+		uris := make(map[string]bool)
+		for _, r := range resources.Resources {
+			uris[r.URI] = true
+		}
+		for _, want := range []string{"nordic://no/municipalities", "nordic://no/org-forms", "nordic://reference/nace-rev2"} {
+			if !uris[want] {
+				t.Errorf("resource %q not listed", want)
+			}
+		}
+
+		templates, err := session.ListResourceTemplates(ctx, nil)
+		if err != nil {
+			t.Fatalf("ListResourceTemplates failed: %v", err)
+		}
+		if len(templates.ResourceTemplates) != 4 {
+			t.Errorf("listed %d templates, want 4 (Sweden not configured)", len(templates.ResourceTemplates))
+		}
+	})
+
+	t.Run("company", func(t *testing.T) {
+		res, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "nordic://no/company/923609016"})
+		if err != nil {
+			t.Fatalf("ReadResource failed: %v", err)
+		}
+		if len(res.Contents) != 1 {
+			t.Fatalf("got %d contents, want 1", len(res.Contents))
+		}
+		c := res.Contents[0]
+		if c.MIMEType != ResourceMIMEType || !strings.Contains(c.Text, "EQUINOR ASA") {
+			t.Errorf("unexpected content %s: %s", c.MIMEType, c.Text)
+		}
+	})
+
+	t.Run("company not found", func(t *testing.T) {
+		_, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "nordic://no/company/974760673"})
+		if err == nil || !strings.Contains(strings.ToLower(err.Error()), "not found") {
+			t.Errorf("expected resource not found, got %v", err)
+		}
+	})
+
+	t.Run("static", func(t *testing.T) {
+		res, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "nordic://reference/nace-rev2"})
+		if err != nil {
+			t.Fatalf("ReadResource failed: %v", err)
+		}
+		if !strings.Contains(res.Contents[0].Text, "Information and communication") {
+			t.Error("NACE resource is missing section J")
+		}
+	})
+}
@@ -0,0 +1,277 @@
+package tools
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	apierrors "github.com/olgasafonova/nordic-registry-mcp-server/internal/errors"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/norway"
+)
+
+// DefaultResourcePollInterval is how often WatchResources checks subscribed
+// resources for changes.
+const DefaultResourcePollInterval = 5 * time.Minute
+
+const (
+	// updateFeedPageSize is the number of brreg feed entries fetched per request
+	updateFeedPageSize = 500
+
+	// maxUpdateFeedPages bounds the feed requests of one poll; a busier feed
+	// is picked up where it left off on the next poll
+	maxUpdateFeedPages = 10
+)
+
+// watchedResource is one subscribed URI and the sessions subscribed to it.
+type watchedResource struct {
+	spec     ResourceSpec
+	vars     map[string]string
+	sessions map[*mcp.ServerSession]bool
+	hash     [sha256.Size]byte // Content at the last check
+}
+
+// feedWatched reports whether changes to the resource are detected through
+// the brreg update feed rather than by re-fetching it.
+func (r *watchedResource) feedWatched() bool {
+	return r.spec.Method == "GetCompany"
+}
+
+// SubscribeResource is the resources/subscribe handler. Any registered
+// resource can be subscribed to; it is read once so that unknown companies
+// are rejected and the watcher has content to compare against.
+func (h *HandlerRegistry) SubscribeResource(ctx context.Context, req *mcp.SubscribeRequest) error {
+	uri := req.Params.URI
+	spec, vars, ok := h.lookupResource(uri)
+	if !ok {
+		return mcp.ResourceNotFoundError(uri)
+	}
+	hash, err := h.hashResource(ctx, spec, vars)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return mcp.ResourceNotFoundError(uri)
+		}
+		return fmt.Errorf("%s failed: %w", spec.Name, err)
+	}
+
+	h.subsMu.Lock()
+	defer h.subsMu.Unlock()
+	r, ok := h.subscriptions[uri]
+	if !ok {
+		r = &watchedResource{spec: spec, vars: vars, sessions: make(map[*mcp.ServerSession]bool), hash: hash}
+		h.subscriptions[uri] = r
+	}
+	r.sessions[req.Session] = true
+	h.logger.Info("Resource subscribed", "resource", spec.Name, "uri", uri, "subscribers", len(r.sessions))
+	return nil
+}
+
+// UnsubscribeResource is the resources/unsubscribe handler.
+func (h *HandlerRegistry) UnsubscribeResource(_ context.Context, req *mcp.UnsubscribeRequest) error {
+	h.subsMu.Lock()
+	defer h.subsMu.Unlock()
+	if r, ok := h.subscriptions[req.Params.URI]; ok {
+		delete(r.sessions, req.Session)
+		if len(r.sessions) == 0 {
+			delete(h.subscriptions, req.Params.URI)
+		}
+	}
+	return nil
+}
+
+// WatchResources reads the brreg update feed and checks subscribed resources
+// every interval, sending notifications/resources/updated for those that
+// changed, until ctx is cancelled. The feed is read whether or not anything
+// is subscribed, since it also keeps cached Norwegian records fresh for tool
+// calls. Norwegian company records are checked against the feed; every other
+// resource is re-fetched and compared by content hash, so its changes show
+// once the client cache entry has expired.
+func (h *HandlerRegistry) WatchResources(ctx context.Context, server *mcp.Server, interval time.Duration) {
+	h.checkUpdateFeed(ctx) // Start the feed cursor at startup
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.pollResources(ctx, server)
+		}
+	}
+}
+
+// pollResources runs one check of all subscriptions.
+func (h *HandlerRegistry) pollResources(ctx context.Context, server *mcp.Server) {
+	h.pruneSubscriptions(server)
+
+	// The feed goes first: it invalidates cached Norwegian records, so the
+	// content check below sees fresh roles for the same companies.
+	changed := h.checkUpdateFeed(ctx)
+	changed = append(changed, h.checkContent(ctx)...)
+
+	for _, uri := range changed {
+		if err := server.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{URI: uri}); err != nil {
+			h.logger.Warn("Failed to send resource update", "uri", uri, "error", err)
+		}
+	}
+}
+
+// pruneSubscriptions drops sessions that have disconnected. The SDK forgets
+// their subscriptions without calling UnsubscribeResource.
+func (h *HandlerRegistry) pruneSubscriptions(server *mcp.Server) {
+	live := make(map[*mcp.ServerSession]bool)
+	for ss := range server.Sessions() {
+		live[ss] = true
+	}
+
+	h.subsMu.Lock()
+	defer h.subsMu.Unlock()
+	for uri, r := range h.subscriptions {
+		for ss := range r.sessions {
+			if !live[ss] {
+				delete(r.sessions, ss)
+			}
+		}
+		if len(r.sessions) == 0 {
+			delete(h.subscriptions, uri)
+		}
+	}
+}
+
+// checkUpdateFeed reads the brreg update feed from where the last poll left
+// off, drops every company it reports from the client cache and returns the
+// subscribed company URIs among them. The first call only sets the cursor to
+// the current time: records cached before it were fetched after it.
+func (h *HandlerRegistry) checkUpdateFeed(ctx context.Context) []string {
+	if h.norwayClient == nil {
+		return nil
+	}
+	h.subsMu.Lock()
+	if h.feedCursor.IsZero() {
+		h.feedCursor = time.Now().UTC()
+		h.subsMu.Unlock()
+		return nil
+	}
+	watched := make(map[string][]string) // org number -> subscribed URIs
+	for uri, r := range h.subscriptions {
+		if r.feedWatched() {
+			org := norway.NormalizeOrgNumber(r.vars["org_number"])
+			watched[org] = append(watched[org], uri)
+		}
+	}
+	since, lastID := h.feedCursor, h.lastUpdateID
+	h.subsMu.Unlock()
+
+	var changed []string
+	seen := make(map[string]bool)
+	for page := 0; page < maxUpdateFeedPages; page++ {
+		resp, err := h.norwayClient.GetUpdates(ctx, since, &norway.UpdatesOptions{Size: updateFeedPageSize})
+		if err != nil {
+			h.logger.Warn("Failed to read brreg update feed", "since", since, "error", err)
+			break
+		}
+		updates := resp.Embedded.Updates
+		advanced := false
+		for _, u := range updates {
+			// The feed is inclusive of since, so entries at the cursor repeat
+			if u.UpdateID <= lastID {
+				continue
+			}
+			lastID, advanced = u.UpdateID, true
+			if u.UpdatedAt.After(since) {
+				since = u.UpdatedAt
+			}
+			org := u.OrganizationNumber
+			if seen[org] {
+				continue
+			}
+			seen[org] = true
+			// Tool calls share the cache, so unsubscribed companies are
+			// invalidated too rather than served stale until expiry.
+			h.norwayClient.InvalidateCompany(org)
+			changed = append(changed, watched[org]...)
+		}
+		if len(updates) < updateFeedPageSize || !advanced {
+			break
+		}
+	}
+
+	h.subsMu.Lock()
+	h.feedCursor, h.lastUpdateID = since, lastID
+	h.subsMu.Unlock()
+	return changed
+}
+
+// checkContent re-reads the resources not covered by the update feed and
+// returns those whose content hash changed.
+func (h *HandlerRegistry) checkContent(ctx context.Context) []string {
+	type check struct {
+		uri  string
+		spec ResourceSpec
+		vars map[string]string
+		hash [sha256.Size]byte
+	}
+	h.subsMu.Lock()
+	var checks []check
+	for uri, r := range h.subscriptions {
+		if !r.feedWatched() {
+			checks = append(checks, check{uri, r.spec, r.vars, r.hash})
+		}
+	}
+	h.subsMu.Unlock()
+
+	var changed []string
+	for _, c := range checks {
+		hash, err := h.hashResource(ctx, c.spec, c.vars)
+		if err != nil {
+			h.logger.Warn("Failed to re-read subscribed resource", "uri", c.uri, "error", err)
+			continue
+		}
+		if hash == c.hash {
+			continue
+		}
+		h.subsMu.Lock()
+		if r, ok := h.subscriptions[c.uri]; ok {
+			r.hash = hash
+		}
+		h.subsMu.Unlock()
+		changed = append(changed, c.uri)
+	}
+	return changed
+}
+
+// lookupResource finds the registered resource serving uri.
+func (h *HandlerRegistry) lookupResource(uri string) (ResourceSpec, map[string]string, bool) {
+	for _, spec := range h.RegisteredResources() {
+		if !spec.Template {
+			if spec.URI == uri {
+				return spec, map[string]string{}, true
+			}
+			continue
+		}
+		if vars, ok := matchURITemplate(spec.URI, uri); ok {
+			return spec, vars, true
+		}
+	}
+	return ResourceSpec{}, nil, false
+}
+
+// hashResource reads a resource and hashes its JSON encoding, the same
+// content a resources/read returns.
+func (h *HandlerRegistry) hashResource(ctx context.Context, spec ResourceSpec, vars map[string]string) ([sha256.Size]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, ToolTimeout)
+	defer cancel()
+
+	result, err := h.resources[spec.Method](ctx, vars)
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	body, err := json.Marshal(result)
+	if err != nil {
+		return [sha256.Size]byte{}, fmt.Errorf("%s: encoding result: %w", spec.Name, err)
+	}
+	return sha256.Sum256(body), nil
+}
@@ -0,0 +1,249 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/denmark"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/finland"
+	"github.com/olgasafonova/nordic-registry-mcp-server/internal/norway"
+)
+
+// mockBrreg serves one company, its roles and an update feed whose entries
+// and role names the test controls.
+type mockBrreg struct {
+	mu      sync.Mutex
+	updates []map[string]any
+	auditor string
+}
+
+func (m *mockBrreg) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+
+	switch {
+	case r.URL.Path == "/oppdateringer/enheter":
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"_embedded": map[string]any{"oppdaterteEnheter": m.updates},
+		})
+	case r.URL.Path == "/enheter/923609016/roller":
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"rollegrupper": []map[string]any{{
+				"type": map[string]string{"kode": "REVI", "beskrivelse": "Revisor"},
+				"roller": []map[string]any{{
+					"type":      map[string]string{"kode": "REVI", "beskrivelse": "Revisor"},
+					"enhet":     map[string]any{"organisasjonsnummer": "976389387", "navn": []string{m.auditor}},
+					"fratraadt": false,
+				}},
+			}},
+		})
+	case r.URL.Path == "/enheter/923609016":
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"organisasjonsnummer": "923609016",
+			"navn":                "EQUINOR ASA",
+			"organisasjonsform":   map[string]string{"kode": "ASA", "beskrivelse": "Allmennaksjeselskap"},
+		})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (m *mockBrreg) addUpdate(id int, orgNumber string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.updates = append(m.updates, map[string]any{
+		"oppdateringsid":      id,
+		"organisasjonsnummer": orgNumber,
+		"dato":                time.Now().UTC().Format(time.RFC3339Nano),
+		"endringstype":        "Endring",
+	})
+}
+
+func (m *mockBrreg) setAuditor(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.auditor = name
+}
+
+// setupSubscriptionTest connects a client that records resource update
+// notifications to a server backed by mock.
+func setupSubscriptionTest(t *testing.T, mock *mockBrreg) (*HandlerRegistry, *mcp.Server, *mcp.ClientSession, <-chan string) {
+	t.Helper()
+	mockServer := httptest.NewServer(mock)
+	t.Cleanup(mockServer.Close)
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	noClient := norway.NewClient(norway.WithLogger(logger), norway.WithBaseURL(mockServer.URL))
+	t.Cleanup(noClient.Close)
+	dkClient := denmark.NewClient(denmark.WithLogger(logger))
+	t.Cleanup(dkClient.Close)
+	fiClient := finland.NewClient(finland.WithLogger(logger))
+	t.Cleanup(fiClient.Close)
+
+	registry := NewHandlerRegistry(HandlerRegistryConfig{NorwayClient: noClient, DenmarkClient: dkClient, FinlandClient: fiClient, Logger: logger})
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "1.0.0"}, &mcp.ServerOptions{
+		Logger:             logger,
+		SubscribeHandler:   registry.SubscribeResource,
+		UnsubscribeHandler: registry.UnsubscribeResource,
+	})
+	registry.RegisterAll(server)
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(context.Background(), serverTransport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect server: %v", err)
+	}
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	updated := make(chan string, 10)
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, &mcp.ClientOptions{
+		ResourceUpdatedHandler: func(_ context.Context, req *mcp.ResourceUpdatedNotificationRequest) {
+			updated <- req.Params.URI
+		},
+	})
+	session, err := client.Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect client: %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	return registry, server, session, updated
+}
+
+// expectUpdates collects notifications until none arrive for a short while.
+func expectUpdates(t *testing.T, updated <-chan string, want ...string) {
+	t.Helper()
+	var got []string
+	for {
+		select {
+		case uri := <-updated:
+			got = append(got, uri)
+			continue
+		case <-time.After(200 * time.Millisecond):
+		}
+		break
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("resource updates = %v, want %v", got, want)
+	}
+}
+
+func TestSubscribeResource_UpdateFeed(t *testing.T) {
+	mock := &mockBrreg{auditor: "KPMG AS"}
+	registry, server, session, updated := setupSubscriptionTest(t, mock)
+	ctx := context.Background()
+
+	const uri = "nordic://no/company/923609016"
+	if err := session.Subscribe(ctx, &mcp.SubscribeParams{URI: uri}); err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	registry.pollResources(ctx, server)
+	expectUpdates(t, updated)
+
+	registry.norwayClient.Cache.Set("company:914778271", &norway.Company{OrganizationNumber: "914778271"}, time.Hour)
+	mock.addUpdate(101, "914778271") // Not subscribed
+	mock.addUpdate(102, "923609016")
+	registry.pollResources(ctx, server)
+	expectUpdates(t, updated, uri)
+	if _, ok := registry.norwayClient.Cache.Get("company:914778271"); ok {
+		t.Error("feed update left the unsubscribed company cached")
+	}
+
+	// The feed repeats entries at the cursor; they are not reported twice.
+	registry.pollResources(ctx, server)
+	expectUpdates(t, updated)
+
+	if err := session.Unsubscribe(ctx, &mcp.UnsubscribeParams{URI: uri}); err != nil {
+		t.Fatalf("Unsubscribe failed: %v", err)
+	}
+	mock.addUpdate(103, "923609016")
+	registry.pollResources(ctx, server)
+	expectUpdates(t, updated)
+}
+
+func TestSubscribeResource_ContentHash(t *testing.T) {
+	mock := &mockBrreg{auditor: "KPMG AS"}
+	registry, server, session, updated := setupSubscriptionTest(t, mock)
+	ctx := context.Background()
+
+	const uri = "nordic://no/company/923609016/roles"
+	if err := session.Subscribe(ctx, &mcp.SubscribeParams{URI: uri}); err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	registry.pollResources(ctx, server)
+	expectUpdates(t, updated)
+
+	mock.setAuditor("ERNST & YOUNG AS")
+	registry.norwayClient.InvalidateCompany("923609016") // As if the cache entry expired
+	registry.pollResources(ctx, server)
+	expectUpdates(t, updated, uri)
+
+	registry.pollResources(ctx, server)
+	expectUpdates(t, updated)
+}
+
+// TestSubscribeResource_Rejected calls the handler directly: at protocol
+// 2026-07-28 ClientSession.Subscribe opens a subscriptions/listen stream in
+// the background and does not return the server's error.
+func TestSubscribeResource_Rejected(t *testing.T) {
+	mock := &mockBrreg{}
+	registry, _, _, _ := setupSubscriptionTest(t, mock)
+	ctx := context.Background()
+
+	for _, uri := range []string{
+		"nordic://no/company/974760673", // Unknown to the registry
+		"nordic://xx/company/1",         // No such resource
+	} {
+		err := registry.SubscribeResource(ctx, &mcp.SubscribeRequest{Params: &mcp.SubscribeParams{URI: uri}})
+		if err == nil || !strings.Contains(strings.ToLower(err.Error()), "not found") {
+			t.Errorf("Subscribe(%s) = %v, want resource not found", uri, err)
+		}
+	}
+}
+
+func TestPruneSubscriptions(t *testing.T) {
+	mock := &mockBrreg{}
+	registry, server, session, _ := setupSubscriptionTest(t, mock)
+	ctx := context.Background()
+
+	if err := session.Subscribe(ctx, &mcp.SubscribeParams{URI: "nordic://no/company/923609016"}); err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	for ss := range server.Sessions() {
+		_ = ss.Close()
+	}
+	registry.pollResources(ctx, server)
+
+	registry.subsMu.Lock()
+	defer registry.subsMu.Unlock()
+	if len(registry.subscriptions) != 0 {
+		t.Errorf("subscriptions of a closed session were kept: %d", len(registry.subscriptions))
+	}
+}
+
+func TestCheckUpdateFeed_WithoutSubscriptions(t *testing.T) {
+	mock := &mockBrreg{}
+	registry, server, _, updated := setupSubscriptionTest(t, mock)
+	ctx := context.Background()
+
+	registry.pollResources(ctx, server) // Sets the cursor
+	registry.norwayClient.Cache.Set("company:923609016", &norway.Company{OrganizationNumber: "923609016"}, time.Hour)
+	mock.addUpdate(101, "923609016")
+	registry.pollResources(ctx, server)
+	expectUpdates(t, updated)
+	if _, ok := registry.norwayClient.Cache.Get("company:923609016"); ok {
+		t.Error("feed update left the company cached with nothing subscribed")
+	}
+}